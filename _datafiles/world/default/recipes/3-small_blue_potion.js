@@ -0,0 +1,12 @@
+
+// Called after the potion is successfully crafted
+function onCraft(actor, item, room) {
+
+    // Skilled casters can stretch the mixture into an extra dose
+    if ( actor.GetSkillLevel('cast') >= 3 ) {
+        item.AddUsesLeft(1);
+        SendUserMessage(actor.UserId(), 'Your practiced hand stretches the mixture into an extra dose.');
+    }
+
+    return true;
+}
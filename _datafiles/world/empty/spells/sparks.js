@@ -18,7 +18,7 @@ function onWait(sourceActor, targetActors) {
 }
 
 // Called when the spell succeeds its cast attempt
-function onMagic(sourceActor, targetActors) {
+function onMagic(sourceActor, targetActors, element) {
 
     roomId = sourceActor.GetRoomId();
 
@@ -27,7 +27,8 @@ function onMagic(sourceActor, targetActors) {
 
     for (var i = 0; i < targetActors.length; i++) {
         
-        dmgAmt = UtilDiceRoll(DMG_DICE_QTY, DMG_DICE_SIDES) + 1;
+        // Resistances to the spell's element can reduce (or increase) the damage done
+        dmgAmt = targetActors[i].TakeElementalDamage(UtilDiceRoll(DMG_DICE_QTY, DMG_DICE_SIDES) + 1, element);
         dmgAmtStr = String(dmgAmt);
 
         targetUserId = targetActors[i].UserId();
@@ -53,9 +54,6 @@ function onMagic(sourceActor, targetActors) {
             SendRoomMessage(roomId, sourceName+' stops chanting and fires a shower of sparks at themselves, hurting themselves.', sourceUserId, targetUserId);
 
         }
-
-        // Apply the dmg to the target
-        targetActors[i].AddHealth(dmgAmt * -1);
     }
     
 }
@@ -127,6 +127,9 @@ func (bs *Buffs) HasFlag(action Flag, expire bool) bool {
 	found := false
 	for index, b := range bs.List {
 		bSpec := GetBuffSpec(b.BuffId)
+		if bSpec == nil {
+			continue
+		}
 		for _, p := range bSpec.Flags {
 
 			if b.Expired() {
@@ -0,0 +1,98 @@
+package buffs
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+)
+
+func CreateNewBuffFile(newBuffInfo BuffSpec) (int, error) {
+
+	if _, ok := buffs[newBuffInfo.BuffId]; ok {
+		return 0, fmt.Errorf(`buff %d already exists`, newBuffInfo.BuffId)
+	}
+
+	if err := newBuffInfo.Validate(); err != nil {
+		return 0, err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*BuffSpec](configs.GetFilePathsConfig().DataFiles.String()+`/buffs`, &newBuffInfo, saveModes...); err != nil {
+		return 0, err
+	}
+
+	// Save to in-memory cache
+	buffs[newBuffInfo.Id()] = &newBuffInfo
+
+	return newBuffInfo.BuffId, nil
+}
+
+// Replaces an existing buff spec, saving it to its file and updating the in-memory cache.
+// If a new name changes the filename, the old file is removed and any script follows it.
+func SaveBuffSpec(buffInfo BuffSpec) error {
+
+	oldBuffInfo, ok := buffs[buffInfo.BuffId]
+	if !ok {
+		return fmt.Errorf(`buff %d does not exist`, buffInfo.BuffId)
+	}
+
+	if err := buffInfo.Validate(); err != nil {
+		return err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	basePath := configs.GetFilePathsConfig().DataFiles.String() + `/buffs`
+
+	if err := fileloader.SaveFlatFile[*BuffSpec](basePath, &buffInfo, saveModes...); err != nil {
+		return err
+	}
+
+	if oldBuffInfo.Filepath() != buffInfo.Filepath() {
+
+		if err := fileloader.DeleteFlatFile[*BuffSpec](basePath, oldBuffInfo); err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(oldBuffInfo.GetScriptPath()); err == nil {
+			os.Rename(oldBuffInfo.GetScriptPath(), buffInfo.GetScriptPath())
+		}
+	}
+
+	buffs[buffInfo.Id()] = &buffInfo
+
+	return nil
+}
+
+// Removes a buff spec (and any script) from disk and memory.
+// Buffs already applied will expire without effect.
+func DeleteBuffSpec(buffId int) error {
+
+	buffInfo, ok := buffs[buffId]
+	if !ok {
+		return fmt.Errorf(`buff %d does not exist`, buffId)
+	}
+
+	if err := fileloader.DeleteFlatFile[*BuffSpec](configs.GetFilePathsConfig().DataFiles.String()+`/buffs`, buffInfo); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(buffInfo.GetScriptPath()); err == nil {
+		os.Remove(buffInfo.GetScriptPath())
+	}
+
+	delete(buffs, buffId)
+
+	return nil
+}
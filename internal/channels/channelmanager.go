@@ -0,0 +1,219 @@
+package channels
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+const (
+	MaxOwnedChannels = 3 // How many channels a single user can create
+)
+
+var (
+	channels = map[string]*Channel{} // key is the lowercase channel name
+	deleted  = []string{}            // Filepaths of deleted channels waiting to be removed
+
+	// Always exist. Membership, mutes and bans are still saved like any other channel.
+	builtInChannels = []Channel{
+		{Name: `ooc`, Description: `Out of character chat`, AutoJoin: true},
+		{Name: `newbie`, Description: `Questions and help for new players`, AutoJoin: true},
+		{Name: `trade`, Description: `Buying, selling and trading`, AutoJoin: true},
+		{Name: `admin`, Description: `Staff chat`, StaffOnly: true},
+	}
+)
+
+func channelsPath() string {
+	return util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/`, `channels`)
+}
+
+// Loads all channels from the channels folder (a sibling of the users folder)
+// and makes sure the built-in channels exist.
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	clear(channels)
+
+	basePath := channelsPath()
+	if _, err := os.Stat(basePath); err == nil {
+
+		tmpChannels, err := fileloader.LoadAllFlatFiles[string, *Channel](basePath)
+		if err != nil {
+			panic(err)
+		}
+
+		channels = tmpChannels
+	}
+
+	loadedCount := len(channels)
+
+	addBuiltInChannels()
+
+	mudlog.Info("channels.LoadDataFiles()", "loadedCount", loadedCount, "Time Taken", time.Since(start))
+}
+
+// Built-in settings always come from the code, in case they have changed since the channel was saved
+func addBuiltInChannels() {
+
+	for _, b := range builtInChannels {
+
+		c, ok := channels[b.Name]
+		if !ok {
+			c = &Channel{Name: b.Name, Created: time.Now()}
+			channels[b.Name] = c
+		}
+
+		c.Description = b.Description
+		c.BuiltIn = true
+		c.AutoJoin = b.AutoJoin
+		c.StaffOnly = b.StaffOnly
+		c.OwnerId = 0
+		c.OwnerName = ``
+		c.Password = ``
+	}
+}
+
+func SaveAllChannels() error {
+
+	start := time.Now()
+
+	basePath := channelsPath()
+
+	for _, fPath := range deleted {
+		if err := os.Remove(filepath.Join(basePath, fPath)); err != nil && !os.IsNotExist(err) {
+			mudlog.Error("SaveAllChannels()", "error", err.Error())
+		}
+	}
+	deleted = deleted[:0]
+
+	if len(channels) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(basePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	saveCt, err := fileloader.SaveAllFlatFiles[string, *Channel](basePath, channels, saveModes...)
+
+	mudlog.Info("SaveAllChannels()", "savedCount", saveCt, "expectedCt", len(channels), "Time Taken", time.Since(start))
+
+	return err
+}
+
+func Get(name string) *Channel {
+	if c, ok := channels[strings.ToLower(name)]; ok {
+		return c
+	}
+	return nil
+}
+
+func GetAll() []*Channel {
+	ret := make([]*Channel, 0, len(channels))
+	for _, c := range channels {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].BuiltIn != ret[j].BuiltIn {
+			return ret[i].BuiltIn
+		}
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+// Returns every channel the user is listening to
+func GetMemberships(userId int, isStaff bool) []*Channel {
+	ret := []*Channel{}
+	for _, c := range GetAll() {
+		if c.IsMember(userId, isStaff) {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Returns every channel the user owns
+func GetOwned(userId int) []*Channel {
+	ret := []*Channel{}
+	for _, c := range GetAll() {
+		if c.OwnerId == userId {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Creates a new channel with the owner as its only member.
+// An empty password leaves the channel open for anyone to join.
+func Create(name string, ownerId int, ownerName string, password string) (*Channel, error) {
+
+	name = strings.ToLower(name)
+
+	if err := ValidateChannelName(name); err != nil {
+		return nil, err
+	}
+
+	if Get(name) != nil {
+		return nil, ErrChannelExists
+	}
+
+	if len(GetOwned(ownerId)) >= MaxOwnedChannels {
+		return nil, ErrTooManyChannels
+	}
+
+	c := &Channel{
+		Name:      name,
+		OwnerId:   ownerId,
+		OwnerName: ownerName,
+		Created:   time.Now(),
+		Members:   []int{ownerId},
+	}
+
+	if err := c.SetPassword(password); err != nil {
+		return nil, err
+	}
+
+	channels[c.Id()] = c
+
+	// In case a channel by this name was deleted and not yet cleaned up
+	for i, fPath := range deleted {
+		if fPath == c.Filepath() {
+			deleted = append(deleted[:i], deleted[i+1:]...)
+			break
+		}
+	}
+
+	return c, nil
+}
+
+// Deletes a player created channel
+func Delete(name string) error {
+
+	c := Get(name)
+	if c == nil {
+		return ErrChannelNotFound
+	}
+
+	if c.BuiltIn {
+		return ErrBuiltIn
+	}
+
+	delete(channels, c.Id())
+	deleted = append(deleted, c.Filepath())
+
+	return nil
+}
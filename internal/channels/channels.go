@@ -0,0 +1,250 @@
+package channels
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	ChannelNameSizeMin = 3
+	ChannelNameSizeMax = 16
+	maxHistory         = 20 // How many recent messages each channel remembers
+)
+
+var (
+	ErrChannelNameInvalid = errors.New(`channel names must be 3-16 letters, numbers or dashes, starting with a letter`)
+	ErrChannelExists      = errors.New(`a channel with that name already exists`)
+	ErrChannelNotFound    = errors.New(`channel not found`)
+	ErrTooManyChannels    = errors.New(`you already own as many channels as you are allowed`)
+	ErrBuiltIn            = errors.New(`built-in channels cannot be changed`)
+	ErrNotMember          = errors.New(`not a member of that channel`)
+	ErrAlreadyMember      = errors.New(`already a member of that channel`)
+	ErrWrongPassword      = errors.New(`incorrect channel password`)
+	ErrBanned             = errors.New(`banned from that channel`)
+	ErrStaffOnly          = errors.New(`that channel is only for staff`)
+
+	channelNameRegex = regexp.MustCompile(`^[a-z][a-z0-9\-]*$`)
+)
+
+type Channel struct {
+	Name        string    `yaml:"name"`                // Lowercase name, also used to talk on the channel such as "ooc"
+	Description string    `yaml:"description"`         // Short description shown in the channel list
+	OwnerId     int       `yaml:"ownerid,omitempty"`   // User ID of the owner. Zero for built-in channels
+	OwnerName   string    `yaml:"ownername,omitempty"` // Character name of the owner when the channel was created
+	Password    string    `yaml:"password,omitempty"`  // Salted hash of the password needed to join, if any
+	Created     time.Time `yaml:"created"`             // When the channel was created
+	BuiltIn     bool      `yaml:"builtin,omitempty"`   // Built-in channels can't be deleted or given a password
+	AutoJoin    bool      `yaml:"autojoin,omitempty"`  // Everyone is a member unless they have left
+	StaffOnly   bool      `yaml:"staffonly,omitempty"` // Only admins and moderators can join, and they are joined automatically
+	Members     []int     `yaml:"members,omitempty"`   // User IDs that have joined (channels that are not auto-join)
+	Left        []int     `yaml:"left,omitempty"`      // User IDs that have left (auto-join channels)
+	Muted       []int     `yaml:"muted,omitempty"`     // User IDs that can listen but not talk
+	Banned      []int     `yaml:"banned,omitempty"`    // User IDs that can't join or listen
+
+	history []HistoryEntry // Recent messages, not saved
+}
+
+type HistoryEntry struct {
+	Name    string
+	Message string
+	Time    time.Time
+}
+
+func (c *Channel) Id() string {
+	return c.Name
+}
+
+func (c *Channel) Filepath() string {
+	return util.ConvertForFilename(c.Id()) + `.yaml`
+}
+
+func (c *Channel) Validate() error {
+
+	c.Name = strings.ToLower(c.Name)
+
+	if err := ValidateChannelName(c.Name); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func ValidateChannelName(name string) error {
+	if len(name) < ChannelNameSizeMin || len(name) > ChannelNameSizeMax {
+		return ErrChannelNameInvalid
+	}
+	if !channelNameRegex.MatchString(name) {
+		return ErrChannelNameInvalid
+	}
+	return nil
+}
+
+// Whether a user is currently listening to the channel
+func (c *Channel) IsMember(userId int, isStaff bool) bool {
+
+	if c.IsBanned(userId) {
+		return false
+	}
+
+	if c.StaffOnly && !isStaff {
+		return false
+	}
+
+	if c.AutoJoin || c.StaffOnly {
+		return !slices.Contains(c.Left, userId)
+	}
+
+	return slices.Contains(c.Members, userId)
+}
+
+// Joins the channel. Staff and the owner don't need the password.
+func (c *Channel) Join(userId int, password string, isStaff bool) error {
+
+	if c.IsBanned(userId) {
+		return ErrBanned
+	}
+
+	if c.StaffOnly && !isStaff {
+		return ErrStaffOnly
+	}
+
+	if c.IsMember(userId, isStaff) {
+		return ErrAlreadyMember
+	}
+
+	if c.Password != `` && !isStaff && userId != c.OwnerId {
+		if bcrypt.CompareHashAndPassword([]byte(c.Password), []byte(password)) != nil {
+			return ErrWrongPassword
+		}
+	}
+
+	if c.AutoJoin || c.StaffOnly {
+		c.Left = removeId(c.Left, userId)
+		return nil
+	}
+
+	c.Members = append(c.Members, userId)
+
+	return nil
+}
+
+func (c *Channel) Leave(userId int, isStaff bool) error {
+
+	if !c.IsMember(userId, isStaff) {
+		return ErrNotMember
+	}
+
+	if c.AutoJoin || c.StaffOnly {
+		c.Left = append(c.Left, userId)
+		return nil
+	}
+
+	c.Members = removeId(c.Members, userId)
+
+	return nil
+}
+
+// Sets or clears (with an empty string) the join password
+func (c *Channel) SetPassword(password string) error {
+
+	if c.BuiltIn {
+		return ErrBuiltIn
+	}
+
+	if password == `` {
+		c.Password = ``
+		return nil
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	c.Password = string(hash)
+
+	return nil
+}
+
+func (c *Channel) HasPassword() bool {
+	return c.Password != ``
+}
+
+// Owners and staff can mute, ban and change the password
+func (c *Channel) CanModerate(userId int, isStaff bool) bool {
+	return isStaff || (c.OwnerId > 0 && c.OwnerId == userId)
+}
+
+func (c *Channel) IsMuted(userId int) bool {
+	return slices.Contains(c.Muted, userId)
+}
+
+// Returns false if the user was already muted
+func (c *Channel) Mute(userId int) bool {
+	if c.IsMuted(userId) {
+		return false
+	}
+	c.Muted = append(c.Muted, userId)
+	return true
+}
+
+// Returns false if the user wasn't muted
+func (c *Channel) Unmute(userId int) bool {
+	if !c.IsMuted(userId) {
+		return false
+	}
+	c.Muted = removeId(c.Muted, userId)
+	return true
+}
+
+func (c *Channel) IsBanned(userId int) bool {
+	return slices.Contains(c.Banned, userId)
+}
+
+// Bans a user, removing them from the channel.
+// Returns false if the user was already banned
+func (c *Channel) Ban(userId int) bool {
+	if c.IsBanned(userId) {
+		return false
+	}
+	c.Banned = append(c.Banned, userId)
+	c.Members = removeId(c.Members, userId)
+	return true
+}
+
+// Lifts a ban. Users must join non auto-join channels again themselves.
+// Returns false if the user wasn't banned
+func (c *Channel) Unban(userId int) bool {
+	if !c.IsBanned(userId) {
+		return false
+	}
+	c.Banned = removeId(c.Banned, userId)
+	return true
+}
+
+func (c *Channel) AddHistory(name string, message string) {
+	c.history = append(c.history, HistoryEntry{
+		Name:    name,
+		Message: message,
+		Time:    time.Now(),
+	})
+	if len(c.history) > maxHistory {
+		c.history = c.history[len(c.history)-maxHistory:]
+	}
+}
+
+// Returns a copy of the recent messages, oldest first
+func (c *Channel) GetHistory() []HistoryEntry {
+	return append([]HistoryEntry{}, c.history...)
+}
+
+func removeId(ids []int, userId int) []int {
+	return slices.DeleteFunc(ids, func(id int) bool {
+		return id == userId
+	})
+}
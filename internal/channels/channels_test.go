@@ -0,0 +1,100 @@
+package channels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetChannels() {
+	clear(channels)
+	deleted = deleted[:0]
+	addBuiltInChannels()
+}
+
+func TestValidateChannelName(t *testing.T) {
+	assert.NoError(t, ValidateChannelName(`ooc`))
+	assert.NoError(t, ValidateChannelName(`role-play2`))
+	assert.Error(t, ValidateChannelName(`ab`))
+	assert.Error(t, ValidateChannelName(`waytoolongchannelname`))
+	assert.Error(t, ValidateChannelName(`2fast`))
+	assert.Error(t, ValidateChannelName(`no spaces`))
+}
+
+func TestBuiltInChannels(t *testing.T) {
+	resetChannels()
+
+	ooc := Get(`OOC`)
+	assert.NotNil(t, ooc)
+	assert.True(t, ooc.IsMember(5, false), "Expected everyone to start on auto-join channels")
+
+	assert.NoError(t, ooc.Leave(5, false))
+	assert.False(t, ooc.IsMember(5, false))
+	assert.ErrorIs(t, ooc.Leave(5, false), ErrNotMember)
+	assert.NoError(t, ooc.Join(5, ``, false))
+	assert.True(t, ooc.IsMember(5, false))
+
+	admin := Get(`admin`)
+	assert.False(t, admin.IsMember(5, false))
+	assert.True(t, admin.IsMember(1, true))
+	assert.ErrorIs(t, admin.Join(5, ``, false), ErrStaffOnly)
+
+	assert.ErrorIs(t, Delete(`ooc`), ErrBuiltIn)
+	assert.ErrorIs(t, ooc.SetPassword(`secret`), ErrBuiltIn)
+}
+
+func TestCreateJoinModerate(t *testing.T) {
+	resetChannels()
+
+	c, err := Create(`Guild-Chat`, 1, `Owner`, `secret`)
+	assert.NoError(t, err)
+	assert.Equal(t, `guild-chat`, c.Name)
+	assert.True(t, c.IsMember(1, false))
+	assert.NotEqual(t, `secret`, c.Password, "Expected the password to be hashed")
+
+	_, err = Create(`guild-chat`, 2, `Other`, ``)
+	assert.ErrorIs(t, err, ErrChannelExists)
+
+	assert.ErrorIs(t, c.Join(2, `wrong`, false), ErrWrongPassword)
+	assert.NoError(t, c.Join(2, `secret`, false))
+	assert.ErrorIs(t, c.Join(2, `secret`, false), ErrAlreadyMember)
+	assert.NoError(t, c.Join(3, ``, true), "Expected staff not to need the password")
+
+	assert.True(t, c.CanModerate(1, false))
+	assert.False(t, c.CanModerate(2, false))
+	assert.True(t, c.CanModerate(2, true))
+
+	assert.True(t, c.Mute(2))
+	assert.False(t, c.Mute(2))
+	assert.True(t, c.IsMuted(2))
+	assert.True(t, c.IsMember(2, false), "Expected muted users to keep listening")
+	assert.True(t, c.Unmute(2))
+
+	assert.True(t, c.Ban(2))
+	assert.False(t, c.IsMember(2, false))
+	assert.ErrorIs(t, c.Join(2, `secret`, false), ErrBanned)
+	assert.True(t, c.Unban(2))
+	assert.False(t, c.IsMember(2, false))
+
+	assert.Equal(t, []*Channel{c}, GetOwned(1))
+	assert.NoError(t, Delete(`guild-chat`))
+	assert.Nil(t, Get(`guild-chat`))
+	assert.Equal(t, []string{`guild_chat.yaml`}, deleted)
+}
+
+func TestOwnedLimitAndHistory(t *testing.T) {
+	resetChannels()
+
+	for _, name := range []string{`one`, `two`, `three`} {
+		_, err := Create(name, 1, `Owner`, ``)
+		assert.NoError(t, err)
+	}
+	_, err := Create(`four`, 1, `Owner`, ``)
+	assert.ErrorIs(t, err, ErrTooManyChannels)
+
+	c := Get(`one`)
+	for i := 0; i < maxHistory+5; i++ {
+		c.AddHistory(`Owner`, `hello`)
+	}
+	assert.Len(t, c.GetHistory(), maxHistory)
+}
@@ -10,13 +10,57 @@ import (
 	"gopkg.in/yaml.v2"
 )
 
+// Replaces the yaml files alts are normally kept in, such as with a database
+type AltStorage interface {
+	LoadAlts(userId int) ([]Character, error)
+	SaveAlts(userId int, alts []Character) error
+}
+
+var (
+	altStorage AltStorage = nil
+)
+
+// Sets where alts are loaded from and saved to. nil restores the default of yaml files.
+func SetAltStorage(s AltStorage) {
+	altStorage = s
+}
+
+func LoadAlts(userId int) []Character {
+
+	if altStorage == nil {
+		return LoadAltsFile(userId)
+	}
+
+	alts, err := altStorage.LoadAlts(userId)
+	if err != nil {
+		mudlog.Error("LoadAlts", "error", err.Error())
+		return nil
+	}
+
+	return alts
+}
+
+func SaveAlts(userId int, alts []Character) bool {
+
+	if altStorage == nil {
+		return SaveAltsFile(userId, alts)
+	}
+
+	if err := altStorage.SaveAlts(userId, alts); err != nil {
+		mudlog.Error("SaveAlts", "error", err.Error())
+		return false
+	}
+
+	return true
+}
+
 func AltsExists(userId int) bool {
 	_, err := os.Stat(util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/users/`, strconv.Itoa(userId)+`.alts.yaml`))
 
 	return !os.IsNotExist(err)
 }
 
-func LoadAlts(userId int) []Character {
+func LoadAltsFile(userId int) []Character {
 
 	if !AltsExists(userId) {
 		return nil
@@ -40,7 +84,7 @@ func LoadAlts(userId int) []Character {
 
 }
 
-func SaveAlts(userId int, alts []Character) bool {
+func SaveAltsFile(userId int, alts []Character) bool {
 
 	fileWritten := false
 	tmpSaved := false
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/clans"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/items"
@@ -220,6 +221,18 @@ func (c *Character) CarryCapacity() int {
 	return 5 + int(math.Floor(float64(c.Stats.Strength.ValueAdj/3)))
 }
 
+func (c *Character) IsEncumbered() bool {
+	return len(c.Items) > c.CarryCapacity()
+}
+
+// How many action points it takes to move to another room
+func (c *Character) MoveActionCost() int {
+	if c.IsEncumbered() {
+		return 50
+	}
+	return 10
+}
+
 func (c *Character) DeductActionPoints(amount int) bool {
 
 	if c.ActionPoints < amount {
@@ -422,6 +435,9 @@ func (c *Character) TrackSpellCast(spellName string) bool {
 func (c *Character) LearnSpell(spellName string) bool {
 	if _, ok := c.SpellBook[spellName]; !ok {
 		c.SpellBook[spellName] = 1
+		if c.userId > 0 {
+			events.AddToQueue(events.CharacterTrained{UserId: c.userId})
+		}
 		return true
 	}
 	return false
@@ -705,6 +721,10 @@ func (c *Character) getFormattedName(viewingUserId int, uType string, renderFlag
 		f.PetName = c.Pet.DisplayName()
 	}
 
+	if c.userId > 0 {
+		f.ClanTag = clans.GetClanTag(c.userId, c.Name)
+	}
+
 	return f
 }
 
@@ -744,7 +764,17 @@ func (c *Character) TryCooldown(trackingTag string, cooldownTime string) bool {
 		c.Cooldowns = make(Cooldowns)
 	}
 
-	return c.Cooldowns.Try(trackingTag, cooldownTime)
+	before := c.Cooldowns[trackingTag]
+
+	if !c.Cooldowns.Try(trackingTag, cooldownTime) {
+		return false
+	}
+
+	if c.userId > 0 && c.Cooldowns[trackingTag] != before {
+		events.AddToQueue(events.CharacterCooldownsChanged{UserId: c.userId})
+	}
+
+	return true
 }
 
 func (c *Character) SetSetting(settingName string, settingValue string) {
@@ -835,6 +865,18 @@ func (c *Character) UpdateItem(originalItm items.Item, replacement items.Item) b
 	return false
 }
 
+// Fully repairs an item in the backpack or worn on the body
+// Returns true if the item was found
+func (c *Character) RepairItem(i items.Item) bool {
+	for j := len(c.Items) - 1; j >= 0; j-- {
+		if c.Items[j].Equals(i) {
+			c.Items[j].Repair()
+			return true
+		}
+	}
+	return c.Equipment.Repair(i)
+}
+
 func (c *Character) UseItem(i items.Item) int {
 	for j := len(c.Items) - 1; j >= 0; j-- {
 		if c.Items[j].Equals(i) {
@@ -920,10 +962,13 @@ func (c *Character) SetSkill(skillName string, level int) {
 
 	if level == 0 {
 		delete(c.Skills, skillName)
-		return
+	} else {
+		c.Skills[skillName] = level
 	}
 
-	c.Skills[skillName] = level
+	if c.userId > 0 {
+		events.AddToQueue(events.CharacterTrained{UserId: c.userId})
+	}
 }
 
 // Increases the skill training counter and returns the new value
@@ -954,6 +999,10 @@ func (c *Character) TrainSkill(skillName string, targetLevel ...int) int {
 
 	c.Skills[skillName] = skillLevel
 
+	if c.userId > 0 {
+		events.AddToQueue(events.CharacterTrained{UserId: c.userId})
+	}
+
 	return skillLevel
 }
 
@@ -1346,7 +1395,17 @@ func (c *Character) MovementCost() int {
 }
 
 func (c *Character) StatMod(statName string) int {
-	return c.Equipment.StatMod(statName) + c.Buffs.StatMod(statName) + c.Pet.StatMod(statName)
+	total := c.Equipment.StatMod(statName) + c.Buffs.StatMod(statName) + c.Pet.StatMod(statName)
+	if raceInfo := races.GetRace(c.RaceId); raceInfo != nil {
+		total += raceInfo.StatMods.Get(statName)
+	}
+	return total
+}
+
+// Percent of damage of an element that is resisted.
+// Negative values are a vulnerability.
+func (c *Character) GetResistance(element items.Element) int {
+	return c.StatMod(element.ResistStatName())
 }
 
 // returns true if something has changed.
@@ -6,10 +6,18 @@ import (
 
 type Cooldowns map[string]int
 
-func (cd Cooldowns) RoundTick() {
+// Counts down all cooldowns by a round, removing any that run out.
+// Returns true if any ran out.
+func (cd Cooldowns) RoundTick() bool {
+	expired := false
 	for trackingTag, _ := range cd {
 		cd[trackingTag] = cd[trackingTag] - 1
+		if cd[trackingTag] <= 0 {
+			delete(cd, trackingTag)
+			expired = true
+		}
 	}
+	return expired
 }
 
 func (cd Cooldowns) Prune() {
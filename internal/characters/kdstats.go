@@ -11,6 +11,11 @@ type KDStats struct {
 	PlayerKills    map[string]int `json:"playerkills,omitempty"`    // map of userid:username to count
 	PlayerDeaths   map[string]int `json:"playerdeaths,omitempty"`   // map of userid:username to count
 	TotalPvpDeaths int            `json:"totalpvpdeaths,omitempty"` // Quick tally of pvp deaths
+
+	TotalDuelWins   int            `json:"totalduelwins,omitempty"`   // Quick tally of duels won
+	TotalDuelLosses int            `json:"totalduellosses,omitempty"` // Quick tally of duels lost
+	DuelWins        map[string]int `json:"duelwins,omitempty"`        // map of userid:username to count
+	DuelLosses      map[string]int `json:"duellosses,omitempty"`      // map of userid:username to count
 }
 
 func (kd *KDStats) GetMobKDRatio() float64 {
@@ -27,6 +32,13 @@ func (kd *KDStats) GetPvpKDRatio() float64 {
 	return float64(kd.TotalPvpKills) / float64(kd.TotalPvpDeaths)
 }
 
+func (kd *KDStats) GetDuelRatio() float64 {
+	if kd.TotalDuelLosses == 0 {
+		return float64(kd.TotalDuelWins)
+	}
+	return float64(kd.TotalDuelWins) / float64(kd.TotalDuelLosses)
+}
+
 func (kd *KDStats) GetMobKills(mobId ...int) int {
 	if len(mobId) == 0 {
 		return kd.TotalKills
@@ -86,3 +98,25 @@ func (kd *KDStats) AddMobDeath() {
 func (kd *KDStats) AddPvpDeath() {
 	kd.TotalPvpDeaths++
 }
+
+func (kd *KDStats) AddDuelWin(opponentUserId int, opponentCharName string) {
+	if kd.DuelWins == nil {
+		kd.DuelWins = make(map[string]int)
+	}
+
+	keyName := fmt.Sprintf(`%d:%s`, opponentUserId, opponentCharName)
+
+	kd.TotalDuelWins++
+	kd.DuelWins[keyName] = kd.DuelWins[keyName] + 1
+}
+
+func (kd *KDStats) AddDuelLoss(opponentUserId int, opponentCharName string) {
+	if kd.DuelLosses == nil {
+		kd.DuelLosses = make(map[string]int)
+	}
+
+	keyName := fmt.Sprintf(`%d:%s`, opponentUserId, opponentCharName)
+
+	kd.TotalDuelLosses++
+	kd.DuelLosses[keyName] = kd.DuelLosses[keyName] + 1
+}
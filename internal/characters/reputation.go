@@ -0,0 +1,113 @@
+package characters
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/GoMudEngine/GoMud/internal/skills"
+)
+
+const (
+	MerchantReputationMin = -100
+	MerchantReputationMax = 100
+	// Merchants refuse to do business at or below this reputation
+	MerchantReputationRefuse = -75
+
+	// Reputation changes for various interactions with a merchant
+	MerchantReputationTrade          = 1   // Each purchase or sale
+	MerchantReputationTheft          = -10 // Successfully stealing from them
+	MerchantReputationCaughtStealing = -25 // Getting caught stealing from them
+
+	// Percent better prices per level of the trading skill
+	tradingSkillPctPerLevel = 5
+	// Reputation is divided by this to get a percent price adjustment (+/- 10%)
+	reputationPctDivisor = 10
+)
+
+func merchantReputationKey(mobId int) string {
+	return `reputation-merchant-` + strconv.Itoa(mobId)
+}
+
+// Returns how a merchant (by MobId) regards this character, from -100 to 100
+func (c *Character) GetMerchantReputation(mobId int) int {
+
+	switch v := c.GetMiscData(merchantReputationKey(mobId)).(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+
+	return 0
+}
+
+// Adjusts the reputation with a merchant, and returns the new reputation
+func (c *Character) AdjustMerchantReputation(mobId int, amt int) int {
+
+	rep := c.GetMerchantReputation(mobId) + amt
+
+	if rep < MerchantReputationMin {
+		rep = MerchantReputationMin
+	} else if rep > MerchantReputationMax {
+		rep = MerchantReputationMax
+	}
+
+	if rep == 0 {
+		c.SetMiscData(merchantReputationKey(mobId), nil)
+	} else {
+		c.SetMiscData(merchantReputationKey(mobId), rep)
+	}
+
+	return rep
+}
+
+// Returns the percent advantage this character has when trading with a merchant.
+// Negative values mean they get worse prices than normal.
+func (c *Character) GetTradingAdvantage(mobId int) int {
+	return c.GetSkillLevel(skills.Trading)*tradingSkillPctPerLevel + c.GetMerchantReputation(mobId)/reputationPctDivisor
+}
+
+// Returns what a merchant will charge this character for something with a base price
+func (c *Character) GetBuyPrice(basePrice int, mobId int) int {
+
+	if basePrice <= 0 {
+		return basePrice
+	}
+
+	price := int(math.Round(float64(basePrice) * float64(100-c.GetTradingAdvantage(mobId)) / 100))
+	if price < 1 {
+		price = 1
+	}
+
+	return price
+}
+
+// Returns what a merchant will pay this character for something they offer a base value for
+func (c *Character) GetSellPrice(baseValue int, mobId int) int {
+
+	if baseValue <= 0 {
+		return baseValue
+	}
+
+	return int(math.Round(float64(baseValue) * float64(100+c.GetTradingAdvantage(mobId)) / 100))
+}
+
+// Returns a short description of how a merchant feels about this character
+func (c *Character) GetMerchantAttitude(mobId int) string {
+
+	rep := c.GetMerchantReputation(mobId)
+
+	switch {
+	case rep <= MerchantReputationRefuse:
+		return `refuses to deal with you`
+	case rep < -25:
+		return `eyes you with suspicion`
+	case rep < 25:
+		return `regards you neutrally`
+	case rep < 75:
+		return `greets you warmly`
+	}
+	return `treats you as a valued customer`
+}
@@ -1,6 +1,9 @@
 package characters
 
-import "github.com/GoMudEngine/GoMud/internal/items"
+import (
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
 
 type Worn struct {
 	Weapon  items.Item `yaml:"weapon,omitempty"`
@@ -111,3 +114,51 @@ func GetAllSlotTypes() []string {
 		string(items.Feet),
 	}
 }
+
+// Fully repairs a worn item.
+// Returns true if the item was found.
+func (w *Worn) Repair(i items.Item) bool {
+	for _, itm := range []*items.Item{&w.Weapon, &w.Offhand, &w.Head, &w.Neck, &w.Body, &w.Belt, &w.Gloves, &w.Ring, &w.Legs, &w.Feet} {
+		if itm.ItemId > 0 && itm.Equals(i) {
+			itm.Repair()
+			return true
+		}
+	}
+	return false
+}
+
+// Wears down whatever weapons are being attacked with.
+// Returns any items whose condition changed.
+func (w *Worn) WearWeapons(amt int) []items.Item {
+	changed := []items.Item{}
+	if w.Weapon.AddWear(amt) {
+		changed = append(changed, w.Weapon)
+	}
+	if w.Offhand.GetSpec().Type == items.Weapon && w.Offhand.AddWear(amt) {
+		changed = append(changed, w.Offhand)
+	}
+	return changed
+}
+
+// Wears down a random piece of protective equipment.
+// Returns the item if its condition changed.
+func (w *Worn) WearArmor(amt int) (items.Item, bool) {
+
+	candidates := []*items.Item{}
+	for _, itm := range []*items.Item{&w.Offhand, &w.Head, &w.Neck, &w.Body, &w.Belt, &w.Gloves, &w.Ring, &w.Legs, &w.Feet} {
+		if itm.ItemId > 0 && itm.GetSpec().DamageReduction > 0 && itm.HasDurability() {
+			candidates = append(candidates, itm)
+		}
+	}
+
+	if len(candidates) == 0 {
+		return items.Item{}, false
+	}
+
+	itm := candidates[util.Rand(len(candidates))]
+	if itm.AddWear(amt) {
+		return *itm, true
+	}
+
+	return items.Item{}, false
+}
@@ -0,0 +1,320 @@
+package clans
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+var (
+	clans       = map[string]*ClanInfo{} // key is lowercase clan tag
+	userClanMap = map[int]string{}       // key is userId, value is lowercase clan tag. Membership is per account, shared by alt characters.
+	deleted     = []string{}             // Filepaths of disbanded clans waiting to be removed
+)
+
+func clansPath() string {
+	return util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/`, `clans`)
+}
+
+// Loads all clans from the clans folder (a sibling of the users folder)
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	clear(clans)
+	clear(userClanMap)
+
+	basePath := clansPath()
+	if _, err := os.Stat(basePath); os.IsNotExist(err) {
+		mudlog.Info("clans.LoadDataFiles()", "loadedCount", 0, "Time Taken", time.Since(start))
+		return
+	}
+
+	tmpClans, err := fileloader.LoadAllFlatFiles[string, *ClanInfo](basePath)
+	if err != nil {
+		panic(err)
+	}
+
+	clans = tmpClans
+
+	for id, c := range clans {
+		for _, m := range c.Members {
+			userClanMap[m.UserId] = id
+		}
+	}
+
+	mudlog.Info("clans.LoadDataFiles()", "loadedCount", len(clans), "Time Taken", time.Since(start))
+}
+
+func SaveAllClans() error {
+
+	start := time.Now()
+
+	basePath := clansPath()
+
+	for _, fPath := range deleted {
+		if err := os.Remove(filepath.Join(basePath, fPath)); err != nil && !os.IsNotExist(err) {
+			mudlog.Error("SaveAllClans()", "error", err.Error())
+		}
+	}
+	deleted = deleted[:0]
+
+	if len(clans) == 0 {
+		return nil
+	}
+
+	if err := os.MkdirAll(basePath, os.ModePerm); err != nil {
+		return err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	saveCt, err := fileloader.SaveAllFlatFiles[string, *ClanInfo](basePath, clans, saveModes...)
+
+	mudlog.Info("SaveAllClans()", "savedCount", saveCt, "expectedCt", len(clans), "Time Taken", time.Since(start))
+
+	return err
+}
+
+func GetClan(clanTag string) *ClanInfo {
+	if c, ok := clans[strings.ToLower(clanTag)]; ok {
+		return c
+	}
+	return nil
+}
+
+// Finds a clan by tag or full name
+func FindClan(search string) *ClanInfo {
+
+	if c := GetClan(search); c != nil {
+		return c
+	}
+
+	search = strings.ToLower(search)
+	for _, c := range clans {
+		if strings.ToLower(c.ClanName) == search {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func GetClanByUserId(userId int) *ClanInfo {
+	if clanId, ok := userClanMap[userId]; ok {
+		return clans[clanId]
+	}
+	return nil
+}
+
+// Returns the clan tag for a user/character combination
+// Alt characters of a member do not carry the tag.
+func GetClanTag(userId int, characterName string) string {
+	if c := GetClanByUserId(userId); c != nil {
+		if m, ok := c.GetMember(userId); ok && strings.EqualFold(m.CharacterName, characterName) {
+			return c.ClanTag
+		}
+	}
+	return ``
+}
+
+func GetAllClans() []*ClanInfo {
+	ret := make([]*ClanInfo, 0, len(clans))
+	for _, c := range clans {
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ClanTag < ret[j].ClanTag
+	})
+	return ret
+}
+
+func Create(clanTag string, clanName string, leaderUserId int, leaderName string, startingGold int) (*ClanInfo, error) {
+
+	if err := ValidateClanTag(clanTag); err != nil {
+		return nil, err
+	}
+
+	if GetClanByUserId(leaderUserId) != nil {
+		return nil, ErrAlreadyMember
+	}
+
+	if GetClan(clanTag) != nil || FindClan(clanName) != nil {
+		return nil, ErrClanExists
+	}
+
+	gp := configs.GetGamePlayConfig()
+
+	c := &ClanInfo{
+		ClanTag:      strings.ToUpper(clanTag),
+		ClanName:     clanName,
+		Upkeep:       int(gp.Clans.DailyUpkeep),
+		MemberUpkeep: int(gp.Clans.DailyMemberUpkeep),
+		Gold:         startingGold,
+		Created:      time.Now(),
+		Members: []ClanMember{
+			{
+				UserId:        leaderUserId,
+				CharacterName: leaderName,
+				Joined:        time.Now(),
+				Rank:          ClanRankLeader,
+			},
+		},
+	}
+
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+
+	clans[c.Id()] = c
+	userClanMap[leaderUserId] = c.Id()
+
+	// In case a clan by this name was disbanded and not yet cleaned up
+	for i, fPath := range deleted {
+		if fPath == c.Filepath() {
+			deleted = append(deleted[:i], deleted[i+1:]...)
+			break
+		}
+	}
+
+	return c, nil
+}
+
+// Joins a user to a clan, clearing any application or invitation they had
+func Join(clanTag string, userId int, characterName string) error {
+
+	c := GetClan(clanTag)
+	if c == nil {
+		return ErrClanNotFound
+	}
+
+	if GetClanByUserId(userId) != nil {
+		return ErrAlreadyMember
+	}
+
+	if len(c.Members) >= int(configs.GetGamePlayConfig().Clans.MaxMembers) {
+		return ErrClanFull
+	}
+
+	c.Applications, _ = removeUser(c.Applications, userId)
+	c.Invites, _ = removeUser(c.Invites, userId)
+
+	c.Members = append(c.Members, ClanMember{
+		UserId:        userId,
+		CharacterName: characterName,
+		Joined:        time.Now(),
+		Rank:          ClanRankMember,
+	})
+
+	userClanMap[userId] = c.Id()
+
+	return nil
+}
+
+// Removes a user from their clan.
+// If the last leader leaves, the highest ranking longest standing member is promoted.
+// If the last member leaves, the clan is disbanded.
+func Leave(userId int) error {
+
+	c := GetClanByUserId(userId)
+	if c == nil {
+		return ErrNotMember
+	}
+
+	m, _ := c.GetMember(userId)
+
+	c.Members, _ = removeUser(c.Members, userId)
+	delete(userClanMap, userId)
+
+	if len(c.Members) == 0 {
+		Disband(c.ClanTag)
+		return nil
+	}
+
+	if m.Rank == ClanRankLeader && c.RankCount(ClanRankLeader) == 0 {
+		successor := 0
+		for i := range c.Members {
+			if c.Members[i].Rank.Value() > c.Members[successor].Rank.Value() {
+				successor = i
+			}
+		}
+		c.Members[successor].Rank = ClanRankLeader
+	}
+
+	return nil
+}
+
+// Declines (removes) an application or an invitation
+func Decline(clanTag string, userId int) bool {
+
+	c := GetClan(clanTag)
+	if c == nil {
+		return false
+	}
+
+	var removedApp, removedInvite bool
+	c.Applications, removedApp = removeUser(c.Applications, userId)
+	c.Invites, removedInvite = removeUser(c.Invites, userId)
+
+	return removedApp || removedInvite
+}
+
+// Returns all clans that have invited the user
+func GetInvitations(userId int) []*ClanInfo {
+	ret := []*ClanInfo{}
+	for _, c := range GetAllClans() {
+		if c.HasInvite(userId) {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// Disbands a clan and returns the members it had at the time.
+func Disband(clanTag string) []ClanMember {
+
+	c := GetClan(clanTag)
+	if c == nil {
+		return nil
+	}
+
+	for _, m := range c.Members {
+		delete(userClanMap, m.UserId)
+	}
+
+	delete(clans, c.Id())
+	deleted = append(deleted, c.Filepath())
+
+	return c.Members
+}
+
+// Deducts the daily upkeep from every clan treasury.
+// Returns any clans that could not pay and were disbanded.
+func DoUpkeep() (paid []*ClanInfo, disbanded []*ClanInfo) {
+
+	for _, c := range GetAllClans() {
+
+		cost := c.DailyCost()
+
+		if c.Gold >= cost {
+			c.Gold -= cost
+			paid = append(paid, c)
+			continue
+		}
+
+		Disband(c.ClanTag)
+		disbanded = append(disbanded, c)
+	}
+
+	return paid, disbanded
+}
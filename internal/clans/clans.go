@@ -1,9 +1,13 @@
 package clans
 
 import (
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
 type ClanRank string
@@ -12,6 +16,21 @@ const (
 	ClanRankMember     ClanRank = `member`     // normal members get no special privileges
 	ClanRankLieutenant ClanRank = `lieutenant` // Lieutenants can accept applications
 	ClanRankLeader     ClanRank = `leader`     // Leaders can invite, kick, accept applications and promote members
+
+	ClanTagSizeMin  = 2
+	ClanTagSizeMax  = 4
+	ClanNameSizeMax = 32
+	maxDonations    = 50 // How many donation records to keep around
+)
+
+var (
+	ErrClanTagInvalid  = errors.New(`clan tags must be 2-4 letters or numbers`)
+	ErrClanNameInvalid = errors.New(`clan names must be between 1 and 32 characters`)
+	ErrClanExists      = errors.New(`a clan with that tag or name already exists`)
+	ErrClanNotFound    = errors.New(`clan not found`)
+	ErrNotMember       = errors.New(`not a member of that clan`)
+	ErrAlreadyMember   = errors.New(`already a member of a clan`)
+	ErrClanFull        = errors.New(`the clan is full`)
 )
 
 type ClanInfo struct {
@@ -20,14 +39,19 @@ type ClanInfo struct {
 	ClanName     string       `json:"clanname"`     // Full clan name such as "Questing Cajuns"
 	Upkeep       int          `json:"upkeep"`       // Daily cost in gold to keep the clan going, or it automatically disbands
 	MemberUpkeep int          `json:"memberupkeep"` // Daily Gold upkeep cost per member
+	Gold         int          `json:"gold"`         // Gold in the clan treasury, used to pay upkeep
+	Created      time.Time    `json:"created"`      // When the clan was founded
 	Members      []ClanMember `json:"members"`      // List of clan members
 	Applications []ClanMember `json:"applications"` // List of clan applications
-	Donations    []ClanMember `json:"donations"`    // List of clan donations
+	Invites      []ClanMember `json:"invites"`      // List of outstanding invitations to join
+	Donations    []Donation   `json:"donations"`    // List of clan donations
 }
 
+// Clan membership belongs to a user (account), so all of their alt characters share it.
+// Only the character that joined wears the clan tag.
 type ClanMember struct {
 	UserId        int       `json:"userid"`        // User ID of the clan member
-	CharacterName string    `json:"charactername"` // Character name of the clan member
+	CharacterName string    `json:"charactername"` // Character name of the clan member, the one that wears the clan tag
 	Joined        time.Time `json:"joined"`        // Date and time the clan member joined the clan
 	Rank          ClanRank  `json:"rank"`          // Rank of the clan member
 }
@@ -38,3 +62,242 @@ type Donation struct {
 	Item   items.Item `json:"item"`   // Item donated
 	Date   time.Time  `json:"date"`   // Date and time the donation was made
 }
+
+func (r ClanRank) Value() int {
+	switch r {
+	case ClanRankLeader:
+		return 3
+	case ClanRankLieutenant:
+		return 2
+	case ClanRankMember:
+		return 1
+	}
+	return 0
+}
+
+// Returns the next rank up from the current one.
+// Leader returns leader.
+func (r ClanRank) Next() ClanRank {
+	switch r {
+	case ClanRankMember:
+		return ClanRankLieutenant
+	}
+	return ClanRankLeader
+}
+
+func (c *ClanInfo) Id() string {
+	return strings.ToLower(c.ClanTag)
+}
+
+func (c *ClanInfo) Filepath() string {
+	return util.ConvertForFilename(c.Id()) + `.yaml`
+}
+
+func (c *ClanInfo) Validate() error {
+
+	if err := ValidateClanTag(c.ClanTag); err != nil {
+		return err
+	}
+
+	if len(c.ClanName) < 1 || len(c.ClanName) > ClanNameSizeMax {
+		return ErrClanNameInvalid
+	}
+
+	if c.Upkeep < 0 {
+		c.Upkeep = 0
+	}
+
+	if c.MemberUpkeep < 0 {
+		c.MemberUpkeep = 0
+	}
+
+	if c.Members == nil {
+		c.Members = []ClanMember{}
+	}
+
+	if c.Applications == nil {
+		c.Applications = []ClanMember{}
+	}
+
+	if c.Invites == nil {
+		c.Invites = []ClanMember{}
+	}
+
+	if c.Donations == nil {
+		c.Donations = []Donation{}
+	}
+
+	for i := range c.Members {
+		if c.Members[i].Rank.Value() == 0 {
+			c.Members[i].Rank = ClanRankMember
+		}
+	}
+
+	return nil
+}
+
+// The total daily gold the treasury must cover
+func (c *ClanInfo) DailyCost() int {
+	return c.Upkeep + (c.MemberUpkeep * len(c.Members))
+}
+
+func (c *ClanInfo) GetMember(userId int) (ClanMember, bool) {
+	for _, m := range c.Members {
+		if m.UserId == userId {
+			return m, true
+		}
+	}
+	return ClanMember{}, false
+}
+
+func (c *ClanInfo) IsMember(userId int) bool {
+	_, ok := c.GetMember(userId)
+	return ok
+}
+
+// Whether the user holds at least the provided rank
+func (c *ClanInfo) HasRank(userId int, rank ClanRank) bool {
+	if m, ok := c.GetMember(userId); ok {
+		return m.Rank.Value() >= rank.Value()
+	}
+	return false
+}
+
+// Finds a member by (partial) character name
+func (c *ClanInfo) FindMember(name string) (ClanMember, bool) {
+	return findByName(c.Members, name)
+}
+
+func (c *ClanInfo) FindApplication(name string) (ClanMember, bool) {
+	return findByName(c.Applications, name)
+}
+
+func (c *ClanInfo) HasApplication(userId int) bool {
+	for _, m := range c.Applications {
+		if m.UserId == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClanInfo) HasInvite(userId int) bool {
+	for _, m := range c.Invites {
+		if m.UserId == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ClanInfo) AddApplication(userId int, characterName string) bool {
+	if c.IsMember(userId) || c.HasApplication(userId) {
+		return false
+	}
+	c.Applications = append(c.Applications, ClanMember{
+		UserId:        userId,
+		CharacterName: characterName,
+		Joined:        time.Now(),
+		Rank:          ClanRankMember,
+	})
+	return true
+}
+
+func (c *ClanInfo) AddInvite(userId int, characterName string) bool {
+	if c.IsMember(userId) || c.HasInvite(userId) {
+		return false
+	}
+	c.Invites = append(c.Invites, ClanMember{
+		UserId:        userId,
+		CharacterName: characterName,
+		Joined:        time.Now(),
+		Rank:          ClanRankMember,
+	})
+	return true
+}
+
+func (c *ClanInfo) SetRank(userId int, rank ClanRank) bool {
+	for i := range c.Members {
+		if c.Members[i].UserId == userId {
+			c.Members[i].Rank = rank
+			return true
+		}
+	}
+	return false
+}
+
+// Returns how many members hold exactly the provided rank
+func (c *ClanInfo) RankCount(rank ClanRank) int {
+	ct := 0
+	for _, m := range c.Members {
+		if m.Rank == rank {
+			ct++
+		}
+	}
+	return ct
+}
+
+func (c *ClanInfo) AddDonation(d Donation) {
+	if d.Date.IsZero() {
+		d.Date = time.Now()
+	}
+	c.Gold += d.Gold
+	c.Donations = append(c.Donations, d)
+	for len(c.Donations) > maxDonations {
+		c.Donations = c.Donations[1:]
+	}
+}
+
+func (c *ClanInfo) GetMemberUserIds() []int {
+	ret := make([]int, 0, len(c.Members))
+	for _, m := range c.Members {
+		ret = append(ret, m.UserId)
+	}
+	return ret
+}
+
+func (c *ClanInfo) String() string {
+	return fmt.Sprintf(`[%s] %s`, c.ClanTag, c.ClanName)
+}
+
+func ValidateClanTag(tag string) error {
+	if len(tag) < ClanTagSizeMin || len(tag) > ClanTagSizeMax {
+		return ErrClanTagInvalid
+	}
+	for _, r := range tag {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return ErrClanTagInvalid
+		}
+	}
+	return nil
+}
+
+func removeUser(list []ClanMember, userId int) ([]ClanMember, bool) {
+	for i, m := range list {
+		if m.UserId == userId {
+			return append(list[:i], list[i+1:]...), true
+		}
+	}
+	return list, false
+}
+
+func findByName(list []ClanMember, name string) (ClanMember, bool) {
+
+	name = strings.ToLower(name)
+
+	var closeMatch ClanMember
+	found := false
+
+	for _, m := range list {
+		testName := strings.ToLower(m.CharacterName)
+		if testName == name {
+			return m, true
+		}
+		if !found && strings.HasPrefix(testName, name) {
+			closeMatch = m
+			found = true
+		}
+	}
+
+	return closeMatch, found
+}
@@ -0,0 +1,80 @@
+package clans
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func resetClans() {
+	clear(clans)
+	clear(userClanMap)
+	deleted = deleted[:0]
+}
+
+func TestValidateClanTag(t *testing.T) {
+	assert.NoError(t, ValidateClanTag(`QC`))
+	assert.NoError(t, ValidateClanTag(`ab12`))
+	assert.Error(t, ValidateClanTag(`Q`))
+	assert.Error(t, ValidateClanTag(`TOOLONG`))
+	assert.Error(t, ValidateClanTag(`Q C`))
+	assert.Error(t, ValidateClanTag(`[QC]`))
+}
+
+func TestCreateJoinLeave(t *testing.T) {
+	resetClans()
+
+	c, err := Create(`qc`, `Questing Cajuns`, 1, `Leader`, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, `QC`, c.ClanTag)
+	assert.True(t, c.HasRank(1, ClanRankLeader))
+
+	_, err = Create(`QC`, `Another Clan`, 2, `Other`, 500)
+	assert.ErrorIs(t, err, ErrClanExists)
+
+	_, err = Create(`XX`, `Another Clan`, 1, `Leader`, 500)
+	assert.ErrorIs(t, err, ErrAlreadyMember)
+
+	assert.True(t, c.AddInvite(2, `Invited`))
+	assert.False(t, c.AddInvite(2, `Invited`))
+	assert.NoError(t, Join(`QC`, 2, `Invited`))
+	assert.False(t, c.HasInvite(2))
+	assert.Equal(t, c, GetClanByUserId(2))
+	assert.Equal(t, `QC`, GetClanTag(2, `invited`))
+	assert.Equal(t, ``, GetClanTag(2, `SomeAlt`))
+
+	// Leader leaving promotes the remaining member
+	assert.NoError(t, Leave(1))
+	assert.Nil(t, GetClanByUserId(1))
+	assert.True(t, c.HasRank(2, ClanRankLeader))
+
+	// Last member leaving disbands the clan
+	assert.NoError(t, Leave(2))
+	assert.Nil(t, GetClan(`QC`))
+	assert.Len(t, deleted, 1)
+}
+
+func TestDoUpkeep(t *testing.T) {
+	resetClans()
+
+	rich, _ := Create(`RICH`, `Rich Clan`, 1, `Rich`, 1000)
+	rich.Upkeep = 100
+	rich.MemberUpkeep = 10
+
+	poor, _ := Create(`POOR`, `Poor Clan`, 2, `Poor`, 5)
+	poor.Upkeep = 100
+
+	paid, disbanded := DoUpkeep()
+
+	assert.Len(t, paid, 1)
+	assert.Len(t, disbanded, 1)
+	assert.Equal(t, 890, rich.Gold)
+	assert.Nil(t, GetClan(`POOR`))
+	assert.Nil(t, GetClanByUserId(2))
+}
+
+func TestRankNext(t *testing.T) {
+	assert.Equal(t, ClanRankLieutenant, ClanRankMember.Next())
+	assert.Equal(t, ClanRankLeader, ClanRankLieutenant.Next())
+	assert.Equal(t, ClanRankLeader, ClanRankLeader.Next())
+}
@@ -45,6 +45,17 @@ func PowerRanking(atkChar characters.Character, defChar characters.Character) fl
 	return pct
 }
 
+// Adjusts damage by a percent resisted, rounding to the nearest point.
+// Resistance is capped at 100 (immune), and negative resistance is a vulnerability that adds damage.
+func ElementalDamage(damage int, resistPct int) int {
+
+	if resistPct > 100 {
+		resistPct = 100
+	}
+
+	return int(math.Round(float64(damage) * float64(100-resistPct) / 100))
+}
+
 func ChanceToTame(s *users.UserRecord, t *mobs.Mob) int {
 
 	var MOD_SKILL_MIN int = 1   // Minimum base tame ability
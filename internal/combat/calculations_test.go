@@ -58,3 +58,28 @@ func TestAlignmentChange(t *testing.T) {
 		}
 	}
 }
+
+func TestElementalDamage(t *testing.T) {
+	tests := []struct {
+		damage    int
+		resistPct int
+		expected  int
+	}{
+		{10, 0, 10},
+		{10, 25, 8},
+		{10, 50, 5},
+		{10, 100, 0},
+		{10, 150, 0},
+		{10, -50, 15},
+		{10, -100, 20},
+		{0, -50, 0},
+		{3, 50, 2},
+	}
+
+	for _, test := range tests {
+		result := ElementalDamage(test.damage, test.resistPct)
+		if result != test.expected {
+			t.Errorf("ElementalDamage(%d, %d) = %d; want %d", test.damage, test.resistPct, result, test.expected)
+		}
+	}
+}
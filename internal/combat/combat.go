@@ -9,6 +9,8 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/buffs"
 	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/duels"
+	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/items"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
@@ -32,6 +34,8 @@ func AttackPlayerVsMob(user *users.UserRecord, mob *mobs.Mob) AttackResult {
 
 	attackResult := calculateCombat(*user.Character, mob.Character, User, Mob)
 
+	wearEquipment(user.Character, &mob.Character, &attackResult, user.UserId, 0)
+
 	if attackResult.DamageToSource != 0 {
 		user.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
 		user.WimpyCheck()
@@ -56,6 +60,11 @@ func AttackPlayerVsPlayer(userAtk *users.UserRecord, userDef *users.UserRecord)
 
 	attackResult := calculateCombat(*userAtk.Character, *userDef.Character, User, User)
 
+	// Friendly duels don't wear down anyone's gear
+	if !duels.IsDueling(userAtk.UserId, userDef.UserId) {
+		wearEquipment(userAtk.Character, userDef.Character, &attackResult, userAtk.UserId, userDef.UserId)
+	}
+
 	if attackResult.DamageToSource != 0 {
 		userAtk.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
 		userAtk.WimpyCheck()
@@ -81,6 +90,8 @@ func AttackMobVsPlayer(mob *mobs.Mob, user *users.UserRecord) AttackResult {
 
 	attackResult := calculateCombat(mob.Character, *user.Character, Mob, User)
 
+	wearEquipment(&mob.Character, user.Character, &attackResult, 0, user.UserId)
+
 	mob.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
 
 	if attackResult.DamageToTarget != 0 {
@@ -100,6 +111,8 @@ func AttackMobVsMob(mobAtk *mobs.Mob, mobDef *mobs.Mob) AttackResult {
 
 	attackResult := calculateCombat(mobAtk.Character, mobDef.Character, Mob, User)
 
+	wearEquipment(&mobAtk.Character, &mobDef.Character, &attackResult, 0, 0)
+
 	mobAtk.Character.ApplyHealthChange(attackResult.DamageToSource * -1)
 	mobDef.Character.ApplyHealthChange(attackResult.DamageToTarget * -1)
 
@@ -112,6 +125,52 @@ func AttackMobVsMob(mobAtk *mobs.Mob, mobDef *mobs.Mob) AttackResult {
 	return attackResult
 }
 
+// Wears down the attackers weapons when they land a hit, and the defenders armor when it absorbs damage.
+// sourceUserId and targetUserId should be zero for mobs.
+func wearEquipment(sourceChar *characters.Character, targetChar *characters.Character, attackResult *AttackResult, sourceUserId int, targetUserId int) {
+
+	if attackResult.Hit {
+		if changed := sourceChar.Equipment.WearWeapons(1); len(changed) > 0 {
+			for _, itm := range changed {
+				if msg := conditionMessage(itm); msg != `` {
+					attackResult.SendToSource(msg)
+				}
+			}
+			if sourceUserId > 0 {
+				events.AddToQueue(events.EquipmentChange{
+					UserId:       sourceUserId,
+					ItemsChanged: changed,
+				})
+			}
+		}
+	}
+
+	if attackResult.DamageToTargetReduction > 0 {
+		if itm, changed := targetChar.Equipment.WearArmor(1); changed {
+			if msg := conditionMessage(itm); msg != `` {
+				attackResult.SendToTarget(msg)
+			}
+			if targetUserId > 0 {
+				events.AddToQueue(events.EquipmentChange{
+					UserId:       targetUserId,
+					ItemsChanged: []items.Item{itm},
+				})
+			}
+		}
+	}
+}
+
+// Returns a message about the new condition of an item, or an empty string if it isn't worth mentioning
+func conditionMessage(itm items.Item) string {
+	if itm.GetCondition() == `good` {
+		return ``
+	}
+	if itm.GetDurability() == 0 {
+		return fmt.Sprintf(`Your <ansi fg="itemname">%s</ansi> is <ansi fg="item-broken">broken</ansi>! It should be repaired.`, itm.DisplayName())
+	}
+	return fmt.Sprintf(`Your <ansi fg="itemname">%s</ansi> is now <ansi fg="item-worn">%s</ansi>.`, itm.DisplayName(), itm.GetCondition())
+}
+
 func GetWaitMessages(stepType items.Intensity, sourceChar *characters.Character, targetChar *characters.Character, sourceType SourceTarget, targetType SourceTarget) AttackResult {
 
 	attackResult := AttackResult{}
@@ -315,6 +374,8 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 			raceInfo := races.GetRace(sourceChar.RaceId)
 			weaponName := raceInfo.UnarmedName
 			weaponSubType := items.Generic
+			weaponElement := items.Element(``)
+			weaponEffectiveness := 100
 
 			// Get default racial dice rolls
 			attacks, dCount, dSides, dBonus, critBuffs := sourceChar.GetDefaultDiceRoll()
@@ -329,6 +390,8 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 				weaponName = weapon.DisplayName()
 
 				weaponSubType = itemSpec.Subtype
+				weaponElement = itemSpec.Element
+				weaponEffectiveness = weapon.GetEffectiveness()
 				attacks, dCount, dSides, dBonus, critBuffs = weapon.GetDiceRoll()
 
 				// If there is a bonus vs. a specific race, apply it
@@ -361,6 +424,11 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 						attackResult.BuffTarget = critBuffs
 						attackTargetDamage += dCount*dSides + dBonus
 					}
+
+					// Worn down weapons don't hit as hard
+					if weaponEffectiveness < 100 {
+						attackTargetDamage = int(math.Round(float64(attackTargetDamage) * float64(weaponEffectiveness) / 100))
+					}
 				}
 
 				defenseAmt := util.Rand(targetChar.GetDefense())
@@ -375,6 +443,14 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 					attackSourceDamage -= attackSourceReduction
 				}
 
+				// Whatever gets through the defense is subject to elemental resistance
+				elementalAdjustment := 0
+				if weaponElement != `` && attackTargetDamage > 0 {
+					elementalDamage := ElementalDamage(attackTargetDamage, targetChar.GetResistance(weaponElement))
+					elementalAdjustment = elementalDamage - attackTargetDamage
+					attackTargetDamage = elementalDamage
+				}
+
 				// Calculate actual damage vs. possible damage pct
 				pctDamage := math.Ceil(float64(attackTargetDamage) / float64(dCount*dSides+dBonus) * 100)
 
@@ -458,6 +534,16 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 					}
 				}
 
+				if weaponElement != `` && attackTargetDamage > 0 {
+					elementalSuffix := fmt.Sprintf(` <ansi fg="element-%s">*%s*</ansi>`, weaponElement, weaponElement.Flavor())
+					toAttackerMsg += items.ItemMessage(elementalSuffix)
+					toDefenderMsg += items.ItemMessage(elementalSuffix)
+					toAttackerRoomMsg += items.ItemMessage(elementalSuffix)
+					if len(string(toDefenderRoomMsg)) > 0 {
+						toDefenderRoomMsg += items.ItemMessage(elementalSuffix)
+					}
+				}
+
 				if len(attackMessagePrefix) > 0 {
 					toAttackerMsg = items.ItemMessage(attackMessagePrefix + string(toAttackerMsg))
 					toDefenderMsg = items.ItemMessage(attackMessagePrefix + string(toDefenderMsg))
@@ -472,6 +558,11 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 				if attackSourceDamage > 0 && attackSourceReduction > 0 {
 					attackerMsg += fmt.Sprintf(` <ansi fg="white">[%d was blocked]</ansi>`, attackSourceReduction)
 				}
+				if elementalAdjustment < 0 {
+					attackerMsg += fmt.Sprintf(` <ansi fg="white">[%d was resisted]</ansi>`, -elementalAdjustment)
+				} else if elementalAdjustment > 0 {
+					attackerMsg += fmt.Sprintf(` <ansi fg="damage">[%d from vulnerability]</ansi>`, elementalAdjustment)
+				}
 
 				attackResult.SendToSource(
 					string(attackerMsg),
@@ -482,6 +573,11 @@ func calculateCombat(sourceChar characters.Character, targetChar characters.Char
 				if attackTargetDamage > 0 && attackTargetReduction > 0 {
 					defenderMsg += fmt.Sprintf(` <ansi fg="red">[you blocked %d]</ansi>`, attackTargetReduction)
 				}
+				if elementalAdjustment < 0 {
+					defenderMsg += fmt.Sprintf(` <ansi fg="red">[you resisted %d]</ansi>`, -elementalAdjustment)
+				} else if elementalAdjustment > 0 {
+					defenderMsg += fmt.Sprintf(` <ansi fg="red">[%d from vulnerability]</ansi>`, elementalAdjustment)
+				}
 
 				attackResult.SendToTarget(
 					string(defenderMsg),
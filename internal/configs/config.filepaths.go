@@ -8,7 +8,10 @@ type FilePaths struct {
 	AdminHtml        ConfigString `yaml:"AdminHtml"`
 	HttpsCertFile    ConfigString `yaml:"HttpsCertFile"`
 	HttpsKeyFile     ConfigString `yaml:"HttpsKeyFile"`
+	SshHostKeyFile   ConfigString `yaml:"SshHostKeyFile"`
 	CarefulSaveFiles ConfigBool   `yaml:"CarefulSaveFiles"`
+	UserStorage      ConfigString `yaml:"UserStorage"`      // Where users are saved: "yaml" (flat files) or "sqlite"
+	UserDatabaseFile ConfigString `yaml:"UserDatabaseFile"` // SQLite database file, if UserStorage is "sqlite"
 }
 
 func (f *FilePaths) Validate() {
@@ -23,6 +26,18 @@ func (f *FilePaths) Validate() {
 		f.DataFiles = `_datafiles/world/default` // default
 	}
 
+	if f.SshHostKeyFile == `` {
+		f.SshHostKeyFile = `_datafiles/ssh_host_ed25519_key` // default
+	}
+
+	if f.UserStorage != `yaml` && f.UserStorage != `sqlite` {
+		f.UserStorage = `yaml` // default
+	}
+
+	if f.UserDatabaseFile == `` {
+		f.UserDatabaseFile = f.DataFiles + `/users.db` // default
+	}
+
 }
 
 func GetFilePathsConfig() FilePaths {
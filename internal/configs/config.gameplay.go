@@ -28,6 +28,45 @@ type GamePlay struct {
 	// XpScale (difficulty)
 	XPScale           ConfigFloat `yaml:"XPScale"`
 	MobConverseChance ConfigInt   `yaml:"MobConverseChance"` // Chance 1-100 of attempting to converse when idle
+	// Clan related settings
+	Clans GameplayClans `yaml:"Clans"`
+	// Weather related settings
+	Weather GameplayWeather `yaml:"Weather"`
+	// Player housing related settings
+	Housing GameplayHousing `yaml:"Housing"`
+	// Duel related settings
+	Duels GameplayDuels `yaml:"Duels"`
+}
+
+type GameplayClans struct {
+	CreateCost         ConfigInt `yaml:"CreateCost"`         // Gold it costs to found a new clan
+	DailyUpkeep        ConfigInt `yaml:"DailyUpkeep"`        // Default daily gold upkeep for new clans
+	DailyMemberUpkeep  ConfigInt `yaml:"DailyMemberUpkeep"`  // Default daily gold upkeep per member for new clans
+	MaxMembers         ConfigInt `yaml:"MaxMembers"`         // Maximum members a clan can have
+	MinimumCreateLevel ConfigInt `yaml:"MinimumCreateLevel"` // Minimum character level required to found a clan
+}
+
+type GameplayWeather struct {
+	Enabled     ConfigBool   `yaml:"Enabled"`     // Whether zones have changing weather
+	MinDuration ConfigString `yaml:"MinDuration"` // Shortest time a weather pattern lasts before it may change
+	MaxDuration ConfigString `yaml:"MaxDuration"` // Longest time a weather pattern lasts before it may change
+}
+
+type GameplayHousing struct {
+	Enabled        ConfigBool   `yaml:"Enabled"`        // Whether players can buy homes
+	Zone           ConfigString `yaml:"Zone"`           // Name of the zone player homes are created in
+	Price          ConfigInt    `yaml:"Price"`          // Gold it costs to buy a home
+	RoomPrice      ConfigInt    `yaml:"RoomPrice"`      // Gold it costs to add another room to a home
+	MaxRooms       ConfigInt    `yaml:"MaxRooms"`       // Most rooms a single home can have
+	FurniturePrice ConfigInt    `yaml:"FurniturePrice"` // Gold it costs to add a piece of storage furniture
+	MaxGuests      ConfigInt    `yaml:"MaxGuests"`      // Most players that can be on the guest list of a home
+}
+
+type GameplayDuels struct {
+	ChallengeRounds  ConfigInt `yaml:"ChallengeRounds"`  // Rounds a challenge waits to be accepted before it expires
+	EndHealthPercent ConfigInt `yaml:"EndHealthPercent"` // A duelist loses when their health drops to this percent of their max health
+	BettingRounds    ConfigInt `yaml:"BettingRounds"`    // Rounds spectators have to bet before an arena duel starts
+	MaxBet           ConfigInt `yaml:"MaxBet"`           // Most gold a spectator can bet on an arena duel. 0 disables betting
 }
 
 type GameplayDeath struct {
@@ -118,6 +157,78 @@ func (g *GamePlay) Validate() {
 		g.XPScale = 100
 	}
 
+	if g.Clans.CreateCost < 0 {
+		g.Clans.CreateCost = 0
+	}
+
+	if g.Clans.DailyUpkeep < 0 {
+		g.Clans.DailyUpkeep = 0
+	}
+
+	if g.Clans.DailyMemberUpkeep < 0 {
+		g.Clans.DailyMemberUpkeep = 0
+	}
+
+	if g.Clans.MaxMembers < 1 {
+		g.Clans.MaxMembers = 20
+	}
+
+	if g.Clans.MinimumCreateLevel < 1 {
+		g.Clans.MinimumCreateLevel = 1
+	}
+
+	// Ignore Weather.Enabled
+
+	if g.Weather.MinDuration == `` {
+		g.Weather.MinDuration = `2 hours` // default
+	}
+
+	if g.Weather.MaxDuration == `` {
+		g.Weather.MaxDuration = `8 hours` // default
+	}
+
+	// Ignore Housing.Enabled
+
+	if g.Housing.Zone == `` {
+		g.Housing.Zone = `Player Homes` // default
+	}
+
+	if g.Housing.Price < 0 {
+		g.Housing.Price = 0
+	}
+
+	if g.Housing.RoomPrice < 0 {
+		g.Housing.RoomPrice = 0
+	}
+
+	if g.Housing.MaxRooms < 1 {
+		g.Housing.MaxRooms = 1
+	}
+
+	if g.Housing.FurniturePrice < 0 {
+		g.Housing.FurniturePrice = 0
+	}
+
+	if g.Housing.MaxGuests < 0 {
+		g.Housing.MaxGuests = 0
+	}
+
+	if g.Duels.ChallengeRounds < 1 {
+		g.Duels.ChallengeRounds = 15 // default
+	}
+
+	if g.Duels.EndHealthPercent < 1 || g.Duels.EndHealthPercent > 99 {
+		g.Duels.EndHealthPercent = 10 // default
+	}
+
+	if g.Duels.BettingRounds < 0 {
+		g.Duels.BettingRounds = 0
+	}
+
+	if g.Duels.MaxBet < 0 {
+		g.Duels.MaxBet = 0
+	}
+
 	if g.MobConverseChance < 0 {
 		g.MobConverseChance = 0
 	} else if g.MobConverseChance > 100 {
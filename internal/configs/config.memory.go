@@ -6,6 +6,8 @@ type Memory struct {
 	MobUnloadThreshold  ConfigInt `yaml:"MobUnloadThreshold"`
 	RoomUnloadRounds    ConfigInt `yaml:"RoomUnloadRounds"`
 	RoomUnloadThreshold ConfigInt `yaml:"RoomUnloadThreshold"`
+	// Zone instance cleanup
+	InstanceUnloadRounds ConfigInt `yaml:"InstanceUnloadRounds"`
 }
 
 func (m *Memory) Validate() {
@@ -26,6 +28,10 @@ func (m *Memory) Validate() {
 		m.RoomUnloadThreshold = 0
 	}
 
+	if m.InstanceUnloadRounds < 1 {
+		m.InstanceUnloadRounds = 75 // default
+	}
+
 }
 
 func GetMemoryConfig() Memory {
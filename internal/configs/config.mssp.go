@@ -0,0 +1,67 @@
+package configs
+
+// Fields reported to MUD listing crawlers via MSSP (Mud Server Status Protocol)
+// See: https://tintin.mudhalla.net/protocols/mssp/
+type Mssp struct {
+	Enabled    ConfigBool   `yaml:"Enabled"`    // Whether to offer MSSP to clients/crawlers
+	Hostname   ConfigString `yaml:"Hostname"`   // Hostname players should connect to
+	Codebase   ConfigString `yaml:"Codebase"`   // Name of the codebase
+	Contact    ConfigString `yaml:"Contact"`    // Email address to contact the admins
+	Website    ConfigString `yaml:"Website"`    // URL of the MUD's website
+	Discord    ConfigString `yaml:"Discord"`    // Discord invite URL
+	Genre      ConfigString `yaml:"Genre"`      // Adult, Fantasy, Historical, Horror, Modern, None, Science Fiction
+	Subgenre   ConfigString `yaml:"Subgenre"`   // Free text, such as "High Fantasy"
+	Gameplay   ConfigString `yaml:"Gameplay"`   // Adventure, Educational, Hack and Slash, None, Player versus Player, Player versus Environment, Roleplaying, Simulation, Social, Strategy
+	Status     ConfigString `yaml:"Status"`     // Alpha, Closed Beta, Open Beta, Live
+	Language   ConfigString `yaml:"Language"`   // Primary language of the game
+	Location   ConfigString `yaml:"Location"`   // Country the server is hosted in
+	Created    ConfigString `yaml:"Created"`    // Year the MUD was created
+	MinimumAge ConfigInt    `yaml:"MinimumAge"` // Minimum age of players. 0 for none.
+}
+
+func (m *Mssp) Validate() {
+
+	// Ignore Enabled
+	// Ignore Hostname
+	// Ignore Contact
+	// Ignore Website
+	// Ignore Discord
+	// Ignore Subgenre
+	// Ignore Location
+	// Ignore Created
+
+	if m.Codebase == `` {
+		m.Codebase = `GoMud` // default
+	}
+
+	if m.Genre == `` {
+		m.Genre = `Fantasy` // default
+	}
+
+	if m.Gameplay == `` {
+		m.Gameplay = `Hack and Slash` // default
+	}
+
+	if m.Status == `` {
+		m.Status = `Alpha` // default
+	}
+
+	if m.Language == `` {
+		m.Language = `English` // default
+	}
+
+	if m.MinimumAge < 0 {
+		m.MinimumAge = 0 // default
+	}
+
+}
+
+func GetMsspConfig() Mssp {
+	configDataLock.RLock()
+	defer configDataLock.RUnlock()
+
+	if !configData.validated {
+		configData.Validate()
+	}
+	return configData.Mssp
+}
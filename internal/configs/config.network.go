@@ -3,6 +3,8 @@ package configs
 type Network struct {
 	MaxTelnetConnections ConfigInt         `yaml:"MaxTelnetConnections"` // Maximum number of telnet connections to accept
 	TelnetPort           ConfigSliceString `yaml:"TelnetPort"`           // One or more Ports used to accept telnet connections
+	TelnetTLSPort        ConfigSliceString `yaml:"TelnetTLSPort"`        // One or more Ports used to accept TLS encrypted telnet connections
+	SshPort              ConfigSliceString `yaml:"SshPort"`              // One or more Ports used to accept SSH connections
 	LocalPort            ConfigInt         `yaml:"LocalPort"`            // Port used for admin connections, localhost only
 	HttpPort             ConfigInt         `yaml:"HttpPort"`             // Port used for web requests
 	HttpsPort            ConfigInt         `yaml:"HttpsPort"`            // Port used for web https requests
@@ -12,11 +14,14 @@ type Network struct {
 	TimeoutMods          ConfigBool        `yaml:"TimeoutMods"`          // Whether to kick admin/mods when idle too long.
 	ZombieSeconds        ConfigInt         `yaml:"ZombieSeconds"`        // How many seconds a player will be a zombie allowing them to reconnect.
 	LogoutRounds         ConfigInt         `yaml:"LogoutRounds"`         // How many rounds of uninterrupted meditation must be completed to log out.
+	Compression          ConfigBool        `yaml:"Compression"`          // Whether to offer MCCP2/MCCP3 compression to telnet clients
 }
 
 func (n *Network) Validate() {
 
 	// Ignore TelnetPort
+	// Ignore TelnetTLSPort
+	// Ignore SshPort
 	// Ignore LocalPort
 	// Ignore TimeoutMods
 
@@ -40,6 +40,10 @@ func (v *Validation) Validate() {
 	if v.PasswordSizeMax < v.PasswordSizeMin {
 		v.PasswordSizeMax = v.PasswordSizeMin
 	}
+	// Passwords are stored as bcrypt hashes, which only support up to 72 bytes
+	if v.PasswordSizeMax > 72 {
+		v.PasswordSizeMax = 72
+	}
 
 	if v.EmailOnJoin != `required` && v.EmailOnJoin != `none` {
 		v.EmailOnJoin = `optional`
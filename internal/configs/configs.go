@@ -44,6 +44,7 @@ type Config struct {
 	TextFormats  TextFormats  `yaml:"TextFormats"`
 	Translation  Translation  `yaml:"Translation"`
 	Network      Network      `yaml:"Network"`
+	Mssp         Mssp         `yaml:"Mssp"`
 	Scripting    Scripting    `yaml:"Scripting"`
 	SpecialRooms SpecialRooms `yaml:"SpecialRooms"`
 	Validation   Validation   `yaml:"Validation"`
@@ -194,6 +195,7 @@ func (c *Config) Validate() {
 	c.TextFormats.Validate()
 	c.Translation.Validate()
 	c.Network.Validate()
+	c.Mssp.Validate()
 	c.Scripting.Validate()
 	c.SpecialRooms.Validate()
 	c.Validation.Validate()
@@ -1,10 +1,22 @@
 package connections
 
+import (
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/term"
+)
+
 type ClientSettings struct {
 	Display DisplaySettings
 	// Is MSP enabled?
 	MSPEnabled        bool // Do they accept sound in their client?
 	SendTelnetGoAhead bool // Defaults false, should we send a IAC GA after prompts?
+	// Reported via TTYPE/MTTS
+	ClientName    string        // The first TTYPE response, such as "MUDLET"
+	TerminalType  string        // The second TTYPE response, such as "XTERM-256COLOR"
+	TerminalTypes []string      // Every TTYPE response received so far, in order
+	MTTS          term.MTTSFlag // Capabilities bitmask reported by the client
+	MTTSReported  bool          // Whether the client reported MTTS at all
 }
 
 type DisplaySettings struct {
@@ -15,3 +27,44 @@ type DisplaySettings struct {
 func (c ClientSettings) IsMsp() bool {
 	return c.MSPEnabled
 }
+
+// The best color mode the client is known to support
+func (c ClientSettings) ColorMode() term.ColorMode {
+
+	if c.MTTSReported {
+		switch {
+		case c.MTTS.Has(term.MTTS_TRUECOLOR):
+			return term.ColorModeTrueColor
+		case c.MTTS.Has(term.MTTS_256COLORS):
+			return term.ColorMode256
+		case c.MTTS.Has(term.MTTS_ANSI):
+			return term.ColorMode16
+		}
+		return term.ColorModeNone
+	}
+
+	return term.ColorModeFromTerminalType(c.TerminalType)
+}
+
+func (c ClientSettings) Supports256Color() bool {
+	return c.ColorMode() >= term.ColorMode256
+}
+
+func (c ClientSettings) SupportsTrueColor() bool {
+	return c.ColorMode() == term.ColorModeTrueColor
+}
+
+func (c ClientSettings) SupportsUTF8() bool {
+	if c.MTTSReported {
+		return c.MTTS.Has(term.MTTS_UTF8)
+	}
+	return strings.Contains(strings.ToUpper(c.TerminalType), `UTF-8`)
+}
+
+func (c ClientSettings) IsScreenReader() bool {
+	return c.MTTSReported && c.MTTS.Has(term.MTTS_SCREENREADER)
+}
+
+func (c ClientSettings) SupportsHyperlinks() bool {
+	return c.MTTSReported && c.MTTS.Has(term.MTTS_MSLP)
+}
@@ -0,0 +1,329 @@
+package connections
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/term"
+)
+
+//
+// MCCP2 (outbound) and MCCP3 (inbound) compression for telnet connections
+//
+
+var (
+	// Server wide compression counters
+	totalOutRaw  uint64 // bytes written to compressed connections before compression
+	totalOutSent uint64 // bytes actually sent over the wire for compressed connections
+	totalInRaw   uint64 // bytes received from compressed connections after decompression
+	totalInRecv  uint64 // bytes actually received over the wire for compressed connections
+
+	errCompressionWebsocket = errors.New("compression is not supported for websocket connections")
+)
+
+type CompressionStats struct {
+	Connections  int    // How many connections are currently compressing output
+	BytesOutRaw  uint64 // Output size before compression
+	BytesOutSent uint64 // Output size after compression
+	BytesInRaw   uint64 // Input size after decompression
+	BytesInRecv  uint64 // Input size before decompression
+}
+
+// How many bytes compression has saved in total
+func (s CompressionStats) BytesSaved() uint64 {
+	var saved uint64
+	if s.BytesOutRaw > s.BytesOutSent {
+		saved += s.BytesOutRaw - s.BytesOutSent
+	}
+	if s.BytesInRaw > s.BytesInRecv {
+		saved += s.BytesInRaw - s.BytesInRecv
+	}
+	return saved
+}
+
+// What percent of bytes compression has saved
+func (s CompressionStats) PercentSaved() float64 {
+	total := s.BytesOutRaw + s.BytesInRaw
+	if total == 0 {
+		return 0
+	}
+	return float64(s.BytesSaved()) / float64(total) * 100
+}
+
+// Returns server wide compression stats
+func GetCompressionStats() CompressionStats {
+
+	s := CompressionStats{
+		BytesOutRaw:  atomic.LoadUint64(&totalOutRaw),
+		BytesOutSent: atomic.LoadUint64(&totalOutSent),
+		BytesInRaw:   atomic.LoadUint64(&totalInRaw),
+		BytesInRecv:  atomic.LoadUint64(&totalInRecv),
+	}
+
+	lock.RLock()
+	defer lock.RUnlock()
+
+	for _, cd := range netConnections {
+		if cd.IsCompressing() {
+			s.Connections++
+		}
+	}
+
+	return s
+}
+
+// Counts the bytes that actually go over the wire
+type countingWriter struct {
+	w       io.Writer
+	counter *uint64
+}
+
+func (cw countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	atomic.AddUint64(cw.counter, uint64(n))
+	atomic.AddUint64(&totalOutSent, uint64(n))
+	return n, err
+}
+
+// Counts the bytes that actually come over the wire
+// Implements io.ByteReader so that the zlib reader never reads past the end of the stream
+type countingReader struct {
+	r       *bufio.Reader
+	counter *uint64
+}
+
+func (cr countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	atomic.AddUint64(cr.counter, uint64(n))
+	atomic.AddUint64(&totalInRecv, uint64(n))
+	return n, err
+}
+
+func (cr countingReader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		atomic.AddUint64(cr.counter, 1)
+		atomic.AddUint64(&totalInRecv, 1)
+	}
+	return b, err
+}
+
+// Whether output to this connection is currently compressed (MCCP2)
+func (cd *ConnectionDetails) IsCompressing() bool {
+	cd.writeLock.Lock()
+	defer cd.writeLock.Unlock()
+
+	return cd.compressor != nil
+}
+
+// Whether input from this connection is currently compressed (MCCP3)
+func (cd *ConnectionDetails) IsDecompressing() bool {
+	return cd.decompressing.Load()
+}
+
+// Returns the compression stats for this connection only
+func (cd *ConnectionDetails) CompressionStats() CompressionStats {
+	s := CompressionStats{
+		BytesOutRaw:  atomic.LoadUint64(&cd.bytesOutRaw),
+		BytesOutSent: atomic.LoadUint64(&cd.bytesOutSent),
+		BytesInRaw:   atomic.LoadUint64(&cd.bytesInRaw),
+		BytesInRecv:  atomic.LoadUint64(&cd.bytesInRecv),
+	}
+	if cd.IsCompressing() {
+		s.Connections = 1
+	}
+	return s
+}
+
+// Begins compressing everything written to the connection (MCCP2).
+// Should only be called after the client has responded with IAC DO MCCP2
+func (cd *ConnectionDetails) StartCompression() error {
+
+	if cd.wsConn != nil {
+		return errCompressionWebsocket
+	}
+
+	cd.writeLock.Lock()
+	defer cd.writeLock.Unlock()
+
+	if cd.compressor != nil {
+		return nil
+	}
+
+	// The start sequence itself must be sent uncompressed
+	if _, err := cd.conn.Write(term.Mccp2Start.BytesWithPayload(nil)); err != nil {
+		return err
+	}
+
+	zw, err := zlib.NewWriterLevel(countingWriter{cd.conn, &cd.bytesOutSent}, zlib.DefaultCompression)
+	if err != nil {
+		return err
+	}
+
+	cd.compressor = zw
+
+	return nil
+}
+
+// Ends the compressed stream, after which output is sent uncompressed.
+func (cd *ConnectionDetails) StopCompression() error {
+
+	cd.writeLock.Lock()
+	defer cd.writeLock.Unlock()
+
+	if cd.compressor == nil {
+		return nil
+	}
+
+	err := cd.compressor.Close()
+	cd.compressor = nil
+
+	return err
+}
+
+// Sets whether the client has agreed to send compressed input (MCCP3)
+// The client signals the actual start of compression with IAC SB MCCP3 IAC SE
+func (cd *ConnectionDetails) AllowInputCompression(allow bool) {
+	cd.mccp3Allowed.Store(allow)
+}
+
+// Writes to the compressed stream and flushes it so the client receives it immediately
+// Caller must hold writeLock
+func (cd *ConnectionDetails) writeCompressed(p []byte) (int, error) {
+
+	n, err := cd.compressor.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	if err = cd.compressor.Flush(); err != nil {
+		return n, err
+	}
+
+	atomic.AddUint64(&cd.bytesOutRaw, uint64(n))
+	atomic.AddUint64(&totalOutRaw, uint64(n))
+
+	return n, nil
+}
+
+// Reads from a telnet connection, handling the start and end of MCCP3 compressed input
+// Only ever called from the connections read loop.
+func (cd *ConnectionDetails) readTelnet(p []byte) (int, error) {
+
+	if cd.reader == nil {
+		cd.reader = bufio.NewReaderSize(cd.conn, ReadBufferSize)
+	}
+
+	if cd.decompressPending {
+		cd.decompressPending = false
+
+		zr, err := zlib.NewReader(countingReader{cd.reader, &cd.bytesInRecv})
+		if err != nil {
+			return 0, err
+		}
+		cd.decompressor = zr
+		cd.decompressing.Store(true)
+	}
+
+	if cd.decompressor != nil {
+
+		n, err := cd.decompressor.Read(p)
+
+		atomic.AddUint64(&cd.bytesInRaw, uint64(n))
+		atomic.AddUint64(&totalInRaw, uint64(n))
+
+		if err == io.EOF {
+			// Client ended the compressed stream, so switch back to uncompressed input
+			cd.decompressor.Close()
+			cd.decompressor = nil
+			cd.decompressing.Store(false)
+
+			if n > 0 {
+				return n, nil
+			}
+			return cd.readTelnet(p)
+		}
+
+		return n, err
+	}
+
+	// Part of a start sequence held back from the last read goes in front
+	held := copy(p, cd.partialStart)
+	cd.partialStart = nil
+
+	n, err := cd.reader.Read(p[held:])
+	n += held
+	if err != nil || n == 0 {
+		return n, err
+	}
+
+	if !cd.mccp3Allowed.Load() {
+		return n, nil
+	}
+
+	// Anything after the start sequence is compressed, so hand back
+	// only up to the end of the sequence and put the rest back in front of the reader
+	startSeq := term.Mccp3Start.BytesWithPayload(nil)
+	if idx := bytes.Index(p[:n], startSeq); idx > -1 {
+
+		end := idx + len(startSeq)
+
+		if end < n {
+			remainder := make([]byte, n-end)
+			copy(remainder, p[end:n])
+			cd.reader = bufio.NewReaderSize(io.MultiReader(bytes.NewReader(remainder), cd.reader), ReadBufferSize)
+		}
+
+		cd.decompressPending = true
+
+		return end, nil
+	}
+
+	// The start sequence may be split across reads, so hold back
+	// a trailing partial sequence until the rest of it arrives
+	if k := partialPrefixLen(p[:n], startSeq); k > 0 {
+
+		cd.partialStart = append([]byte{}, p[n-k:n]...)
+		n -= k
+
+		if n == 0 {
+			return cd.readTelnet(p)
+		}
+	}
+
+	return n, nil
+}
+
+// Returns the length of the longest end of data that is the start of seq, but not all of it
+func partialPrefixLen(data []byte, seq []byte) int {
+
+	for k := min(len(seq)-1, len(data)); k > 0; k-- {
+		if bytes.Equal(data[len(data)-k:], seq[:k]) {
+			return k
+		}
+	}
+
+	return 0
+}
+
+// Ends any compressed output so the client sees a complete stream before the connection closes
+func (cd *ConnectionDetails) closeCompression() {
+
+	cd.writeLock.Lock()
+	defer cd.writeLock.Unlock()
+
+	if cd.compressor == nil {
+		return
+	}
+
+	// Don't let a client that stopped reading hold up the disconnect
+	cd.conn.SetWriteDeadline(time.Now().Add(time.Second))
+
+	cd.compressor.Close()
+	cd.compressor = nil
+}
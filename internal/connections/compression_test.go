@@ -0,0 +1,169 @@
+package connections
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputCompression(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	cd := NewConnectionDetails(1, server, nil, nil)
+
+	go func() {
+		cd.StartCompression()
+		cd.Write([]byte("hello world\n"))
+		cd.StopCompression()
+		cd.Write([]byte("plain"))
+	}()
+
+	r := bufio.NewReader(client)
+
+	start := make([]byte, len(term.Mccp2Start.Chars))
+	_, err := io.ReadFull(r, start)
+	assert.NoError(t, err)
+	assert.Equal(t, term.Mccp2Start.Chars, start)
+
+	zr, err := zlib.NewReader(r)
+	assert.NoError(t, err)
+
+	out, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	assert.Equal(t, "hello world\r\n", string(out))
+
+	plain := make([]byte, 5)
+	_, err = io.ReadFull(r, plain)
+	assert.NoError(t, err)
+	assert.Equal(t, "plain", string(plain))
+
+	s := cd.CompressionStats()
+	assert.Equal(t, uint64(13), s.BytesOutRaw)
+	assert.Greater(t, s.BytesOutSent, uint64(0))
+}
+
+func TestInputDecompression(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	cd := NewConnectionDetails(1, server, nil, nil)
+	cd.AllowInputCompression(true)
+
+	compressed := bytes.Buffer{}
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte("look\r\n"))
+	zw.Flush()
+
+	firstChunk := append([]byte("n\r\n"), term.Mccp3Start.Chars...)
+	firstChunk = append(firstChunk, compressed.Bytes()...)
+
+	// Start sequence and compressed data arrive together
+	go client.Write(firstChunk)
+
+	buf := make([]byte, ReadBufferSize)
+
+	n, err := cd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, append([]byte("n\r\n"), term.Mccp3Start.Chars...), buf[:n])
+
+	n, err = cd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "look\r\n", string(buf[:n]))
+	assert.True(t, cd.IsDecompressing())
+
+	// Ending the compressed stream, followed by uncompressed input
+	compressed.Reset()
+	zw.Close()
+	go func() {
+		client.Write(compressed.Bytes())
+		client.Write([]byte("s\r\n"))
+	}()
+
+	n, err = cd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "s\r\n", string(buf[:n]))
+	assert.False(t, cd.IsDecompressing())
+
+	s := cd.CompressionStats()
+	assert.Equal(t, uint64(6), s.BytesInRaw)
+	assert.Greater(t, s.BytesInRecv, uint64(0))
+}
+
+func TestInputDecompressionSplitStart(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer server.Close()
+	defer client.Close()
+
+	cd := NewConnectionDetails(1, server, nil, nil)
+	cd.AllowInputCompression(true)
+
+	compressed := bytes.Buffer{}
+	zw := zlib.NewWriter(&compressed)
+	zw.Write([]byte("look\r\n"))
+	zw.Flush()
+
+	startSeq := term.Mccp3Start.Chars
+
+	// Start sequence split across reads, with compressed data right behind it
+	go func() {
+		client.Write(append([]byte("n\r\n"), startSeq[:2]...))
+		client.Write(startSeq[2:4])
+		client.Write(append(startSeq[4:], compressed.Bytes()...))
+	}()
+
+	buf := make([]byte, ReadBufferSize)
+
+	n, err := cd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "n\r\n", string(buf[:n]))
+
+	n, err = cd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, startSeq, buf[:n])
+
+	n, err = cd.Read(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, "look\r\n", string(buf[:n]))
+	assert.True(t, cd.IsDecompressing())
+}
+
+func TestCloseEndsCompressedStream(t *testing.T) {
+
+	server, client := net.Pipe()
+	defer client.Close()
+
+	cd := NewConnectionDetails(1, server, nil, nil)
+
+	go func() {
+		cd.StartCompression()
+		cd.Write([]byte("bye"))
+		cd.Close()
+	}()
+
+	r := bufio.NewReader(client)
+
+	start := make([]byte, len(term.Mccp2Start.Chars))
+	_, err := io.ReadFull(r, start)
+	assert.NoError(t, err)
+
+	zr, err := zlib.NewReader(r)
+	assert.NoError(t, err)
+
+	// Only a properly ended stream reads to EOF without error
+	out, err := io.ReadAll(zr)
+	assert.NoError(t, err)
+	assert.Equal(t, "bye", string(out))
+	assert.False(t, cd.IsCompressing())
+}
@@ -1,7 +1,10 @@
 package connections
 
 import (
+	"bufio"
+	"compress/zlib"
 	"errors"
+	"io"
 	"net"
 	"strings"
 	"sync"
@@ -124,6 +127,19 @@ type ConnectionDetails struct {
 	inputDisabled     bool
 	clientSettings    ClientSettings
 	heartbeat         *heartbeatManager
+	// Compression (MCCP2/MCCP3)
+	writeLock         sync.Mutex
+	compressor        *zlib.Writer  // non-nil while output is compressed
+	reader            *bufio.Reader // telnet input is read through this
+	decompressor      io.ReadCloser // non-nil while input is compressed
+	decompressPending bool          // the client has signaled the start of compressed input
+	partialStart      []byte        // the start of an MCCP3 start sequence that was split across reads
+	decompressing     atomic.Bool
+	mccp3Allowed      atomic.Bool
+	bytesOutRaw       uint64
+	bytesOutSent      uint64
+	bytesInRaw        uint64
+	bytesInRecv       uint64
 }
 
 func (cd *ConnectionDetails) IsWebSocket() bool {
@@ -194,6 +210,9 @@ func (cd *ConnectionDetails) Write(p []byte) (n int, err error) {
 		return 0, nil
 	}
 
+	// Convert colors the client has reported it can't display
+	p = term.DowngradeColors(p, cd.clientSettings.ColorMode())
+
 	if cd.wsConn != nil {
 		cd.wsLock.Lock()
 		defer cd.wsLock.Unlock()
@@ -214,6 +233,13 @@ func (cd *ConnectionDetails) Write(p []byte) (n int, err error) {
 		return len(p), nil
 	}
 
+	cd.writeLock.Lock()
+	defer cd.writeLock.Unlock()
+
+	if cd.compressor != nil {
+		return cd.writeCompressed(p)
+	}
+
 	return cd.conn.Write(p)
 }
 
@@ -229,7 +255,7 @@ func (cd *ConnectionDetails) Read(p []byte) (n int, err error) {
 		return len(message), nil
 	}
 
-	return cd.conn.Read(p)
+	return cd.readTelnet(p)
 }
 
 func (cd *ConnectionDetails) Close() {
@@ -241,6 +267,9 @@ func (cd *ConnectionDetails) Close() {
 		cd.wsConn.Close()
 		return
 	}
+
+	cd.closeCompression()
+
 	cd.conn.Close()
 }
 
@@ -80,6 +80,17 @@ func IsWebsocket(id ConnectionId) bool {
 	return false
 }
 
+func IsSSH(id ConnectionId) bool {
+	lock.Lock()
+	defer lock.Unlock()
+
+	if cd, ok := netConnections[id]; ok {
+		return cd.IsSSH()
+	}
+
+	return false
+}
+
 func GetAllConnectionIds() []ConnectionId {
 
 	lock.Lock()
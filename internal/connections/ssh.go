@@ -0,0 +1,79 @@
+package connections
+
+import (
+	"net"
+	"strconv"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// Set on the ssh.Permissions of a session that logged in with a public key
+	SSHUserIdExtension = `gomud-userid`
+)
+
+// Wraps an SSH session channel so that it can be used like any other net.Conn
+type SSHConn struct {
+	channel    ssh.Channel
+	serverConn *ssh.ServerConn
+}
+
+func NewSSHConn(channel ssh.Channel, serverConn *ssh.ServerConn) *SSHConn {
+	return &SSHConn{
+		channel:    channel,
+		serverConn: serverConn,
+	}
+}
+
+func (s *SSHConn) Read(p []byte) (int, error) {
+	return s.channel.Read(p)
+}
+
+func (s *SSHConn) Write(p []byte) (int, error) {
+	return s.channel.Write(p)
+}
+
+// Closing the session also closes the underlying connection, since only one session is allowed per connection.
+func (s *SSHConn) Close() error {
+	s.channel.Close()
+	return s.serverConn.Close()
+}
+
+func (s *SSHConn) LocalAddr() net.Addr {
+	return s.serverConn.LocalAddr()
+}
+
+func (s *SSHConn) RemoteAddr() net.Addr {
+	return s.serverConn.RemoteAddr()
+}
+
+// Deadlines aren't supported on SSH channels
+func (s *SSHConn) SetDeadline(t time.Time) error      { return nil }
+func (s *SSHConn) SetReadDeadline(t time.Time) error  { return nil }
+func (s *SSHConn) SetWriteDeadline(t time.Time) error { return nil }
+
+// The username the client provided when connecting
+func (s *SSHConn) User() string {
+	return s.serverConn.User()
+}
+
+// The UserId that was authenticated by public key, or zero if none was
+func (s *SSHConn) AuthenticatedUserId() int {
+	if s.serverConn.Permissions == nil {
+		return 0
+	}
+	userId, _ := strconv.Atoi(s.serverConn.Permissions.Extensions[SSHUserIdExtension])
+	return userId
+}
+
+func (cd *ConnectionDetails) IsSSH() bool {
+	_, ok := cd.conn.(*SSHConn)
+	return ok
+}
+
+// Returns the SSH details of the connection, or nil if it isn't an SSH connection
+func (cd *ConnectionDetails) SSHConn() *SSHConn {
+	sshConn, _ := cd.conn.(*SSHConn)
+	return sshConn
+}
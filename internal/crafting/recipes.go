@@ -0,0 +1,188 @@
+package crafting
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/skills"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+var (
+	recipes = map[int]*Recipe{}
+)
+
+type Ingredient struct {
+	ItemId   int `yaml:"itemid"`
+	Quantity int `yaml:"quantity,omitempty"` // How many are needed (defaults to 1)
+}
+
+type Recipe struct {
+	RecipeId       int             `yaml:"recipeid"`
+	Name           string          `yaml:"name"`
+	Description    string          `yaml:"description,omitempty"`
+	Ingredients    []Ingredient    `yaml:"ingredients"`              // Items consumed by crafting, whether it succeeds or not
+	Tools          []int           `yaml:"tools,omitempty"`          // ItemIds that must be carried, but are not consumed
+	RoomId         int             `yaml:"roomid,omitempty"`         // If set, crafting can only happen in this room
+	Skill          skills.SkillTag `yaml:"skill,omitempty"`          // If set, this skill is required
+	SkillLevel     int             `yaml:"skilllevel,omitempty"`     // Minimum level of the required skill
+	Chance         int             `yaml:"chance,omitempty"`         // 1-100 percent chance of success
+	OutputItemId   int             `yaml:"outputitemid"`             // The item created
+	OutputQuantity int             `yaml:"outputquantity,omitempty"` // How many are created (defaults to 1)
+}
+
+func (r *Recipe) Id() int {
+	return r.RecipeId
+}
+
+func (r *Recipe) Validate() error {
+
+	if r.Name == `` {
+		return fmt.Errorf("recipeId %d has no name", r.RecipeId)
+	}
+
+	if r.OutputItemId == 0 {
+		return fmt.Errorf("recipeId %d (%s) has no outputitemid", r.RecipeId, r.Name)
+	}
+
+	if len(r.Ingredients) == 0 {
+		return fmt.Errorf("recipeId %d (%s) has no ingredients", r.RecipeId, r.Name)
+	}
+
+	for i := range r.Ingredients {
+		if r.Ingredients[i].ItemId == 0 {
+			return fmt.Errorf("recipeId %d (%s) has an ingredient with no itemid", r.RecipeId, r.Name)
+		}
+		if r.Ingredients[i].Quantity < 1 {
+			r.Ingredients[i].Quantity = 1
+		}
+	}
+
+	if r.OutputQuantity < 1 {
+		r.OutputQuantity = 1
+	}
+
+	if r.Chance < 1 || r.Chance > 100 {
+		r.Chance = 100
+	}
+
+	if r.Skill == `` {
+		r.SkillLevel = 0
+	} else if r.SkillLevel < 1 {
+		r.SkillLevel = 1
+	}
+
+	return nil
+}
+
+func (r *Recipe) Filename() string {
+	filename := util.ConvertForFilename(r.Name)
+	return fmt.Sprintf("%d-%s.yaml", r.RecipeId, filename)
+}
+
+func (r *Recipe) Filepath() string {
+	return r.Filename()
+}
+
+func (r *Recipe) GetScript() string {
+
+	scriptPath := r.GetScriptPath()
+	// Load the script into a string
+	if _, err := os.Stat(scriptPath); err == nil {
+		if bytes, err := os.ReadFile(scriptPath); err == nil {
+			return string(bytes)
+		}
+	}
+
+	return ``
+}
+
+func (r *Recipe) GetScriptPath() string {
+	// Load any script for the recipe
+
+	recipeFilePath := r.Filename()
+	scriptFilePath := strings.Replace(recipeFilePath, `.yaml`, `.js`, 1)
+
+	fullScriptPath := strings.Replace(string(configs.GetFilePathsConfig().DataFiles)+`/recipes/`+r.Filepath(),
+		recipeFilePath,
+		scriptFilePath,
+		1)
+
+	return util.FilePath(fullScriptPath)
+}
+
+// Returns a map of itemId => quantity needed
+func (r *Recipe) IngredientCounts() map[int]int {
+	counts := map[int]int{}
+	for _, ing := range r.Ingredients {
+		counts[ing.ItemId] += ing.Quantity
+	}
+	return counts
+}
+
+func GetRecipe(recipeId int) *Recipe {
+	if r, ok := recipes[recipeId]; ok {
+		return r
+	}
+	return nil
+}
+
+// Returns all recipes, sorted by name
+func GetAllRecipes() []*Recipe {
+	ret := make([]*Recipe, 0, len(recipes))
+	for _, r := range recipes {
+		ret = append(ret, r)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].Name < ret[j].Name
+	})
+	return ret
+}
+
+// Finds a recipe by full or partial name
+func FindRecipe(name string) *Recipe {
+
+	names := []string{}
+	byName := map[string]*Recipe{}
+	for _, r := range recipes {
+		names = append(names, r.Name)
+		byName[r.Name] = r
+	}
+
+	match, closeMatch := util.FindMatchIn(strings.ToLower(name), names...)
+	if match == `` {
+		match = closeMatch
+	}
+
+	return byName[match]
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	recipePath := string(configs.GetFilePathsConfig().DataFiles) + `/recipes`
+
+	// Recipes are optional, a world without them simply has nothing to craft.
+	if _, err := os.Stat(recipePath); errors.Is(err, os.ErrNotExist) {
+		recipes = map[int]*Recipe{}
+		mudlog.Info("crafting.LoadDataFiles()", "loadedCount", 0, "Time Taken", time.Since(start))
+		return
+	}
+
+	tmpRecipes, err := fileloader.LoadAllFlatFiles[int, *Recipe](recipePath)
+	if err != nil {
+		panic(err)
+	}
+
+	recipes = tmpRecipes
+
+	mudlog.Info("crafting.LoadDataFiles()", "loadedCount", len(recipes), "Time Taken", time.Since(start))
+}
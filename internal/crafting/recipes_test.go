@@ -0,0 +1,67 @@
+package crafting
+
+import (
+	"testing"
+)
+
+func TestRecipe_Validate(t *testing.T) {
+
+	r := &Recipe{
+		RecipeId: 1,
+		Name:     `tea`,
+		Ingredients: []Ingredient{
+			{ItemId: 10},
+			{ItemId: 11, Quantity: 2},
+		},
+		Chance:       0,
+		SkillLevel:   3,
+		OutputItemId: 20,
+	}
+
+	if err := r.Validate(); err != nil {
+		t.Fatalf("Validate() returned error: %v", err)
+	}
+
+	if r.Ingredients[0].Quantity != 1 {
+		t.Errorf("ingredient quantity = %d, want 1", r.Ingredients[0].Quantity)
+	}
+	if r.OutputQuantity != 1 {
+		t.Errorf("OutputQuantity = %d, want 1", r.OutputQuantity)
+	}
+	if r.Chance != 100 {
+		t.Errorf("Chance = %d, want 100", r.Chance)
+	}
+	if r.SkillLevel != 0 {
+		t.Errorf("SkillLevel = %d, want 0 when no skill is required", r.SkillLevel)
+	}
+
+	invalid := []*Recipe{
+		{RecipeId: 2, Ingredients: []Ingredient{{ItemId: 10}}, OutputItemId: 20},
+		{RecipeId: 3, Name: `nothing out`, Ingredients: []Ingredient{{ItemId: 10}}},
+		{RecipeId: 4, Name: `nothing in`, OutputItemId: 20},
+		{RecipeId: 5, Name: `bad ingredient`, Ingredients: []Ingredient{{Quantity: 2}}, OutputItemId: 20},
+	}
+
+	for _, bad := range invalid {
+		if err := bad.Validate(); err == nil {
+			t.Errorf("Validate() on recipe %d expected an error", bad.RecipeId)
+		}
+	}
+}
+
+func TestRecipe_IngredientCounts(t *testing.T) {
+
+	r := &Recipe{
+		Ingredients: []Ingredient{
+			{ItemId: 10, Quantity: 1},
+			{ItemId: 11, Quantity: 2},
+			{ItemId: 10, Quantity: 3},
+		},
+	}
+
+	counts := r.IngredientCounts()
+
+	if counts[10] != 4 || counts[11] != 2 || len(counts) != 2 {
+		t.Errorf("IngredientCounts() = %v, want map[10:4 11:2]", counts)
+	}
+}
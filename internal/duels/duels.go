@@ -0,0 +1,236 @@
+package duels
+
+import (
+	"errors"
+	"slices"
+	"sort"
+)
+
+type State int
+
+const (
+	Challenged State = iota // Waiting for the defender to accept
+	Starting                // Accepted, spectators are placing bets before the fight starts
+	Fighting                // The fight is on
+)
+
+var (
+	ErrDuelSelf        = errors.New(`you can't duel yourself`)
+	ErrAlreadyDueling  = errors.New(`already in a duel`)
+	ErrBettingClosed   = errors.New(`betting on this duel is closed`)
+	ErrDuelistBet      = errors.New(`duelists can't bet on their own duel`)
+	ErrAlreadyBet      = errors.New(`you have already bet on this duel`)
+	ErrInvalidBet      = errors.New(`you can only bet gold on one of the duelists`)
+	ErrNotArena        = errors.New(`duels can only be bet on in an arena`)
+	ErrDuelNotAccepted = errors.New(`the duel has already been accepted`)
+)
+
+type Bet struct {
+	UserId   int    // Who placed the bet
+	Username string // So winnings can be paid if they have logged off
+	OnUserId int    // Which duelist they bet on
+	Gold     int
+}
+
+type Duel struct {
+	ChallengerId     int
+	DefenderId       int
+	RoomId           int   // Where the duel is fought
+	SpectatorRoomIds []int // Rooms the duel can be watched from, other than RoomId. Only arenas have spectators.
+	Arena            bool  // Whether the duel is fought in an arena, and can be bet on
+	State            State
+	StateRound       uint64 // The round the current state ends: when a challenge expires, or when the fight starts
+	Bets             []Bet
+}
+
+var (
+	duelMap = map[int]*Duel{} // key is the user id of either duelist
+)
+
+// Starts a new duel waiting for the defender to accept
+func Challenge(challengerId int, defenderId int, roomId int, expiresRound uint64) (*Duel, error) {
+
+	if challengerId == defenderId {
+		return nil, ErrDuelSelf
+	}
+
+	if Get(challengerId) != nil || Get(defenderId) != nil {
+		return nil, ErrAlreadyDueling
+	}
+
+	d := &Duel{
+		ChallengerId: challengerId,
+		DefenderId:   defenderId,
+		RoomId:       roomId,
+		State:        Challenged,
+		StateRound:   expiresRound,
+		Bets:         []Bet{},
+	}
+
+	duelMap[challengerId] = d
+	duelMap[defenderId] = d
+
+	return d, nil
+}
+
+func Get(userId int) *Duel {
+	if d, ok := duelMap[userId]; ok {
+		return d
+	}
+	return nil
+}
+
+// Returns every duel, oldest challenger first
+func GetAll() []*Duel {
+	ret := []*Duel{}
+	for userId, d := range duelMap {
+		if userId == d.ChallengerId {
+			ret = append(ret, d)
+		}
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].ChallengerId < ret[j].ChallengerId
+	})
+	return ret
+}
+
+// Returns true if the two users are fighting a duel against each other
+func IsDueling(userId1 int, userId2 int) bool {
+	d := Get(userId1)
+	if d == nil || d.State != Fighting {
+		return false
+	}
+	return d.Opponent(userId1) == userId2
+}
+
+// Removes the duel without any result. Bets must be settled first.
+func Remove(d *Duel) {
+	if duelMap[d.ChallengerId] == d {
+		delete(duelMap, d.ChallengerId)
+	}
+	if duelMap[d.DefenderId] == d {
+		delete(duelMap, d.DefenderId)
+	}
+}
+
+func (d *Duel) IsDuelist(userId int) bool {
+	return userId == d.ChallengerId || userId == d.DefenderId
+}
+
+// Returns the other duelist, or zero if the user isn't in this duel
+func (d *Duel) Opponent(userId int) int {
+	if userId == d.ChallengerId {
+		return d.DefenderId
+	}
+	if userId == d.DefenderId {
+		return d.ChallengerId
+	}
+	return 0
+}
+
+// Whether the duel can be seen from a room
+func (d *Duel) IsWatchedFrom(roomId int) bool {
+	return roomId == d.RoomId || slices.Contains(d.SpectatorRoomIds, roomId)
+}
+
+// Accepts the challenge. Arena duels wait for bets until startRound, everything else starts right away.
+func (d *Duel) Accept(arena bool, spectatorRoomIds []int, startRound uint64, roundNow uint64) error {
+
+	if d.State != Challenged {
+		return ErrDuelNotAccepted
+	}
+
+	d.Arena = arena
+	d.SpectatorRoomIds = append([]int{}, spectatorRoomIds...)
+
+	if arena && startRound > roundNow {
+		d.State = Starting
+		d.StateRound = startRound
+		return nil
+	}
+
+	d.State = Fighting
+	d.StateRound = roundNow
+
+	return nil
+}
+
+// Moves a starting duel on to the fight
+func (d *Duel) Start(roundNow uint64) {
+	d.State = Fighting
+	d.StateRound = roundNow
+}
+
+// Records a bet. The gold should already have been taken from the bettor.
+func (d *Duel) PlaceBet(userId int, username string, onUserId int, gold int) error {
+
+	if !d.Arena {
+		return ErrNotArena
+	}
+
+	if d.State != Starting {
+		return ErrBettingClosed
+	}
+
+	if d.IsDuelist(userId) {
+		return ErrDuelistBet
+	}
+
+	if !d.IsDuelist(onUserId) || gold < 1 {
+		return ErrInvalidBet
+	}
+
+	for _, b := range d.Bets {
+		if b.UserId == userId {
+			return ErrAlreadyBet
+		}
+	}
+
+	d.Bets = append(d.Bets, Bet{
+		UserId:   userId,
+		Username: username,
+		OnUserId: onUserId,
+		Gold:     gold,
+	})
+
+	return nil
+}
+
+// Total gold bet on a duelist
+func (d *Duel) BetTotal(onUserId int) int {
+	total := 0
+	for _, b := range d.Bets {
+		if b.OnUserId == onUserId {
+			total += b.Gold
+		}
+	}
+	return total
+}
+
+// Works out how much gold each bet returns, in the same order as Bets.
+// Everyone who backed the winner shares the whole pot in proportion to their stake.
+// If nobody backed the winner, or there is no winner (winnerId of zero), every bet is refunded.
+func (d *Duel) Payouts(winnerId int) []int {
+
+	payouts := make([]int, len(d.Bets))
+
+	pot := 0
+	for _, b := range d.Bets {
+		pot += b.Gold
+	}
+
+	winningStake := 0
+	if winnerId > 0 {
+		winningStake = d.BetTotal(winnerId)
+	}
+
+	for i, b := range d.Bets {
+		if winningStake == 0 {
+			payouts[i] = b.Gold
+		} else if b.OnUserId == winnerId {
+			payouts[i] = b.Gold * pot / winningStake
+		}
+	}
+
+	return payouts
+}
@@ -0,0 +1,88 @@
+package duels
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChallenge(t *testing.T) {
+	clear(duelMap)
+
+	_, err := Challenge(1, 1, 100, 10)
+	assert.ErrorIs(t, err, ErrDuelSelf)
+
+	d, err := Challenge(1, 2, 100, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, d, Get(1))
+	assert.Equal(t, d, Get(2))
+	assert.Equal(t, 2, d.Opponent(1))
+	assert.Equal(t, 1, d.Opponent(2))
+	assert.Equal(t, 0, d.Opponent(3))
+
+	_, err = Challenge(3, 2, 100, 10)
+	assert.ErrorIs(t, err, ErrAlreadyDueling)
+
+	assert.False(t, IsDueling(1, 2), "Expected a challenge not to count until accepted")
+
+	assert.NoError(t, d.Accept(false, nil, 20, 5))
+	assert.Equal(t, Fighting, d.State, "Expected duels outside of an arena to start right away")
+	assert.True(t, IsDueling(1, 2))
+	assert.True(t, IsDueling(2, 1))
+	assert.False(t, IsDueling(1, 3))
+	assert.ErrorIs(t, d.Accept(false, nil, 20, 5), ErrDuelNotAccepted)
+
+	assert.Len(t, GetAll(), 1)
+
+	Remove(d)
+	assert.Nil(t, Get(1))
+	assert.Nil(t, Get(2))
+	assert.Len(t, GetAll(), 0)
+}
+
+func TestArenaBets(t *testing.T) {
+	clear(duelMap)
+
+	d, _ := Challenge(1, 2, 100, 10)
+
+	assert.ErrorIs(t, d.PlaceBet(3, `carl`, 1, 50), ErrNotArena)
+
+	assert.NoError(t, d.Accept(true, []int{101, 102}, 10, 5))
+	assert.Equal(t, Starting, d.State)
+	assert.False(t, IsDueling(1, 2), "Expected arena duels to wait for bets")
+	assert.True(t, d.IsWatchedFrom(100))
+	assert.True(t, d.IsWatchedFrom(102))
+	assert.False(t, d.IsWatchedFrom(103))
+
+	assert.ErrorIs(t, d.PlaceBet(1, `admin`, 1, 50), ErrDuelistBet)
+	assert.ErrorIs(t, d.PlaceBet(3, `carl`, 4, 50), ErrInvalidBet)
+	assert.ErrorIs(t, d.PlaceBet(3, `carl`, 1, 0), ErrInvalidBet)
+
+	assert.NoError(t, d.PlaceBet(3, `carl`, 1, 100))
+	assert.ErrorIs(t, d.PlaceBet(3, `carl`, 2, 100), ErrAlreadyBet)
+	assert.NoError(t, d.PlaceBet(4, `dave`, 1, 300))
+	assert.NoError(t, d.PlaceBet(5, `erin`, 2, 200))
+
+	assert.Equal(t, 400, d.BetTotal(1))
+	assert.Equal(t, 200, d.BetTotal(2))
+
+	// The whole pot of 600 is shared by the bets on the winner
+	assert.Equal(t, []int{150, 450, 0}, d.Payouts(1))
+	assert.Equal(t, []int{0, 0, 600}, d.Payouts(2))
+	// No winner refunds everyone
+	assert.Equal(t, []int{100, 300, 200}, d.Payouts(0))
+
+	d.Start(10)
+	assert.True(t, IsDueling(1, 2))
+	assert.ErrorIs(t, d.PlaceBet(6, `fred`, 1, 50), ErrBettingClosed)
+}
+
+func TestPayoutsNobodyBackedWinner(t *testing.T) {
+	clear(duelMap)
+
+	d, _ := Challenge(1, 2, 100, 10)
+	d.Accept(true, nil, 10, 5)
+	d.PlaceBet(3, `carl`, 1, 100)
+
+	assert.Equal(t, []int{100}, d.Payouts(2), "Expected bets to be refunded when nobody backed the winner")
+}
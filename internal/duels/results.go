@@ -0,0 +1,156 @@
+package duels
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+// Whether a duelist has taken enough damage to lose
+func IsBeaten(user *users.UserRecord) bool {
+
+	if user.Character.Health < 1 {
+		return true
+	}
+
+	endPct := int(configs.GetGamePlayConfig().Duels.EndHealthPercent)
+
+	return user.Character.Health*100 <= user.Character.HealthMax.Value*endPct
+}
+
+// Sends a message to everyone that can see the duel, including the duelists
+func (d *Duel) Announce(txt string, excludeUserIds ...int) {
+
+	for _, roomId := range append([]int{d.RoomId}, d.SpectatorRoomIds...) {
+		events.AddToQueue(events.Message{
+			RoomId:         roomId,
+			Text:           txt + "\n",
+			ExcludeUserIds: excludeUserIds,
+		})
+	}
+
+}
+
+// Calls off a duel with no winner, refunding any bets
+func Cancel(d *Duel) {
+
+	Remove(d)
+
+	if d.State == Fighting {
+		stopFighting(users.GetByUserId(d.ChallengerId), d.DefenderId)
+		stopFighting(users.GetByUserId(d.DefenderId), d.ChallengerId)
+	}
+
+	settleBets(d, 0)
+}
+
+// Ends a duel with a winner. The result goes on both records,
+// and anyone who bet on the winner is paid.
+func End(d *Duel, winnerId int) {
+
+	Remove(d)
+
+	loserId := d.Opponent(winnerId)
+
+	winner := users.GetByUserId(winnerId)
+	loser := users.GetByUserId(loserId)
+
+	stopFighting(winner, loserId)
+	stopFighting(loser, winnerId)
+
+	if winner != nil && loser != nil {
+		winner.Character.KD.AddDuelWin(loser.UserId, loser.Character.Name)
+		loser.Character.KD.AddDuelLoss(winner.UserId, winner.Character.Name)
+
+		d.Announce(fmt.Sprintf(`<ansi fg="yellow-bold">***</ansi> <ansi fg="username">%s</ansi> has won the duel against <ansi fg="username">%s</ansi>! <ansi fg="yellow-bold">***</ansi>`, winner.Character.Name, loser.Character.Name))
+	}
+
+	settleBets(d, winnerId)
+}
+
+// Ends the duel if the user was just beaten by their opponent, with the opponent as the winner.
+// Losing to a duel opponent never kills or downs anyone, so the loser is left on their feet.
+// Damage from anyone else isn't forgiven. Returns true if the duel ended.
+func EndIfBeaten(user *users.UserRecord, attackerUserId int) bool {
+
+	if !IsDueling(user.UserId, attackerUserId) {
+		return false
+	}
+
+	if !IsBeaten(user) {
+		return false
+	}
+
+	End(Get(user.UserId), attackerUserId)
+
+	if user.Character.Health < 1 {
+		user.Character.Health = 1
+	}
+
+	return true
+}
+
+// Calls off every duel, refunding all bets. Used when the server is shutting down,
+// since bets only live in memory.
+func CancelAll() {
+	for _, d := range GetAll() {
+		Cancel(d)
+	}
+}
+
+// Stops a duelist attacking their opponent.
+func stopFighting(user *users.UserRecord, opponentId int) {
+
+	if user == nil {
+		return
+	}
+
+	if user.Character.IsAggro(opponentId, 0) {
+		user.Character.EndAggro()
+	}
+
+	// Damage from a duel shouldn't count toward a pvp kill later on
+	delete(user.Character.PlayerDamage, opponentId)
+}
+
+func settleBets(d *Duel, winnerId int) {
+
+	payouts := d.Payouts(winnerId)
+
+	for i, b := range d.Bets {
+
+		gold := payouts[i]
+		if gold < 1 {
+			if bettor := users.GetByUserId(b.UserId); bettor != nil {
+				bettor.SendText(fmt.Sprintf(`You lost your bet of <ansi fg="gold">%d gold</ansi>.`, b.Gold))
+			}
+			continue
+		}
+
+		if bettor := users.GetByUserId(b.UserId); bettor != nil {
+
+			bettor.Character.Gold += gold
+
+			if winnerId == 0 || gold == b.Gold {
+				bettor.SendText(fmt.Sprintf(`Your bet of <ansi fg="gold">%d gold</ansi> is returned to you.`, b.Gold))
+			} else {
+				bettor.SendText(fmt.Sprintf(`You won your bet! You collect <ansi fg="gold">%d gold</ansi>.`, gold))
+			}
+
+			continue
+		}
+
+		// Logged off before the duel was over, but still gets paid
+		if bettor, err := users.LoadUser(b.Username, true); err == nil {
+			bettor.Character.Gold += gold
+			users.SaveUser(*bettor)
+		} else {
+			mudlog.Error("duels.settleBets()", "username", b.Username, "gold", gold, "error", err)
+		}
+	}
+
+	d.Bets = d.Bets[:0]
+}
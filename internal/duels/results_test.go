@@ -0,0 +1,104 @@
+package duels
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Logs in a pair of duelists, saving to a throwaway store when they log out again
+func loginDuelists(t *testing.T) (challenger *users.UserRecord, defender *users.UserRecord) {
+
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+
+	store, err := users.NewSQLiteUserStore(filepath.Join(t.TempDir(), `users.db`))
+	require.NoError(t, err)
+	users.SetUserStore(store)
+
+	for i, name := range []string{`Challenger`, `Defender`} {
+
+		u := users.NewUserRecord(9001+i, uint64(9001+i))
+		u.Username = name
+		u.Character.Name = name
+		u.Character.HealthMax.Value = 100
+		u.Character.Health = 100
+
+		_, _, err := users.LoginUser(u, connections.ConnectionId(9001+i))
+		require.NoError(t, err)
+	}
+
+	t.Cleanup(func() {
+		users.LogOutUserByConnectionId(9001)
+		users.LogOutUserByConnectionId(9002)
+		users.SetUserStore(users.NewYamlUserStore())
+		clear(duelMap)
+	})
+
+	return users.GetByUserId(9001), users.GetByUserId(9002)
+}
+
+func startDuel(t *testing.T, challengerId int, defenderId int) *Duel {
+	clear(duelMap)
+
+	d, err := Challenge(challengerId, defenderId, 100, 10)
+	require.NoError(t, err)
+	require.NoError(t, d.Accept(false, nil, 20, 5))
+
+	return d
+}
+
+func TestEndIfBeatenBySpell(t *testing.T) {
+
+	challenger, defender := loginDuelists(t)
+	startDuel(t, challenger.UserId, defender.UserId)
+
+	// A spell cast by the challenger takes the defender well below zero
+	challenger.Character.SetCast(0, characters.SpellAggroInfo{SpellId: `sparks`, TargetUserIds: []int{defender.UserId}})
+	defender.Character.Health = -12
+
+	assert.False(t, EndIfBeaten(defender, 0), "Expected damage from outside the duel not to end it")
+	assert.True(t, IsDueling(challenger.UserId, defender.UserId))
+
+	assert.True(t, EndIfBeaten(defender, challenger.UserId))
+
+	assert.Nil(t, Get(defender.UserId))
+	assert.Equal(t, 1, defender.Character.Health, "Expected the loser to be left on their feet")
+	assert.Nil(t, challenger.Character.Aggro, "Expected the winner to stop casting at the loser")
+	assert.Equal(t, 1, challenger.Character.KD.TotalDuelWins)
+	assert.Equal(t, 1, defender.Character.KD.TotalDuelLosses)
+}
+
+func TestEndIfBeaten(t *testing.T) {
+
+	challenger, defender := loginDuelists(t)
+
+	tests := []struct {
+		name       string
+		health     int
+		attackerId int
+		ended      bool
+		wantHealth int
+	}{
+		{"Still standing", 50, 9001, false, 50},
+		{"At the end percent", 10, 9001, true, 10},
+		{"Downed by the opponent", -3, 9001, true, 1},
+		{"Downed by someone else", -3, 0, false, -3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			startDuel(t, challenger.UserId, defender.UserId)
+			defender.Character.Health = tt.health
+
+			assert.Equal(t, tt.ended, EndIfBeaten(defender, tt.attackerId))
+			assert.Equal(t, !tt.ended, IsDueling(challenger.UserId, defender.UserId))
+			assert.Equal(t, tt.wantHealth, defender.Character.Health)
+		})
+	}
+}
@@ -71,6 +71,7 @@ type Broadcast struct {
 	TextScreenReader string // optional text for screenreader friendliness
 	IsCommunication  bool
 	SourceIsMod      bool
+	SourceUserId     int // User that sent it, so that anyone ignoring them can skip it
 	SkipLineRefresh  bool
 }
 
@@ -78,6 +79,7 @@ func (b Broadcast) Type() string { return `Broadcast` }
 
 type Message struct {
 	UserId          int
+	SourceUserId    int // User the message came from, so that anyone ignoring them can skip it
 	ExcludeUserIds  []int
 	RoomId          int
 	Text            string
@@ -91,9 +93,10 @@ type Communication struct {
 	SourceUserId        int    // User that sent the message
 	SourceMobInstanceId int    // Mob that sent the message
 	TargetUserId        int    // Sent to only 1 person
-	CommType            string // say, party, broadcast, whisper, shout
+	CommType            string // say, party, broadcast, whisper, shout, or a chat channel name
 	Name                string
 	Message             string
+	Recipients          []int // Chat channels only: the users that received the message
 }
 
 func (m Communication) Type() string { return `Communication` }
@@ -160,6 +163,7 @@ type EquipmentChange struct {
 	BankChange    int
 	ItemsWorn     []items.Item
 	ItemsRemoved  []items.Item
+	ItemsChanged  []items.Item // Equipment that is still worn, but changed in some way (worn down, repaired, etc.)
 }
 
 func (i EquipmentChange) Type() string { return `EquipmentChange` }
@@ -268,6 +272,24 @@ type DayNightCycle struct {
 
 func (l DayNightCycle) Type() string { return `DayNightCycle` }
 
+// Fired when the in-game date rolls over to a new day
+type NewDay struct {
+	Day   int
+	Month int
+	Year  int
+}
+
+func (l NewDay) Type() string { return `NewDay` }
+
+// Fired when the weather of a zone changes
+type WeatherChange struct {
+	Zone          string
+	FromWeatherId string
+	ToWeatherId   string
+}
+
+func (l WeatherChange) Type() string { return `WeatherChange` }
+
 type Looking struct {
 	UserId int
 	RoomId int
@@ -315,6 +337,13 @@ type CharacterTrained struct {
 
 func (p CharacterTrained) Type() string { return `CharacterTrained` }
 
+// A cooldown started or ran out
+type CharacterCooldownsChanged struct {
+	UserId int
+}
+
+func (p CharacterCooldownsChanged) Type() string { return `CharacterCooldownsChanged` }
+
 // any stats or healthmax etc. have changed
 type CharacterStatsChanged struct {
 	UserId int
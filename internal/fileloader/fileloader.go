@@ -302,6 +302,21 @@ func SaveFlatFile[T LoadableSimple](basePath string, dataUnit T, saveOptions ...
 	return nil
 }
 
+// Removes the file that SaveFlatFile() would have written the data unit to
+func DeleteFlatFile[T LoadableSimple](basePath string, dataUnit T) error {
+
+	// Normalize slashes
+	basePath = filepath.FromSlash(basePath)
+
+	path := filepath.Join(basePath, dataUnit.Filepath())
+
+	if err := os.Remove(path); err != nil {
+		return errors.Wrap(err, `filepath: `+path)
+	}
+
+	return nil
+}
+
 // Returns the number of files saved and error
 func SaveAllFlatFiles[K comparable, T Loadable[K]](basePath string, data map[K]T, saveOptions ...SaveOption) (int, error) {
 
@@ -7,14 +7,19 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
 )
 
 func HandleFlags() {
 	var portsearch string
+	var migrateUsersSQLite bool
 
 	flag.StringVar(&portsearch, "port-search", "", "Search for the first 10 open ports: -port-search=30000-40000")
+	flag.BoolVar(&migrateUsersSQLite, "migrate-users-sqlite", false, "Copy all users from yaml files into the SQLite database (FilePaths.UserDatabaseFile) and exit")
 
 	flag.Parse()
 
@@ -22,6 +27,35 @@ func HandleFlags() {
 		doPortSearch(portsearch)
 		os.Exit(0)
 	}
+
+	if migrateUsersSQLite {
+		if !doUserMigrationSQLite() {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+}
+
+func doUserMigrationSQLite() bool {
+
+	configs.ReloadConfig()
+
+	dbFile := configs.GetFilePathsConfig().UserDatabaseFile.String()
+
+	mudlog.Info("-migrate-users-sqlite", "message", "Copying users to SQLite", "database", dbFile)
+
+	start := time.Now()
+
+	migratedCt, err := users.MigrateFlatFilesToSQLite()
+	if err != nil {
+		mudlog.Error("-migrate-users-sqlite", "error", err, "migrated", migratedCt)
+		return false
+	}
+
+	mudlog.Info("-migrate-users-sqlite", "message", fmt.Sprintf("Copied %d users", migratedCt), "Time Taken", time.Since(start))
+	mudlog.Info("-migrate-users-sqlite", "message", "Set FilePaths.UserStorage to sqlite to start using the database. The yaml files have not been changed.")
+
+	return true
 }
 
 func doPortSearch(portRangeStr string) {
@@ -25,6 +25,7 @@ type GameDate struct {
 
 	Year        int
 	Month       int
+	Season      string
 	Week        int
 	Day         int
 	Hour        int
@@ -206,6 +207,7 @@ func (g *GameDate) ReCalculate() {
 	g.Day = int(day)
 	g.Year = int(year)
 	g.Month = int(month)
+	g.Season = SeasonName(g.Month)
 	g.Week = int(week)
 	g.Hour = hour
 	g.Hour24 = hour24
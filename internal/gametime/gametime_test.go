@@ -20,3 +20,19 @@ func Benchmark_GetDate_Cached(b *testing.B) {
 		GetDate()
 	}
 }
+
+func TestSeasonName(t *testing.T) {
+	tests := map[int]string{
+		1:  `spring`,
+		3:  `spring`,
+		4:  `summer`,
+		9:  `autumn`,
+		12: `winter`,
+		13: `spring`,
+	}
+	for month, want := range tests {
+		if got := SeasonName(month); got != want {
+			t.Errorf("SeasonName(%d) = %q, want %q", month, got, want)
+		}
+	}
+}
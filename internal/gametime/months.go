@@ -21,3 +21,18 @@ func MonthName(month int) string {
 	month--
 	return monthNames[month%len(monthNames)]
 }
+
+var (
+	// Each season spans three months, starting with the first month of the year
+	seasonNames = []string{
+		`spring`,
+		`summer`,
+		`autumn`,
+		`winter`,
+	}
+)
+
+func SeasonName(month int) string {
+	month--
+	return seasonNames[(month/3)%len(seasonNames)]
+}
@@ -36,6 +36,10 @@ func Broadcast_SendToAll(e events.Event) events.ListenerReturn {
 			}
 		}
 
+		if broadcast.SourceUserId > 0 && u.IsIgnoring(broadcast.SourceUserId) {
+			continue
+		}
+
 		events.AddToQueue(events.RedrawPrompt{UserId: u.UserId}, 100)
 
 		if u.ScreenReader {
@@ -32,6 +32,11 @@ func PlaySound(e events.Event) events.ListenerReturn {
 
 	if user := users.GetByUserId(evt.UserId); user != nil {
 
+		// SSH has no telnet layer to carry MSP commands
+		if connections.IsSSH(user.ConnectionId()) {
+			return events.Continue
+		}
+
 		if evt.SoundType == `MUSIC` {
 
 			if user.LastMusic != evt.SoundFile {
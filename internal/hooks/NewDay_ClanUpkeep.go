@@ -0,0 +1,54 @@
+package hooks
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/clans"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+//
+// Deducts clan upkeep from clan treasuries at the start of each day
+// Clans that cannot pay are disbanded
+//
+
+func ClanUpkeep(e events.Event) events.ListenerReturn {
+	if _, typeOk := e.(events.NewDay); !typeOk {
+		mudlog.Error("Event", "Expected Type", "NewDay", "Actual Type", e.Type())
+		return events.Cancel
+	}
+
+	paid, disbanded := clans.DoUpkeep()
+
+	for _, c := range paid {
+
+		// Warn members if tomorrow's upkeep can't be paid
+		if c.Gold >= c.DailyCost() {
+			continue
+		}
+
+		msg := fmt.Sprintf(`<ansi fg="clantag">[%s]</ansi> <ansi fg="yellow">The clan treasury paid <ansi fg="gold">%d gold</ansi> in upkeep and only <ansi fg="gold">%d gold</ansi> remains. Without a <ansi fg="command">clan donate</ansi> the clan will disband tomorrow!</ansi>`, c.ClanTag, c.DailyCost(), c.Gold)
+		for _, userId := range c.GetMemberUserIds() {
+			if u := users.GetByUserId(userId); u != nil {
+				u.SendText(msg)
+			}
+		}
+	}
+
+	for _, c := range disbanded {
+
+		mudlog.Info("Clan Upkeep", "disbanded", c.ClanTag, "gold", c.Gold, "upkeep", c.DailyCost())
+
+		msg := fmt.Sprintf(`<ansi fg="clantag">[%s]</ansi> <ansi fg="red">The clan treasury could not cover the daily upkeep of <ansi fg="gold">%d gold</ansi>. The clan has been disbanded.</ansi>`, c.ClanTag, c.DailyCost())
+		for _, userId := range c.GetMemberUserIds() {
+			if u := users.GetByUserId(userId); u != nil {
+				u.EventLog.Add(`clan`, fmt.Sprintf(`The clan <ansi fg="clantag">%s</ansi> was disbanded for unpaid upkeep`, c.String()))
+				u.SendText(msg)
+			}
+		}
+	}
+
+	return events.Continue
+}
@@ -7,7 +7,7 @@ import (
 
 //
 // Watches the rounds go by
-// fires event when it's a new day
+// fires events at sunrise/sunset and when it's a new day
 //
 
 func CheckNewDay(e events.Event) events.ListenerReturn {
@@ -30,5 +30,15 @@ func CheckNewDay(e events.Event) events.ListenerReturn {
 
 	}
 
+	if gdBefore.Day != gdNow.Day || gdBefore.Month != gdNow.Month || gdBefore.Year != gdNow.Year {
+
+		events.AddToQueue(events.NewDay{
+			Day:   gdNow.Day,
+			Month: gdNow.Month,
+			Year:  gdNow.Year,
+		})
+
+	}
+
 	return events.Continue
 }
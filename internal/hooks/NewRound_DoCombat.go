@@ -9,6 +9,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/combat"
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/duels"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/items"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
@@ -157,11 +158,12 @@ func handlePlayerCombat(evt events.NewRound) (affectedPlayerIds []int, affectedM
 					}
 				}
 
-				newRoom := rooms.LoadRoom(exitRoomId)
+				// Fleeing into an instanced zone leads to a copy of the room
+				newRoom := rooms.LoadRoom(user.Character.RoomId)
 
 				usercommands.Look(``, user, newRoom, events.CmdSecretly)
 
-				scripting.TryRoomScriptEvent(`onEnter`, user.UserId, exitRoomId)
+				scripting.TryRoomScriptEvent(`onEnter`, user.UserId, newRoom.RoomId)
 
 			}
 
@@ -255,8 +257,17 @@ func handlePlayerCombat(evt events.NewRound) (affectedPlayerIds []int, affectedM
 				}
 			}
 
+			targetUserIds := user.Character.Aggro.SpellInfo.TargetUserIds
+
 			user.Character.Aggro = nil
 
+			// A spell can end a duel just like a blow can, and is just as forgiving
+			for _, targetUserId := range targetUserIds {
+				if targetUser := users.GetByUserId(targetUserId); targetUser != nil {
+					duels.EndIfBeaten(targetUser, user.UserId)
+				}
+			}
+
 			continue
 
 		}
@@ -407,8 +418,8 @@ func handlePlayerCombat(evt events.NewRound) (affectedPlayerIds []int, affectedM
 
 				defUser.Character.TrackPlayerDamage(user.UserId, roundResult.DamageToTarget)
 
-				// For now, only focus on offhand items.
-				if defUser.Character.Equipment.Offhand.ItemId > 0 {
+				// For now, only focus on offhand items. Friendly duels don't break anything.
+				if defUser.Character.Equipment.Offhand.ItemId > 0 && !duels.IsDueling(user.UserId, defUser.UserId) {
 
 					modifier := 0
 					if roundResult.Crit { // Crits double the chance of breakage for offhand items.
@@ -446,6 +457,11 @@ func handlePlayerCombat(evt events.NewRound) (affectedPlayerIds []int, affectedM
 				}
 			}
 
+			// Duels are over before anyone is hurt too badly
+			if duels.EndIfBeaten(defUser, user.UserId) {
+				continue
+			}
+
 			if user.Character.Health <= 0 || defUser.Character.Health <= 0 {
 				defUser.Character.EndAggro()
 				user.Character.EndAggro()
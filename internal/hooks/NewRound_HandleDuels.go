@@ -0,0 +1,141 @@
+package hooks
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/duels"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+//
+// Keeps duels moving along
+// Expires challenges, starts arena fights once betting closes,
+// and ends any duel that has been won or abandoned
+//
+
+func HandleDuels(e events.Event) events.ListenerReturn {
+
+	evt := e.(events.NewRound)
+
+	for _, d := range duels.GetAll() {
+
+		challenger := users.GetByUserId(d.ChallengerId)
+		defender := users.GetByUserId(d.DefenderId)
+
+		// Leaving the game is handled on despawn, so this shouldn't happen
+		if challenger == nil || defender == nil {
+			duels.Cancel(d)
+			continue
+		}
+
+		challengerHere := challenger.Character.RoomId == d.RoomId
+		defenderHere := defender.Character.RoomId == d.RoomId
+
+		if d.State == duels.Challenged {
+
+			if evt.RoundNumber < d.StateRound && challengerHere && defenderHere {
+				continue
+			}
+
+			duels.Cancel(d)
+
+			challenger.SendText(fmt.Sprintf(`Your duel challenge to <ansi fg="username">%s</ansi> has expired.`, defender.Character.Name))
+			defender.SendText(fmt.Sprintf(`The duel challenge from <ansi fg="username">%s</ansi> has expired.`, challenger.Character.Name))
+
+			continue
+		}
+
+		// Walking away from an accepted duel forfeits it
+		if !challengerHere || !defenderHere {
+
+			if !challengerHere && !defenderHere {
+				d.Announce(fmt.Sprintf(`The duel between <ansi fg="username">%s</ansi> and <ansi fg="username">%s</ansi> is called off.`, challenger.Character.Name, defender.Character.Name))
+				challenger.SendText(`The duel is called off.`)
+				defender.SendText(`The duel is called off.`)
+				duels.Cancel(d)
+				continue
+			}
+
+			if !challengerHere {
+				forfeitDuel(d, challenger)
+			} else {
+				forfeitDuel(d, defender)
+			}
+
+			continue
+		}
+
+		if d.State == duels.Starting {
+
+			if evt.RoundNumber < d.StateRound {
+				continue
+			}
+
+			d.Start(evt.RoundNumber)
+
+			challenger.Character.SetAggro(defender.UserId, 0, characters.DefaultAttack)
+			defender.Character.SetAggro(challenger.UserId, 0, characters.DefaultAttack)
+
+			d.Announce(fmt.Sprintf(`<ansi fg="yellow-bold">***</ansi> Betting is closed. The duel between <ansi fg="username">%s</ansi> and <ansi fg="username">%s</ansi> begins! <ansi fg="yellow-bold">***</ansi>`, challenger.Character.Name, defender.Character.Name))
+
+			continue
+		}
+
+		// Fighting. Catches anything that beat a duelist outside of melee with their opponent,
+		// such as spells or mobs. Whoever did it, the duel doesn't save them.
+		if duels.IsBeaten(challenger) {
+			duels.End(d, defender.UserId)
+			continue
+		}
+
+		if duels.IsBeaten(defender) {
+			duels.End(d, challenger.UserId)
+			continue
+		}
+
+		// Duels go on until someone wins or yields
+		if challenger.Character.Aggro == nil {
+			challenger.Character.SetAggro(defender.UserId, 0, characters.DefaultAttack)
+		}
+
+		if defender.Character.Aggro == nil {
+			defender.Character.SetAggro(challenger.UserId, 0, characters.DefaultAttack)
+		}
+
+		// The stands can't see the blow by blow, so give them the standings
+		for _, roomId := range d.SpectatorRoomIds {
+			if standsRoom := rooms.LoadRoom(roomId); standsRoom != nil {
+				standsRoom.SendText(
+					fmt.Sprintf(`<ansi fg="yellow">In the arena below,</ansi> <ansi fg="username">%s</ansi> %s and <ansi fg="username">%s</ansi> %s trade blows.`,
+						challenger.Character.Name, duelHealth(challenger),
+						defender.Character.Name, duelHealth(defender),
+					),
+				)
+			}
+		}
+
+	}
+
+	return events.Continue
+}
+
+// The loser's opponent wins by default
+func forfeitDuel(d *duels.Duel, loser *users.UserRecord) {
+
+	d.Announce(fmt.Sprintf(`<ansi fg="username">%s</ansi> has abandoned the duel!`, loser.Character.Name), loser.UserId)
+	loser.SendText(`You abandoned the duel, and lose by forfeit.`)
+
+	duels.End(d, d.Opponent(loser.UserId))
+}
+
+func duelHealth(user *users.UserRecord) string {
+	pct := 0
+	if user.Character.HealthMax.Value > 0 {
+		pct = user.Character.Health * 100 / user.Character.HealthMax.Value
+	}
+	return fmt.Sprintf(`<ansi fg="%s">(%d%%)</ansi>`, util.HealthClass(user.Character.Health, user.Character.HealthMax.Value), pct)
+}
@@ -91,6 +91,12 @@ func IdleMobs(e events.Event) events.ListenerReturn {
 			continue
 		}
 
+		// If they are on their way somewhere, keep going
+		if mob.PathTargetId > 0 {
+			mob.Command(fmt.Sprintf(`pathto %d`, mob.PathTargetId))
+			continue
+		}
+
 		// If they have idle commands, maybe do one of them?
 		handled, _ := scripting.TryMobScriptEvent("onIdle", mob.InstanceId, 0, ``, nil)
 		if !handled {
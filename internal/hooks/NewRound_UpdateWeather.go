@@ -0,0 +1,37 @@
+package hooks
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/weather"
+)
+
+//
+// Check all zones and roll new weather when it has run its course.
+//
+
+func UpdateWeather(e events.Event) events.ListenerReturn {
+	evt := e.(events.NewRound)
+
+	if !configs.GetGamePlayConfig().Weather.Enabled {
+		weather.ClearZones()
+		return events.Continue
+	}
+
+	for _, zoneName := range rooms.GetAllZoneNames() {
+
+		fromWeatherId, toWeatherId, changed := weather.UpdateZone(zoneName, rooms.GetZoneBiome(zoneName), evt.RoundNumber)
+		if !changed {
+			continue
+		}
+
+		events.AddToQueue(events.WeatherChange{
+			Zone:          zoneName,
+			FromWeatherId: fromWeatherId,
+			ToWeatherId:   toWeatherId,
+		})
+	}
+
+	return events.Continue
+}
@@ -96,7 +96,9 @@ func UserRoundTick(e events.Event) events.ListenerReturn {
 				}
 
 				// Roundtick any cooldowns
-				user.Character.Cooldowns.RoundTick()
+				if user.Character.Cooldowns.RoundTick() {
+					events.AddToQueue(events.CharacterCooldownsChanged{UserId: uId})
+				}
 
 				if user.Character.Charmed != nil && user.Character.Charmed.RoundsRemaining > 0 {
 					user.Character.Charmed.RoundsRemaining--
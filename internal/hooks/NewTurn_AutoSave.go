@@ -4,13 +4,15 @@ package hooks
 import (
 	"time"
 
+	"github.com/GoMudEngine/GoMud/internal/channels"
+	"github.com/GoMudEngine/GoMud/internal/clans"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/plugins"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
 	"github.com/GoMudEngine/GoMud/internal/term"
-	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
@@ -38,13 +40,35 @@ func AutoSave(e events.Event) events.ListenerReturn {
 		//////////////////////////////////////////
 		events.AddToQueue(events.Broadcast{Text: `Saving users...`})
 
-		users.SaveAllUsers(true)
+		// Instances don't survive a restart, so nobody is saved inside of one
+		rooms.SaveAllUsersOutsideInstances(true)
 
 		events.AddToQueue(events.Broadcast{
 			Text:            `Done.` + term.CRLFStr,
 			SkipLineRefresh: true,
 		})
 
+		//////////////////////////////////////////
+		// SAVE ALL CLANS
+		//////////////////////////////////////////
+		if err := clans.SaveAllClans(); err != nil {
+			mudlog.Error("clans.SaveAllClans()", "error", err.Error())
+		}
+
+		//////////////////////////////////////////
+		// SAVE ALL CHANNELS
+		//////////////////////////////////////////
+		if err := channels.SaveAllChannels(); err != nil {
+			mudlog.Error("channels.SaveAllChannels()", "error", err.Error())
+		}
+
+		//////////////////////////////////////////
+		// SAVE SCRIPT STORE
+		//////////////////////////////////////////
+		if err := scripting.SaveScriptStore(); err != nil {
+			mudlog.Error("scripting.SaveScriptStore()", "error", err.Error())
+		}
+
 		//////////////////////////////////////////
 		// SAVE ALL ROOMS
 		//////////////////////////////////////////
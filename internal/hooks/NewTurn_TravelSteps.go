@@ -0,0 +1,48 @@
+package hooks
+
+import (
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/usercommands"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+//
+// Moves speedwalking or traveling players along one step per turn
+//
+
+func TravelSteps(e events.Event) events.ListenerReturn {
+
+	for _, user := range users.GetAllActiveUsers() {
+
+		steps := user.GetTravelSteps()
+		if len(steps) == 0 {
+			continue
+		}
+
+		if user.Character.Aggro != nil {
+			user.SetTravelSteps(nil)
+			user.SendText(`You stop in your tracks to fight!`)
+			continue
+		}
+
+		// Wait for them to catch their breath, same as if they were walking it themselves
+		if user.InputBlocked() || user.Character.ActionPoints < user.Character.MoveActionCost() {
+			continue
+		}
+
+		roomId := user.Character.RoomId
+
+		usercommands.TryCommand(steps[0], ``, user.UserId, events.CmdNone)
+
+		// If they couldn't go that way, don't keep blundering on
+		if user.Character.RoomId == roomId {
+			user.SetTravelSteps(nil)
+			user.SendText(`You can't go any further that way.`)
+			continue
+		}
+
+		user.SetTravelSteps(steps[1:])
+	}
+
+	return events.Continue
+}
@@ -4,11 +4,13 @@ import (
 	"fmt"
 
 	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/duels"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/parties"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
 	"github.com/GoMudEngine/GoMud/internal/templates"
 	"github.com/GoMudEngine/GoMud/internal/users"
 )
@@ -25,6 +27,9 @@ func HandleLeave(e events.Event) events.ListenerReturn {
 		return events.Cancel
 	}
 
+	// Stop sending script console output to anyone who has left
+	scripting.SetConsoleListener(evt.UserId, false)
+
 	user := users.GetByUserId(evt.UserId)
 	if user == nil {
 		mudlog.Error("HandleLeave", "error", fmt.Sprintf(`user %d not found`, evt.UserId))
@@ -44,6 +49,15 @@ func HandleLeave(e events.Event) events.ListenerReturn {
 		currentParty.Leave(evt.UserId)
 	}
 
+	// Leaving in the middle of a duel loses it
+	if d := duels.Get(evt.UserId); d != nil {
+		if d.State == duels.Challenged {
+			duels.Cancel(d)
+		} else {
+			forfeitDuel(d, user)
+		}
+	}
+
 	for _, mobInstId := range room.GetMobs(rooms.FindCharmed) {
 		if mob := mobs.GetInstance(mobInstId); mob != nil {
 			if mob.Character.IsCharmed(evt.UserId) {
@@ -57,6 +71,11 @@ func HandleLeave(e events.Event) events.ListenerReturn {
 		room.SendText(tplTxt)
 	}
 
+	// Instances don't outlast the players in them, so nobody should be saved inside of one
+	if returnRoomId, ok := rooms.GetInstanceReturnRoomId(room.RoomId); ok {
+		user.Character.RoomId = returnRoomId
+	}
+
 	tplTxt, _ := templates.Process("goodbye", nil, evt.UserId)
 	connections.SendTo([]byte(templates.AnsiParse(tplTxt)), connId)
 
@@ -32,6 +32,20 @@ func HandleJoin(e events.Event) events.ListenerReturn {
 
 	users.RemoveZombieUser(evt.UserId)
 
+	// A saved instance room can't be trusted after a restart
+	if rooms.IsForeignInstanceRoom(user.UserId, user.Character.RoomId) {
+
+		mudlog.Warn("EnterWorld", "userId", user.UserId, "error", fmt.Sprintf(`instance room %d refused`, user.Character.RoomId))
+
+		if rooms.LoadRoom(user.Character.RoomId) == nil {
+			user.Character.RoomId = 1
+		}
+
+		if err := rooms.MoveToRoom(user.UserId, rooms.StartRoomIdAlias); err != nil {
+			mudlog.Error("EnterWorld", "error", err)
+		}
+	}
+
 	room := rooms.LoadRoom(user.Character.RoomId)
 	if room == nil {
 
@@ -0,0 +1,48 @@
+package hooks
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/weather"
+)
+
+//
+// Lets players outdoors know the weather changed
+//
+
+func NotifyWeatherChange(e events.Event) events.ListenerReturn {
+	evt, typeOk := e.(events.WeatherChange)
+	if !typeOk {
+		return events.Cancel
+	}
+
+	msg := ``
+	if spec := weather.GetSpec(evt.FromWeatherId); spec != nil && spec.StopMessage != `` {
+		msg = fmt.Sprintf(`<ansi fg="weather">%s</ansi>`, spec.StopMessage)
+	}
+
+	if spec := weather.GetSpec(evt.ToWeatherId); spec != nil && spec.StartMessage != `` {
+		if msg != `` {
+			msg += "\n"
+		}
+		msg += fmt.Sprintf(`<ansi fg="weather">%s</ansi>`, spec.StartMessage)
+	}
+
+	if msg == `` {
+		return events.Continue
+	}
+
+	for _, roomId := range rooms.GetRoomsWithPlayers() {
+
+		room := rooms.LoadRoom(roomId)
+		if room == nil || room.Zone != evt.Zone || !room.IsOutdoors() {
+			continue
+		}
+
+		room.SendText(msg)
+	}
+
+	return events.Continue
+}
@@ -17,11 +17,13 @@ func RegisterListeners() {
 	events.RegisterListener(events.NewRound{}, PruneVMs)
 	events.RegisterListener(events.NewRound{}, InactivePlayers)
 	events.RegisterListener(events.NewRound{}, UpdateZoneMutators)
+	events.RegisterListener(events.NewRound{}, UpdateWeather)
 	events.RegisterListener(events.NewRound{}, CheckNewDay)
 	events.RegisterListener(events.NewRound{}, SpawnLootGoblin)
 	events.RegisterListener(events.NewRound{}, UserRoundTick)
 	events.RegisterListener(events.NewRound{}, MobRoundTick)
 	events.RegisterListener(events.NewRound{}, HandleRespawns)
+	events.RegisterListener(events.NewRound{}, HandleDuels)
 	//
 	// Combat goes here
 	//
@@ -37,6 +39,7 @@ func RegisterListeners() {
 	events.RegisterListener(events.NewTurn{}, AutoSave)
 	events.RegisterListener(events.NewTurn{}, PruneBuffs)
 	events.RegisterListener(events.NewTurn{}, ActionPoints)
+	events.RegisterListener(events.NewTurn{}, TravelSteps)
 
 	// ItemOwnership
 	events.RegisterListener(events.ItemOwnership{}, CheckItemQuests)
@@ -56,6 +59,12 @@ func RegisterListeners() {
 	// Day/Night cycle
 	events.RegisterListener(events.DayNightCycle{}, NotifySunriseSunset)
 
+	// New day
+	events.RegisterListener(events.NewDay{}, ClanUpkeep)
+
+	// Weather
+	events.RegisterListener(events.WeatherChange{}, NotifyWeatherChange)
+
 	// Looking
 	events.RegisterListener(events.Looking{}, HandleLookHints)
 
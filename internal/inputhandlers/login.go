@@ -64,6 +64,15 @@ func FinalizeLoginOrCreate(results map[string]string, sharedState map[string]any
 				return false // Indicate failure, connection removed
 			}
 
+			// Upgrade plaintext/unsalted/outdated password hashes now that we know the password
+			if loggedInUser.PasswordNeedsRehash() {
+				if err := loggedInUser.RehashPassword(password); err != nil {
+					mudlog.Error("Password rehash failed", "username", username, "error", err)
+				} else {
+					users.SaveUser(*loggedInUser)
+				}
+			}
+
 			sharedState["UserObject"] = loggedInUser // For main loop
 
 			if len(msg) > 0 {
@@ -109,7 +118,8 @@ func FinalizeLoginOrCreate(results map[string]string, sharedState map[string]any
 
 		newUser := users.NewUserRecord(0, clientInput.ConnectionId)
 		newUser.EmailAddress = results["email-new"]
-		newUser.ScreenReader = results["screen-reader-new"] == `y`
+		// Also honor a screen reader reported by the client (MTTS)
+		newUser.ScreenReader = results["screen-reader-new"] == `y` || connections.GetClientSettings(clientInput.ConnectionId).IsScreenReader()
 
 		// Error handling for SetUsername/SetPassword might be redundant if validation passed, but good practice
 		if err := newUser.SetUsername(username); err != nil {
@@ -144,6 +154,38 @@ func FinalizeLoginOrCreate(results map[string]string, sharedState map[string]any
 	}
 }
 
+// LoginAuthenticatedUser logs in a user whose identity was already verified by the connection itself,
+// such as an SSH public key. The login prompts are skipped entirely.
+func LoginAuthenticatedUser(username string, sharedState map[string]any, connectionId connections.ConnectionId) bool {
+
+	tmpUser, err := users.LoadUser(username)
+	if err != nil {
+		mudlog.Error("Failed to load authenticated user during login", "username", username, "error", err)
+		connections.SendTo([]byte(language.T("Error.LoginFailedGeneric")), connectionId)
+		connections.SendTo(term.CRLF, connectionId)
+		connections.Remove(connectionId)
+		return false
+	}
+
+	loggedInUser, msg, err := users.LoginUser(tmpUser, connectionId)
+	if err != nil {
+		connections.SendTo([]byte(msg), connectionId)
+		connections.SendTo(term.CRLF, connectionId)
+		connections.Remove(connectionId)
+		return false
+	}
+
+	sharedState["UserObject"] = loggedInUser // For main loop
+
+	if len(msg) > 0 {
+		connections.SendTo([]byte(msg), connectionId)
+		connections.SendTo(term.CRLF, connectionId)
+	}
+	mudlog.Info("User logged in", "username", username, "connectionId", connectionId, "method", "pre-authenticated")
+
+	return true
+}
+
 func GetLoginPromptHandler() connections.InputHandler {
 
 	// Define the steps for the login process
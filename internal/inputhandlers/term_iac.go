@@ -7,6 +7,10 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/term"
 )
 
+const (
+	maxTerminalTypeRequests = 3 // Client name, terminal type, MTTS
+)
+
 var (
 	iacHandlers = []IACHandler{}
 )
@@ -95,6 +99,102 @@ func TelnetIACHandler(clientInput *connections.ClientInput, sharedState map[stri
 			continue
 		}
 
+		if term.IsMSSPCommand(iacCmd) {
+
+			if ok, _ := term.Matches(iacCmd, term.MsspAccept); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MSSP Accept)")
+
+				connections.SendTo(
+					getMsspResponse(),
+					clientInput.ConnectionId,
+				)
+
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.MsspRefuse); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MSSP Refuse)")
+				continue
+			}
+
+			continue
+		}
+
+		if term.IsMCCPCommand(iacCmd) {
+
+			cd := connections.Get(clientInput.ConnectionId)
+			if cd == nil {
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.Mccp2Accept); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP2 Accept)")
+
+				if err := cd.StartCompression(); err != nil {
+					mudlog.Error("MCCP2", "connectionId", clientInput.ConnectionId, "error", err)
+				}
+
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.Mccp2Refuse); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP2 Refuse)")
+
+				if err := cd.StopCompression(); err != nil {
+					mudlog.Error("MCCP2", "connectionId", clientInput.ConnectionId, "error", err)
+				}
+
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.Mccp3Accept); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP3 Accept)")
+				cd.AllowInputCompression(true)
+				continue
+			}
+
+			if ok, _ := term.Matches(iacCmd, term.Mccp3Refuse); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP3 Refuse)")
+				cd.AllowInputCompression(false)
+				continue
+			}
+
+			// IAC SB MCCP3 IAC SE is handled when reading from the connection,
+			// since everything after it is compressed.
+			if ok, _ := term.Matches(iacCmd, term.Mccp3Start); ok {
+				mudlog.Debug("Received", "type", "IAC (Client-MCCP3 Start)")
+				continue
+			}
+
+			continue
+		}
+
+		if ok, _ := term.Matches(iacCmd, term.TelnetTermTypeWill); ok {
+			mudlog.Debug("Received", "type", "IAC (Client-TTYPE Will)")
+			connections.SendTo(
+				term.TelnetTermTypeSend.BytesWithPayload(nil),
+				clientInput.ConnectionId,
+			)
+			continue
+		}
+
+		if ok, _ := term.Matches(iacCmd, term.TelnetTermTypeWont); ok {
+			mudlog.Debug("Received", "type", "IAC (Client-TTYPE Wont)")
+			continue
+		}
+
+		if ok, payload := term.Matches(iacCmd, term.TelnetTermTypeResponse); ok {
+			mudlog.Debug("Received", "type", "IAC (Client-TTYPE Is)", "value", string(payload))
+
+			if handleTerminalType(clientInput.ConnectionId, string(payload)) {
+				connections.SendTo(
+					term.TelnetTermTypeSend.BytesWithPayload(nil),
+					clientInput.ConnectionId,
+				)
+			}
+			continue
+		}
+
 		if ok, payload := term.Matches(iacCmd, term.TelnetAcceptedChangeCharset); ok {
 			mudlog.Debug("Received", "type", "IAC (TelnetAcceptedChangeCharset)", "data", term.BytesString(payload))
 			continue
@@ -155,3 +255,40 @@ func TelnetIACHandler(clientInput *connections.ClientInput, sharedState map[stri
 	// We handled it, so don't pass it on
 	return false
 }
+
+// Records a TTYPE response on the client settings.
+// Returns true if the next terminal type should be requested.
+func handleTerminalType(connectionId uint64, ttype string) (requestNext bool) {
+
+	cs := connections.GetClientSettings(connectionId)
+	defer func() {
+		connections.OverwriteClientSettings(connectionId, cs)
+	}()
+
+	if mtts, ok := term.ParseMTTS(ttype); ok {
+		cs.MTTS = mtts
+		cs.MTTSReported = true
+		cs.TerminalTypes = append(cs.TerminalTypes, ttype)
+
+		mudlog.Debug("MTTS", "connectionId", connectionId, "client", cs.ClientName, "terminal", cs.TerminalType, "colors", cs.ColorMode().String(), "utf8", cs.SupportsUTF8(), "screenreader", cs.IsScreenReader())
+		return false
+	}
+
+	// A repeated value means the client has nothing more to report
+	if len(cs.TerminalTypes) > 0 && cs.TerminalTypes[len(cs.TerminalTypes)-1] == ttype {
+		return false
+	}
+
+	cs.TerminalTypes = append(cs.TerminalTypes, ttype)
+
+	switch len(cs.TerminalTypes) {
+	case 1:
+		cs.ClientName = ttype
+		// Some clients only ever report a terminal type
+		cs.TerminalType = ttype
+	case 2:
+		cs.TerminalType = ttype
+	}
+
+	return len(cs.TerminalTypes) < maxTerminalTypeRequests
+}
@@ -0,0 +1,98 @@
+package inputhandlers
+
+import (
+	"strconv"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/races"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/skills"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+// Builds the full MSSP subnegotiation describing the server
+func getMsspResponse() []byte {
+	return term.MsspCommand.BytesWithPayload(term.MsspPayload(getMsspVariables()))
+}
+
+func getMsspVariables() map[string]string {
+
+	// World data is owned by the world loop, so read it under the mud lock
+	util.RLockMud()
+	playerCt := len(users.GetOnlineUserIds())
+	roomCt := len(rooms.GetAllRoomIds())
+	zoneCt := len(rooms.GetAllZoneNames())
+	mobCt := len(mobs.GetAllMobInfo())
+	itemCt := len(items.GetAllItemSpecs())
+	raceCt := 0
+	for _, r := range races.GetRaces() {
+		if r.Selectable {
+			raceCt++
+		}
+	}
+	util.RUnlockMud()
+
+	c := configs.GetConfig()
+	m := c.Mssp
+
+	port := ``
+	if len(c.Network.TelnetPort) > 0 {
+		port = c.Network.TelnetPort[0]
+	}
+
+	tlsPort := ``
+	if len(c.Network.TelnetTLSPort) > 0 {
+		tlsPort = c.Network.TelnetTLSPort[0]
+	}
+
+	return map[string]string{
+		// Required
+		`NAME`:    c.Server.MudName.String(),
+		`PLAYERS`: strconv.Itoa(playerCt),
+		`UPTIME`:  strconv.FormatInt(util.GetServerStartTime().Unix(), 10),
+
+		// Generic
+		`HOSTNAME`:    m.Hostname.String(),
+		`PORT`:        port,
+		`SSL`:         tlsPort,
+		`CODEBASE`:    m.Codebase.String(),
+		`CONTACT`:     m.Contact.String(),
+		`WEBSITE`:     m.Website.String(),
+		`DISCORD`:     m.Discord.String(),
+		`GENRE`:       m.Genre.String(),
+		`SUBGENRE`:    m.Subgenre.String(),
+		`GAMEPLAY`:    m.Gameplay.String(),
+		`STATUS`:      m.Status.String(),
+		`LANGUAGE`:    m.Language.String(),
+		`LOCATION`:    m.Location.String(),
+		`CREATED`:     m.Created.String(),
+		`MINIMUM AGE`: strconv.Itoa(int(m.MinimumAge)),
+
+		// World
+		`AREAS`:   strconv.Itoa(zoneCt),
+		`ROOMS`:   strconv.Itoa(roomCt),
+		`MOBILES`: strconv.Itoa(mobCt),
+		`OBJECTS`: strconv.Itoa(itemCt),
+		`RACES`:   strconv.Itoa(raceCt),
+		`SKILLS`:  strconv.Itoa(len(skills.GetAllSkillNames())),
+
+		// Protocols
+		`ANSI`:             `1`,
+		`UTF-8`:            `1`,
+		`MSP`:              `1`,
+		`MCCP`:             msspBool(bool(c.Network.Compression)),
+		`XTERM 256 COLORS`: `1`,
+	}
+}
+
+// MSSP reports booleans as "1" or "0"
+func msspBool(b bool) string {
+	if b {
+		return `1`
+	}
+	return `0`
+}
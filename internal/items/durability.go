@@ -0,0 +1,148 @@
+package items
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// Max durability for weapons and wearables that don't specify one
+	DefaultDurability = 100
+	// The least it will cost to repair a completely broken item
+	minFullRepairCost = 20
+)
+
+// Whether this item wears down with use.
+// Weapons and wearables do, unless their spec sets a negative durability.
+func (i *Item) HasDurability() bool {
+	if i.ItemId < 1 {
+		return false
+	}
+	iSpec := i.GetSpec()
+	if iSpec.Durability < 0 {
+		return false
+	}
+	return iSpec.Type == Weapon || iSpec.Subtype == Wearable
+}
+
+// Returns the durability of the item when fully repaired
+func (i *Item) GetMaxDurability() int {
+	if !i.HasDurability() {
+		return 0
+	}
+	if d := i.GetSpec().Durability; d > 0 {
+		return d
+	}
+	return DefaultDurability
+}
+
+// Returns how much durability is left on the item
+func (i *Item) GetDurability() int {
+	maxDurability := i.GetMaxDurability()
+	if i.Wear >= maxDurability {
+		return 0
+	}
+	return maxDurability - i.Wear
+}
+
+// Returns 0-100 representing how much durability is left
+func (i *Item) GetDurabilityPct() int {
+	maxDurability := i.GetMaxDurability()
+	if maxDurability < 1 {
+		return 100
+	}
+	return int(math.Ceil(float64(i.GetDurability()) / float64(maxDurability) * 100))
+}
+
+// Returns a word describing the condition of the item
+func (i *Item) GetCondition() string {
+	pct := i.GetDurabilityPct()
+	switch {
+	case pct >= 100:
+		return `pristine`
+	case pct >= 75:
+		return `good`
+	case pct >= 50:
+		return `worn`
+	case pct >= 25:
+		return `damaged`
+	case pct > 0:
+		return `badly damaged`
+	}
+	return `broken`
+}
+
+// Returns the % effectiveness (damage or defense) the item has in its current condition
+func (i *Item) GetEffectiveness() int {
+	pct := i.GetDurabilityPct()
+	switch {
+	case pct >= 50:
+		return 100
+	case pct >= 25:
+		return 75
+	case pct > 0:
+		return 50
+	}
+	return 25
+}
+
+// Whether the item has taken any wear at all
+func (i *Item) IsDamaged() bool {
+	return i.HasDurability() && i.Wear > 0
+}
+
+// Wears down the item.
+// Returns true if the condition of the item changed as a result.
+func (i *Item) AddWear(amt int) bool {
+	if amt < 1 || !i.HasDurability() {
+		return false
+	}
+
+	maxDurability := i.GetMaxDurability()
+	if i.Wear >= maxDurability {
+		return false
+	}
+
+	conditionBefore := i.GetCondition()
+
+	i.Wear += amt
+	if i.Wear > maxDurability {
+		i.Wear = maxDurability
+	}
+
+	return conditionBefore != i.GetCondition()
+}
+
+// Restores the item to full durability
+func (i *Item) Repair() {
+	i.Wear = 0
+}
+
+// Returns a short colorized tag such as "(45%)" for damaged items, or an empty string otherwise.
+func (i *Item) ConditionTag() string {
+	if !i.IsDamaged() {
+		return ``
+	}
+	if i.GetDurability() == 0 {
+		return `<ansi fg="item-broken">(broken)</ansi>`
+	}
+	return fmt.Sprintf(`<ansi fg="item-worn">(%d%%)</ansi>`, i.GetDurabilityPct())
+}
+
+// Returns the base gold cost to fully repair the item in its current condition
+func (i *Item) GetRepairCost() int {
+	if !i.IsDamaged() {
+		return 0
+	}
+
+	fullCost := i.GetSpec().Value / 2
+	if fullCost < minFullRepairCost {
+		fullCost = minFullRepairCost
+	}
+
+	cost := int(math.Ceil(float64(fullCost) * float64(i.Wear) / float64(i.GetMaxDurability())))
+	if cost < 1 {
+		cost = 1
+	}
+	return cost
+}
@@ -0,0 +1,90 @@
+package items
+
+import "testing"
+
+func TestItem_Durability(t *testing.T) {
+
+	tests := []struct {
+		name              string
+		spec              ItemSpec
+		wear              int
+		wantHas           bool
+		wantDurability    int
+		wantCondition     string
+		wantEffectiveness int
+	}{
+		{"Pristine weapon", ItemSpec{Type: Weapon}, 0, true, 100, `pristine`, 100},
+		{"Worn weapon", ItemSpec{Type: Weapon}, 40, true, 60, `worn`, 100},
+		{"Damaged armor", ItemSpec{Type: Body, Subtype: Wearable}, 60, true, 40, `damaged`, 75},
+		{"Badly damaged armor", ItemSpec{Type: Body, Subtype: Wearable}, 90, true, 10, `badly damaged`, 50},
+		{"Broken weapon", ItemSpec{Type: Weapon}, 150, true, 0, `broken`, 25},
+		{"Custom durability", ItemSpec{Type: Weapon, Durability: 20}, 10, true, 10, `worn`, 100},
+		{"Never wears out", ItemSpec{Type: Weapon, Durability: -1}, 0, false, 0, `pristine`, 100},
+		{"Not equipment", ItemSpec{Type: Potion, Subtype: Drinkable}, 0, false, 0, `pristine`, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := tt.spec
+			itm := Item{ItemId: 1, Spec: &spec, Wear: tt.wear}
+
+			if got := itm.HasDurability(); got != tt.wantHas {
+				t.Errorf("HasDurability() = %v, want %v", got, tt.wantHas)
+			}
+			if got := itm.GetDurability(); got != tt.wantDurability {
+				t.Errorf("GetDurability() = %d, want %d", got, tt.wantDurability)
+			}
+			if got := itm.GetCondition(); got != tt.wantCondition {
+				t.Errorf("GetCondition() = %q, want %q", got, tt.wantCondition)
+			}
+			if got := itm.GetEffectiveness(); got != tt.wantEffectiveness {
+				t.Errorf("GetEffectiveness() = %d, want %d", got, tt.wantEffectiveness)
+			}
+		})
+	}
+}
+
+func TestItem_AddWearAndRepair(t *testing.T) {
+
+	itm := Item{ItemId: 1, Spec: &ItemSpec{Type: Body, Subtype: Wearable, DamageReduction: 20, Value: 100}}
+
+	if itm.AddWear(0) {
+		t.Errorf("AddWear(0) should not change condition")
+	}
+
+	// pristine -> good
+	if !itm.AddWear(1) {
+		t.Errorf("AddWear(1) should change condition from pristine")
+	}
+
+	// good -> good
+	if itm.AddWear(1) {
+		t.Errorf("AddWear(1) should not change condition")
+	}
+
+	itm.AddWear(500)
+	if itm.Wear != 100 {
+		t.Errorf("Wear = %d, want it capped at 100", itm.Wear)
+	}
+	if itm.AddWear(1) {
+		t.Errorf("AddWear() on a broken item should not change condition")
+	}
+
+	if got := itm.GetDefense(); got != 5 {
+		t.Errorf("GetDefense() = %d, want 5 for a broken item", got)
+	}
+	if got := itm.GetRepairCost(); got != 50 {
+		t.Errorf("GetRepairCost() = %d, want 50", got)
+	}
+
+	itm.Repair()
+	if itm.IsDamaged() {
+		t.Errorf("IsDamaged() should be false after Repair()")
+	}
+	if got := itm.GetDefense(); got != 20 {
+		t.Errorf("GetDefense() = %d, want 20 after Repair()", got)
+	}
+	if got := itm.GetRepairCost(); got != 0 {
+		t.Errorf("GetRepairCost() = %d, want 0 after Repair()", got)
+	}
+}
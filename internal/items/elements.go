@@ -0,0 +1,51 @@
+package items
+
+import (
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/statmods"
+)
+
+var (
+	elementFlavors = map[Element]string{
+		Fire:        `searing`,
+		Water:       `drenching`,
+		Ice:         `freezing`,
+		Electricity: `shocking`,
+		Acid:        `corrosive`,
+		Life:        `radiant`,
+		Death:       `withering`,
+	}
+)
+
+// Returns all elements that damage can be dealt as
+func Elements() []Element {
+	return []Element{Fire, Water, Ice, Electricity, Acid, Life, Death}
+}
+
+// Finds an element by name, returning an empty element if there is no match
+func FindElement(name string) Element {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for _, e := range Elements() {
+		if string(e) == name {
+			return e
+		}
+	}
+	return ``
+}
+
+func (e Element) IsValid() bool {
+	_, ok := elementFlavors[e]
+	return ok
+}
+
+// A word to describe damage of this element ("searing")
+func (e Element) Flavor() string {
+	return elementFlavors[e]
+}
+
+// The statmod that resists this element, such as "resist-fire"
+// Positive values are a percent of damage resisted, negative values are a vulnerability.
+func (e Element) ResistStatName() string {
+	return string(statmods.ResistPrefix) + string(e)
+}
@@ -47,6 +47,7 @@ type Item struct {
 	Enchantments  uint8          `yaml:"enchantments,omitempty"` // Is this item enchanted?
 	Adjectives    []string       `yaml:"adjectives,omitempty"`   // Decorative text for the name of the item (e.g. "exploding")
 	StashedBy     int            `yaml:"stashedby,omitempty"`    // userid of whoever stashed this item
+	Wear          int            `yaml:"wear,omitempty"`         // How much durability has been lost through use
 	tempDataStore map[string]any // Temporary data store for this item. Not saved to disk.
 }
 
@@ -382,9 +383,13 @@ func (i *Item) GetDamage() Damage {
 }
 
 // Returns a random number up to the total possible reduction for this item.
+// Worn down items provide less protection.
 func (i *Item) GetDefense() int {
 	itemInfo := i.GetSpec()
-	return itemInfo.DamageReduction
+	if itemInfo.DamageReduction < 1 {
+		return itemInfo.DamageReduction
+	}
+	return itemInfo.DamageReduction * i.GetEffectiveness() / 100
 }
 
 func (i *Item) Equals(b Item) bool {
@@ -405,6 +410,10 @@ func (i *Item) Equals(b Item) bool {
 		return false
 	}
 
+	if i.Wear != b.Wear {
+		return false
+	}
+
 	if i.Spec != b.Spec {
 		return false
 	}
@@ -551,6 +560,9 @@ func (i *Item) NameComplex() string {
 	if flagsStr != `` {
 		nm = fmt.Sprintf(`%s %s`, flagsStr, nm)
 	}
+	if conditionStr := i.ConditionTag(); conditionStr != `` {
+		nm = fmt.Sprintf(`%s %s`, nm, conditionStr)
+	}
 	return nm
 }
 
@@ -215,6 +215,7 @@ type ItemSpec struct {
 	Element         Element           `yaml:"element,omitempty"`
 	StatMods        statmods.StatMods `yaml:"statmods,omitempty"`    // What stats it modifies when equipped
 	BreakChance     uint8             `yaml:"breakchance,omitempty"` // Chance in 100 that the item will break when used, or when the character is hit with it equipped, or if it is in the characters inventory during an explosion, etc.
+	Durability      int               `yaml:"durability,omitempty"`  // How much wear a weapon or wearable can take before it is broken. 0 = default, -1 = never wears out
 	Cursed          bool              `yaml:"cursed,omitempty"`      // Can't be removed once equipped
 	KeyLockId       string            `yaml:"keylockid,omitempty"`   // Example: `778-north` - If it's a key, what lock does it open? roomid-exitname etc.
 }
@@ -411,6 +412,10 @@ func (i *ItemSpec) Validate() error {
 		i.NameSimple = i.Name
 	}
 
+	if i.Element != `` {
+		i.Element = FindElement(string(i.Element))
+	}
+
 	if i.DisplayName != `` {
 		i.DisplayName = util.ConvertColorShortTags(i.DisplayName)
 	}
@@ -2,6 +2,8 @@ package items
 
 import (
 	"errors"
+	"fmt"
+	"os"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/fileloader"
@@ -44,6 +46,75 @@ func CreateNewItemFile(newItemInfo ItemSpec) (int, error) {
 	return newItemInfo.Id(), nil
 }
 
+// Replaces an existing item spec, saving it to its file and updating the in-memory cache.
+// If a new name changes the filename, the old file is removed and any script follows it.
+func SaveItemSpec(itemInfo ItemSpec) error {
+
+	oldItemInfo, ok := items[itemInfo.ItemId]
+	if !ok {
+		return fmt.Errorf(`item %d does not exist`, itemInfo.ItemId)
+	}
+
+	if err := itemInfo.Validate(); err != nil {
+		return err
+	}
+
+	itemInfo.Damage.Attacks = 0
+	itemInfo.Damage.DiceCount = 0
+	itemInfo.Damage.SideCount = 0
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	basePath := configs.GetFilePathsConfig().DataFiles.String() + `/items`
+
+	if err := fileloader.SaveFlatFile[*ItemSpec](basePath, &itemInfo, saveModes...); err != nil {
+		return err
+	}
+
+	if oldItemInfo.Filepath() != itemInfo.Filepath() {
+
+		if err := fileloader.DeleteFlatFile[*ItemSpec](basePath, oldItemInfo); err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(oldItemInfo.GetScriptPath()); err == nil {
+			os.Rename(oldItemInfo.GetScriptPath(), itemInfo.GetScriptPath())
+		}
+	}
+
+	// Validate() again to restore the damage info zeroed out for saving
+	itemInfo.Validate()
+
+	items[itemInfo.Id()] = &itemInfo
+
+	return nil
+}
+
+// Removes an item spec (and any script) from disk and memory.
+// Existing instances of the item will no longer have a spec to refer to.
+func DeleteItemSpec(itemId int) error {
+
+	itemInfo, ok := items[itemId]
+	if !ok {
+		return fmt.Errorf(`item %d does not exist`, itemId)
+	}
+
+	if err := fileloader.DeleteFlatFile[*ItemSpec](configs.GetFilePathsConfig().DataFiles.String()+`/items`, itemInfo); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(itemInfo.GetScriptPath()); err == nil {
+		os.Remove(itemInfo.GetScriptPath())
+	}
+
+	delete(items, itemId)
+
+	return nil
+}
+
 func getNextItemId(t ItemType) int {
 
 	rangeMin := 0
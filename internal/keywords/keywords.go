@@ -211,6 +211,17 @@ func TryDirectionAlias(input string) string {
 	return input
 }
 
+// Whether the input is a direction or one of its aliases, such as "north" or "n"
+func IsDirection(input string) bool {
+	input = strings.ToLower(input)
+	for alias, direction := range loadedKeywords.DirectionAliases {
+		if input == alias || input == direction {
+			return true
+		}
+	}
+	return false
+}
+
 func TryCommandAlias(input string) string {
 	if alias, ok := loadedKeywords.commandAliases[strings.ToLower(input)]; ok {
 		return alias
@@ -0,0 +1,123 @@
+package mapper
+
+import (
+	"sort"
+
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+)
+
+var (
+	// The short direction names used by the IRE map format (and Mudlet's mapper)
+	exportDirections = map[string]string{
+		"north":     "n",
+		"south":     "s",
+		"west":      "w",
+		"east":      "e",
+		"northwest": "nw",
+		"northeast": "ne",
+		"southwest": "sw",
+		"southeast": "se",
+		"down":      "down",
+		"up":        "up",
+	}
+)
+
+// A zone laid out the same way as the IRE map format (areas, then rooms with coords and exits),
+// which is what Mudlet's mapper scripts know how to import.
+type ZoneExport struct {
+	Areas []ExportArea `json:"areas"`
+	Rooms []ExportRoom `json:"rooms"`
+}
+
+type ExportArea struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type ExportRoom struct {
+	Id          int          `json:"id"`
+	Area        int          `json:"area"`
+	Title       string       `json:"title"`
+	Environment string       `json:"environment"`
+	Coord       ExportCoord  `json:"coord"`
+	Exits       []ExportExit `json:"exits"`
+}
+
+// North is -y and up is +z, the same as the in-game map
+type ExportCoord struct {
+	X        int `json:"x"`
+	Y        int `json:"y"`
+	Z        int `json:"z"`
+	Building int `json:"building"`
+}
+
+type ExportExit struct {
+	Direction string `json:"direction"` // Short compass direction, or the exit name for special exits
+	Target    int    `json:"target"`
+	Door      bool   `json:"door,omitempty"`
+	Hidden    bool   `json:"hidden,omitempty"`
+}
+
+// The area id a zone uses in map exports, which is its root room id
+func GetAreaId(zoneName string) int {
+	rootRoomId, _ := rooms.GetZoneRoot(zoneName)
+	return rootRoomId
+}
+
+// Exports every mapped room of a zone from the room grid, with coordinates matching GetCoordinates()
+func (r *mapper) ExportZone(zoneName string) ZoneExport {
+
+	areaId := GetAreaId(zoneName)
+
+	export := ZoneExport{
+		Areas: []ExportArea{{Id: areaId, Name: zoneName}},
+		Rooms: []ExportRoom{},
+	}
+
+	for _, plane := range r.roomGrid.rooms {
+		for _, row := range plane {
+			for _, node := range row {
+
+				if node == nil || node.Zone != zoneName {
+					continue
+				}
+
+				room := ExportRoom{
+					Id:          node.RoomId,
+					Area:        areaId,
+					Title:       node.Title,
+					Environment: node.Environment,
+					Coord:       ExportCoord{X: node.Pos.x, Y: node.Pos.y, Z: node.Pos.z},
+					Exits:       []ExportExit{},
+				}
+
+				for exitName, exitInfo := range node.Exits {
+
+					direction, ok := exportDirections[exitName]
+					if !ok {
+						direction = exitName
+					}
+
+					room.Exits = append(room.Exits, ExportExit{
+						Direction: direction,
+						Target:    exitInfo.RoomId,
+						Door:      exitInfo.LockDifficulty > 0,
+						Hidden:    exitInfo.Secret,
+					})
+				}
+
+				sort.Slice(room.Exits, func(i, j int) bool {
+					return room.Exits[i].Direction < room.Exits[j].Direction
+				})
+
+				export.Rooms = append(export.Rooms, room)
+			}
+		}
+	}
+
+	sort.Slice(export.Rooms, func(i, j int) bool {
+		return export.Rooms[i].Id < export.Rooms[j].Id
+	})
+
+	return export
+}
@@ -800,6 +800,9 @@ func (r *mapper) getMapNode(roomId int) *mapNode {
 
 	mNode := &mapNode{
 		RoomId:      room.RoomId,
+		Zone:        room.Zone,
+		Title:       room.Title,
+		Environment: room.GetBiome().Name(),
 		Exits:       make(map[string]nodeExit, 2), // assume there will be on average 2 exits per room
 		SecretExits: make(map[string]struct{}),
 	}
@@ -3,6 +3,9 @@ package mapper
 // represents a single room
 type mapNode struct {
 	RoomId      int
+	Zone        string
+	Title       string
+	Environment string // Biome name
 	Symbol      rune
 	Legend      string // The same that shows in the legend for this symbol
 	Exits       map[string]nodeExit
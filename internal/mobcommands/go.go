@@ -37,7 +37,11 @@ func Go(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
 
 			if len(mob.RoomStack) == 0 {
 
-				if util.Rand(50) == 0 {
+				// Lost track of the way back, so try to find it
+				if path, err := rooms.FindPath(room.RoomId, mob.HomeRoomId, rooms.PathAllowSecret); err == nil {
+					exitName = path[0].ExitName
+					goRoomId = path[0].RoomId
+				} else if util.Rand(50) == 0 {
 					goRoomId = mob.HomeRoomId
 					exitName = `mysterious`
 				} else {
@@ -0,0 +1,45 @@
+package mobcommands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+)
+
+// Takes one step along the shortest path to a room.
+// The target is remembered, so each time the mob is idle it takes another step until it arrives.
+// Usage: pathto <roomId>
+//
+//	pathto stop
+func PathTo(rest string, mob *mobs.Mob, room *rooms.Room) (bool, error) {
+
+	if rest == `` || rest == `stop` {
+		mob.PathTargetId = 0
+		return true, nil
+	}
+
+	targetRoomId, err := strconv.Atoi(rest)
+	if err != nil || targetRoomId < 1 {
+		return true, fmt.Errorf(`invalid roomId: %s`, rest)
+	}
+
+	if room.RoomId == targetRoomId {
+		mob.PathTargetId = 0
+		return true, nil
+	}
+
+	path, err := rooms.FindPath(room.RoomId, targetRoomId, rooms.PathAllowSecret)
+	if err != nil {
+		mob.PathTargetId = 0
+		return true, fmt.Errorf(`room %d: %w`, targetRoomId, err)
+	}
+
+	mob.PathTargetId = targetRoomId
+	mob.GoingHome = false
+
+	mob.Command(fmt.Sprintf(`go %s`, path[0].ExitName))
+
+	return true, nil
+}
@@ -71,10 +71,12 @@ type Mob struct {
 	MaxWander       int      `yaml:"maxwander,omitempty"`       // Max rooms to wander from home
 	GoingHome       bool     `yaml:"-"`                         // WHether they are trying to get home
 	RoomStack       []int    `yaml:"-"`                         // Stack of rooms to get back home
+	PathTargetId    int      `yaml:"-"`                         // Room they are currently finding their way to, if any
 	PreventIdle     bool     `yaml:"-"`                         // Whether they can't possibly be idle
 	ScriptTag       string   `yaml:"scripttag"`                 // Script for this mob: mobs/frostfang/scripts/{mobId}-{mobname}-{ScriptTag}.js
 	QuestFlags      []string `yaml:"questflags,omitempty,flow"` // What quest flags are set on this mob?
 	BuffIds         []int    `yaml:"buffids,omitempty"`         // Buff Id's this mob always has upon spawn
+	Blacksmith      bool     `yaml:"blacksmith,omitempty"`      // Whether this mob repairs weapons and armor for gold
 	tempDataStore   map[string]any
 	conversationId  int  // Identifier of conversation currently involved in.
 	hasConverseFile bool // whether they have a converse file to look for conversations in
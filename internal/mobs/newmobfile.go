@@ -2,6 +2,7 @@ package mobs
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -69,6 +70,91 @@ func CreateNewMobFile(newMobInfo Mob, copyScript string) (MobId, error) {
 	return newMobInfo.MobId, nil
 }
 
+// Replaces an existing mob spec, saving it to its file and updating the in-memory cache.
+// Mobs already spawned keep the details they spawned with.
+func SaveMobSpec(mobInfo Mob) error {
+
+	oldMobInfo, ok := mobs[int(mobInfo.MobId)]
+	if !ok {
+		return fmt.Errorf(`mob %d does not exist`, mobInfo.MobId)
+	}
+
+	if err := mobInfo.Validate(); err != nil {
+		return err
+	}
+
+	// Descriptions are hashed in memory, but the file needs the real thing
+	mobInfo.Character.Description = mobInfo.Character.GetDescription()
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	basePath := configs.GetFilePathsConfig().DataFiles.String() + `/mobs`
+
+	if err := fileloader.SaveFlatFile[*Mob](basePath, &mobInfo, saveModes...); err != nil {
+		return err
+	}
+
+	// The filename comes from the original name, so only a zone change moves the file
+	if oldMobInfo.Filepath() != mobInfo.Filepath() {
+
+		if err := fileloader.DeleteFlatFile[*Mob](basePath, oldMobInfo); err != nil {
+			return err
+		}
+
+		if _, err := os.Stat(oldMobInfo.GetScriptPath()); err == nil {
+			os.MkdirAll(filepath.Dir(mobInfo.GetScriptPath()), os.ModePerm)
+			os.Rename(oldMobInfo.GetScriptPath(), mobInfo.GetScriptPath())
+		}
+	}
+
+	if oldMobInfo.Character.Name != mobInfo.Character.Name {
+		removeMobName(oldMobInfo.Character.Name)
+		allMobNames = append(allMobNames, mobInfo.Character.Name)
+	}
+
+	mobInfo.Character.CacheDescription()
+	mobs[mobInfo.Id()] = &mobInfo
+
+	return nil
+}
+
+// Removes a mob spec (and any script) from disk and memory.
+// Mobs already spawned are left alone.
+func DeleteMobSpec(mobId MobId) error {
+
+	mobInfo, ok := mobs[int(mobId)]
+	if !ok {
+		return fmt.Errorf(`mob %d does not exist`, mobId)
+	}
+
+	if err := fileloader.DeleteFlatFile[*Mob](configs.GetFilePathsConfig().DataFiles.String()+`/mobs`, mobInfo); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(mobInfo.GetScriptPath()); err == nil {
+		os.Remove(mobInfo.GetScriptPath())
+	}
+
+	removeMobName(mobInfo.Character.Name)
+	delete(mobNameCache, mobId)
+	delete(mobs, int(mobId))
+
+	return nil
+}
+
+func removeMobName(name string) {
+	for i, mobName := range allMobNames {
+		if mobName == name {
+			allMobNames = append(allMobNames[:i], allMobNames[i+1:]...)
+			return
+		}
+	}
+}
+
 func getNextMobId() MobId {
 
 	lowestFreeId := MobId(0)
@@ -198,8 +198,12 @@ func (m *Mutator) Removable() bool {
 	return true
 }
 
+// Mutators whose spec has been removed get an empty spec with no effects
 func (m *Mutator) GetSpec() *MutatorSpec {
-	return allMutators[m.MutatorId]
+	if spec, ok := allMutators[m.MutatorId]; ok {
+		return spec
+	}
+	return &MutatorSpec{MutatorId: m.MutatorId}
 }
 
 // Checks whether it decays or respawns
@@ -0,0 +1,84 @@
+package mutators
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+)
+
+func CreateNewMutatorFile(newMutatorInfo MutatorSpec) (string, error) {
+
+	if newMutatorInfo.MutatorId == `` {
+		return ``, errors.New(`mutatorid is required`)
+	}
+
+	if _, ok := allMutators[newMutatorInfo.MutatorId]; ok {
+		return ``, fmt.Errorf(`mutator %s already exists`, newMutatorInfo.MutatorId)
+	}
+
+	if err := newMutatorInfo.Validate(); err != nil {
+		return ``, err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*MutatorSpec](configs.GetFilePathsConfig().DataFiles.String()+`/mutators`, &newMutatorInfo, saveModes...); err != nil {
+		return ``, err
+	}
+
+	// Save to in-memory cache
+	allMutators[newMutatorInfo.Id()] = &newMutatorInfo
+
+	return newMutatorInfo.MutatorId, nil
+}
+
+// Replaces an existing mutator spec, saving it to its file and updating the in-memory cache.
+// Live mutators look up their spec by id, so they pick up the changes right away.
+func SaveMutatorSpec(mutatorInfo MutatorSpec) error {
+
+	if _, ok := allMutators[mutatorInfo.MutatorId]; !ok {
+		return fmt.Errorf(`mutator %s does not exist`, mutatorInfo.MutatorId)
+	}
+
+	if err := mutatorInfo.Validate(); err != nil {
+		return err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*MutatorSpec](configs.GetFilePathsConfig().DataFiles.String()+`/mutators`, &mutatorInfo, saveModes...); err != nil {
+		return err
+	}
+
+	allMutators[mutatorInfo.Id()] = &mutatorInfo
+
+	return nil
+}
+
+// Removes a mutator spec from disk and memory.
+// Rooms and zones that still list the mutator will treat it as having no effect.
+func DeleteMutatorSpec(mutatorId string) error {
+
+	mutatorInfo, ok := allMutators[mutatorId]
+	if !ok {
+		return fmt.Errorf(`mutator %s does not exist`, mutatorId)
+	}
+
+	if err := fileloader.DeleteFlatFile[*MutatorSpec](configs.GetFilePathsConfig().DataFiles.String()+`/mutators`, mutatorInfo); err != nil {
+		return err
+	}
+
+	delete(allMutators, mutatorId)
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package mutators
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Points the data files at an empty directory and starts with no mutators loaded
+func setupMutatorFiles(t *testing.T) string {
+
+	dataDir := t.TempDir()
+	require.NoError(t, configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: dataDir}))
+
+	savedMutators := allMutators
+	allMutators = map[string]*MutatorSpec{}
+
+	t.Cleanup(func() {
+		allMutators = savedMutators
+		configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: `_datafiles/world/default`})
+	})
+
+	return dataDir
+}
+
+func TestCreateNewMutatorFile(t *testing.T) {
+
+	dataDir := setupMutatorFiles(t)
+
+	tests := []struct {
+		name     string
+		spec     MutatorSpec
+		wantFile string
+		wantErr  bool
+	}{
+		{"Simple id", MutatorSpec{MutatorId: `dusty`}, `dusty.yaml`, false},
+		{"Id becomes a safe file name", MutatorSpec{MutatorId: `../../Muddy Floor`}, `______muddy_floor.yaml`, false},
+		{"Missing id", MutatorSpec{}, ``, true},
+		{"Already exists", MutatorSpec{MutatorId: `dusty`}, ``, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			mutatorId, err := CreateNewMutatorFile(tt.spec)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.spec.MutatorId, mutatorId)
+			assert.FileExists(t, filepath.Join(dataDir, `mutators`, tt.wantFile))
+			assert.Contains(t, allMutators, mutatorId)
+		})
+	}
+
+	// Bad text behaviors fall back to the default
+	_, err := CreateNewMutatorFile(MutatorSpec{MutatorId: `shiny`, NameModifier: &TextModifier{Text: `shiny`, Behavior: `sideways`}})
+	require.NoError(t, err)
+	assert.Equal(t, TextDefault, allMutators[`shiny`].NameModifier.Behavior)
+}
+
+func TestSaveMutatorSpec(t *testing.T) {
+
+	setupMutatorFiles(t)
+
+	assert.Error(t, SaveMutatorSpec(MutatorSpec{MutatorId: `dusty`}), "Expected only existing mutators to be saved")
+
+	_, err := CreateNewMutatorFile(MutatorSpec{MutatorId: `dusty`, LightMod: -1})
+	require.NoError(t, err)
+
+	require.NoError(t, SaveMutatorSpec(MutatorSpec{MutatorId: `dusty`, LightMod: 1}))
+	assert.Equal(t, 1, allMutators[`dusty`].LightMod)
+}
+
+func TestDeleteMutatorSpec(t *testing.T) {
+
+	dataDir := setupMutatorFiles(t)
+
+	assert.Error(t, DeleteMutatorSpec(`dusty`))
+
+	_, err := CreateNewMutatorFile(MutatorSpec{MutatorId: `dusty`})
+	require.NoError(t, err)
+
+	require.NoError(t, DeleteMutatorSpec(`dusty`))
+	assert.NoFileExists(t, filepath.Join(dataDir, `mutators`, `dusty.yaml`))
+	assert.NotContains(t, allMutators, `dusty`)
+}
@@ -26,6 +26,7 @@ func newPluginCallbacks() PluginCallbacks {
 
 type NetConnection interface {
 	IsWebSocket() bool
+	IsSSH() bool
 	Read(p []byte) (n int, err error)
 	Write(p []byte) (n int, err error)
 	Close()
@@ -0,0 +1,88 @@
+package quests
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+)
+
+func CreateNewQuestFile(newQuestInfo Quest) (int, error) {
+
+	if _, ok := quests[newQuestInfo.QuestId]; ok {
+		return 0, fmt.Errorf(`quest %d already exists`, newQuestInfo.QuestId)
+	}
+
+	if err := newQuestInfo.Validate(); err != nil {
+		return 0, err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*Quest](configs.GetFilePathsConfig().DataFiles.String()+`/quests`, &newQuestInfo, saveModes...); err != nil {
+		return 0, err
+	}
+
+	// Save to in-memory cache
+	quests[newQuestInfo.Id()] = &newQuestInfo
+
+	return newQuestInfo.QuestId, nil
+}
+
+// Replaces an existing quest, saving it to its file and updating the in-memory cache.
+// If a new name changes the filename, the old file is removed.
+func SaveQuest(questInfo Quest) error {
+
+	oldQuestInfo, ok := quests[questInfo.QuestId]
+	if !ok {
+		return fmt.Errorf(`quest %d does not exist`, questInfo.QuestId)
+	}
+
+	if err := questInfo.Validate(); err != nil {
+		return err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	basePath := configs.GetFilePathsConfig().DataFiles.String() + `/quests`
+
+	if err := fileloader.SaveFlatFile[*Quest](basePath, &questInfo, saveModes...); err != nil {
+		return err
+	}
+
+	if oldQuestInfo.Filepath() != questInfo.Filepath() {
+		if err := fileloader.DeleteFlatFile[*Quest](basePath, oldQuestInfo); err != nil {
+			return err
+		}
+	}
+
+	quests[questInfo.Id()] = &questInfo
+
+	return nil
+}
+
+// Removes a quest from disk and memory.
+// Players already on the quest will keep their progress tokens, but they will lead nowhere.
+func DeleteQuest(questId int) error {
+
+	questInfo, ok := quests[questId]
+	if !ok {
+		return fmt.Errorf(`quest %d does not exist`, questId)
+	}
+
+	if err := fileloader.DeleteFlatFile[*Quest](configs.GetFilePathsConfig().DataFiles.String()+`/quests`, questInfo); err != nil {
+		return err
+	}
+
+	delete(quests, questId)
+
+	return nil
+}
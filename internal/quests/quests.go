@@ -171,6 +171,10 @@ func GetQuest(questToken string) *Quest {
 	return nil
 }
 
+func GetQuestById(questId int) *Quest {
+	return quests[questId]
+}
+
 func GetAllQuests() []Quest {
 	ret := []Quest{}
 	for _, q := range quests {
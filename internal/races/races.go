@@ -11,6 +11,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/fileloader"
 	"github.com/GoMudEngine/GoMud/internal/items"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/statmods"
 	"github.com/GoMudEngine/GoMud/internal/stats"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"gopkg.in/yaml.v2"
@@ -40,10 +41,11 @@ type Race struct {
 	Tameable         bool
 	Damage           items.Damage
 	Selectable       bool
-	AngryCommands    []string         // randomly chosen to queue when they are angry/entering combat.
-	KnowsFirstAid    bool             // Whether they can apply aid to other players.
-	Stats            stats.Statistics // Base stats for this race.
-	DisabledSlots    []string         `yaml:"disabledslots,omitempty"`
+	AngryCommands    []string          // randomly chosen to queue when they are angry/entering combat.
+	KnowsFirstAid    bool              // Whether they can apply aid to other players.
+	Stats            stats.Statistics  // Base stats for this race.
+	DisabledSlots    []string          `yaml:"disabledslots,omitempty"`
+	StatMods         statmods.StatMods `yaml:"statmods,omitempty"` // Innate statmods, such as elemental resistances
 }
 
 func GetRaces() []Race {
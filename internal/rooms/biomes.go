@@ -11,6 +11,7 @@ type BiomeInfo struct {
 	requiredItemId int  // item id required to move into any room with this biome
 	usesItem       bool // Whether it "uses" the item (i.e. consumes it or decreases its uses left) when moving into a room with this biome
 	burns          bool // Does this area catch fire? (brush etc.)
+	sheltered      bool // Whether it is indoors/underground, out of the weather
 }
 
 func (bi BiomeInfo) Name() string {
@@ -49,6 +50,10 @@ func (bi BiomeInfo) Burns() bool {
 	return bi.burns
 }
 
+func (bi BiomeInfo) IsSheltered() bool {
+	return bi.sheltered
+}
+
 var (
 	AllBiomes = map[string]BiomeInfo{
 		`city`: {
@@ -74,6 +79,7 @@ var (
 			litArea:     true,
 			description: `A standard dwelling, houses can appear almost anywhere. They are usually safe, but may be abandoned or occupied by hostile creatures.`,
 			burns:       true,
+			sheltered:   true,
 		},
 		`shore`: {
 			name:        `Shore`,
@@ -124,6 +130,7 @@ var (
 			symbol:      '⌬',
 			darkArea:    true,
 			description: `The land is covered in caves of all sorts. You never know what you'll find in them.`,
+			sheltered:   true,
 		},
 		`desert`: {
 			name:        `Desert`,
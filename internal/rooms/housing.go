@@ -0,0 +1,270 @@
+package rooms
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/exit"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+)
+
+const (
+	// Name of the exit that leads from the entrance of a home back to where it was bought
+	HomeExitName = `out`
+	// Difficulty of locks installed in homes
+	HomeLockDifficulty = 10
+
+	homeBiome       = `house`
+	homeTitle       = `An Empty Home`
+	homeDescription = `Bare walls and a dusty floor await a personal touch. The owner of this home can change its title and description at any time.`
+)
+
+type HomeGuest struct {
+	UserId        int    `yaml:"userid"`
+	CharacterName string `yaml:"charactername"`
+}
+
+var (
+	// userId => roomIds of their home. The lowest roomId is the entrance.
+	homes = map[int][]int{}
+
+	ErrHomeExists    = errors.New(`a home already exists for that user`)
+	ErrNoHome        = errors.New(`no home found for that user`)
+	ErrHomeMaxRooms  = errors.New(`the home cannot have any more rooms`)
+	ErrHomeExitInUse = errors.New(`an exit by that name already exists`)
+	ErrHomeDirection = errors.New(`homes can only be expanded in a compass direction, up or down`)
+
+	// direction => exit leading back
+	homeReturnExits = map[string]string{
+		`north`:     `south`,
+		`south`:     `north`,
+		`east`:      `west`,
+		`west`:      `east`,
+		`northeast`: `southwest`,
+		`southwest`: `northeast`,
+		`northwest`: `southeast`,
+		`southeast`: `northwest`,
+		`up`:        `down`,
+		`down`:      `up`,
+	}
+)
+
+func trackHomeRoom(userId int, roomId int) {
+	for _, rId := range homes[userId] {
+		if rId == roomId {
+			return
+		}
+	}
+	homes[userId] = append(homes[userId], roomId)
+	sort.Ints(homes[userId])
+}
+
+// Returns all roomIds that make up the home of a user
+func GetHomeRoomIds(userId int) []int {
+	return append([]int{}, homes[userId]...)
+}
+
+// Returns the roomId of the entrance to a users home, or 0 if they have none
+func GetHomeEntranceId(userId int) int {
+	if roomIds := homes[userId]; len(roomIds) > 0 {
+		return roomIds[0]
+	}
+	return 0
+}
+
+// Returns the entrance room of a users home, or nil if they have none
+func GetHomeEntrance(userId int) *Room {
+	if roomId := GetHomeEntranceId(userId); roomId > 0 {
+		return LoadRoom(roomId)
+	}
+	return nil
+}
+
+// Whether this room is part of a players home
+func (r *Room) IsHome() bool {
+	return r.HomeOwner > 0
+}
+
+// Whether this room is the entrance of a players home
+func (r *Room) IsHomeEntrance() bool {
+	return r.HomeOwner > 0 && GetHomeEntranceId(r.HomeOwner) == r.RoomId
+}
+
+// Whether the user is on the guest list of the home this room is part of
+func (r *Room) IsHomeGuest(userId int) bool {
+	if !r.IsHome() {
+		return false
+	}
+
+	entrance := r
+	if !r.IsHomeEntrance() {
+		if entrance = GetHomeEntrance(r.HomeOwner); entrance == nil {
+			return false
+		}
+	}
+
+	for _, guest := range entrance.HomeGuests {
+		if guest.UserId == userId {
+			return true
+		}
+	}
+	return false
+}
+
+// Adds a guest to the home this room is the entrance of.
+// Returns false if they were already a guest.
+func (r *Room) AddHomeGuest(userId int, characterName string) bool {
+	for _, guest := range r.HomeGuests {
+		if guest.UserId == userId {
+			return false
+		}
+	}
+	r.HomeGuests = append(r.HomeGuests, HomeGuest{UserId: userId, CharacterName: characterName})
+	return true
+}
+
+// Removes a guest (by character name) from the home this room is the entrance of.
+// Returns the removed guest, and whether one was found.
+func (r *Room) RemoveHomeGuest(characterName string) (HomeGuest, bool) {
+	for idx, guest := range r.HomeGuests {
+		if strings.EqualFold(guest.CharacterName, characterName) {
+			r.HomeGuests = append(r.HomeGuests[:idx], r.HomeGuests[idx+1:]...)
+			return guest, true
+		}
+	}
+	return HomeGuest{}, false
+}
+
+// Whether the user is allowed to be in the home this room is part of
+func (r *Room) CanVisitHome(userId int) bool {
+	return r.HomeOwner == userId || r.IsHomeGuest(userId)
+}
+
+// Returns the lock id of every lock in a users home
+func GetHomeLockIds(userId int) []string {
+	lockIds := []string{}
+	for _, roomId := range homes[userId] {
+		room := LoadRoom(roomId)
+		if room == nil {
+			continue
+		}
+		for exitName, exitInfo := range room.Exits {
+			if exitInfo.HasLock() {
+				lockIds = append(lockIds, fmt.Sprintf(`%d-%s`, room.RoomId, exitName))
+			}
+		}
+		for containerName, container := range room.Containers {
+			if container.HasLock() {
+				lockIds = append(lockIds, fmt.Sprintf(`%d-%s`, room.RoomId, containerName))
+			}
+		}
+	}
+	sort.Strings(lockIds)
+	return lockIds
+}
+
+// Creates a new home for a user, with an exit leading back to the room it was bought from.
+func CreateHome(userId int, fromRoomId int) (*Room, error) {
+
+	if GetHomeEntranceId(userId) > 0 {
+		return nil, ErrHomeExists
+	}
+
+	fromRoom := LoadRoom(fromRoomId)
+	if fromRoom == nil {
+		return nil, fmt.Errorf(`room %d not found`, fromRoomId)
+	}
+
+	zoneName := string(configs.GetGamePlayConfig().Housing.Zone)
+
+	var home *Room
+
+	if _, ok := roomManager.zones[zoneName]; !ok {
+
+		// The first home becomes the root of the zone
+		roomId, err := CreateZone(zoneName)
+		if err != nil {
+			return nil, err
+		}
+
+		if home = LoadRoom(roomId); home == nil {
+			return nil, fmt.Errorf(`room %d not found`, roomId)
+		}
+
+	} else {
+
+		roomId, err := CreateNewRoomFile(Room{
+			Zone:        zoneName,
+			Title:       homeTitle,
+			Description: homeDescription,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if home = LoadRoom(roomId); home == nil {
+			return nil, fmt.Errorf(`room %d not found`, roomId)
+		}
+	}
+
+	home.Title = homeTitle
+	home.SetDescription(homeDescription)
+	home.Biome = homeBiome
+	home.HomeOwner = userId
+	home.Exits[HomeExitName] = exit.RoomExit{RoomId: fromRoom.RoomId}
+
+	SaveRoom(*home)
+
+	trackHomeRoom(userId, home.RoomId)
+
+	mudlog.Info("Home created", "userId", userId, "roomId", home.RoomId, "fromRoomId", fromRoom.RoomId)
+
+	return home, nil
+}
+
+// Adds a room to a home in the given direction, connected both ways.
+func ExpandHome(fromRoomId int, direction string) (*Room, error) {
+
+	returnDirection, ok := homeReturnExits[direction]
+	if !ok {
+		return nil, ErrHomeDirection
+	}
+
+	fromRoom := LoadRoom(fromRoomId)
+	if fromRoom == nil {
+		return nil, fmt.Errorf(`room %d not found`, fromRoomId)
+	}
+
+	if !fromRoom.IsHome() {
+		return nil, ErrNoHome
+	}
+
+	if len(homes[fromRoom.HomeOwner]) >= int(configs.GetGamePlayConfig().Housing.MaxRooms) {
+		return nil, ErrHomeMaxRooms
+	}
+
+	if _, ok := fromRoom.Exits[direction]; ok {
+		return nil, ErrHomeExitInUse
+	}
+
+	newRoom, err := BuildRoom(fromRoom.RoomId, direction)
+	if err != nil {
+		return nil, err
+	}
+
+	newRoom.HomeOwner = fromRoom.HomeOwner
+	SaveRoom(*newRoom)
+
+	if err := ConnectRoom(newRoom.RoomId, fromRoom.RoomId, returnDirection); err != nil {
+		return nil, err
+	}
+
+	trackHomeRoom(newRoom.HomeOwner, newRoom.RoomId)
+
+	mudlog.Info("Home expanded", "userId", newRoom.HomeOwner, "roomId", newRoom.RoomId, "fromRoomId", fromRoom.RoomId)
+
+	return newRoom, nil
+}
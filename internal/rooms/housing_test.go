@@ -0,0 +1,43 @@
+package rooms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrackHomeRoom(t *testing.T) {
+	defer delete(homes, 77)
+
+	assert.Equal(t, 0, GetHomeEntranceId(77), "Expected no home initially")
+
+	trackHomeRoom(77, 2005)
+	trackHomeRoom(77, 2001)
+	trackHomeRoom(77, 2005)
+
+	assert.Equal(t, []int{2001, 2005}, GetHomeRoomIds(77), "Expected sorted roomIds without duplicates")
+	assert.Equal(t, 2001, GetHomeEntranceId(77), "Expected the lowest roomId to be the entrance")
+}
+
+func TestRoom_HomeGuests(t *testing.T) {
+	defer delete(homes, 77)
+
+	r := &Room{RoomId: 2001, HomeOwner: 77}
+	trackHomeRoom(77, r.RoomId)
+
+	assert.True(t, r.IsHomeEntrance())
+	assert.True(t, r.CanVisitHome(77), "Expected the owner to be able to visit")
+	assert.False(t, r.CanVisitHome(5), "Expected strangers to be kept out")
+
+	assert.True(t, r.AddHomeGuest(5, "Bob"))
+	assert.False(t, r.AddHomeGuest(5, "Bob"), "Expected a duplicate guest to be rejected")
+	assert.True(t, r.CanVisitHome(5), "Expected guests to be able to visit")
+
+	guest, found := r.RemoveHomeGuest("bob")
+	assert.True(t, found)
+	assert.Equal(t, 5, guest.UserId)
+	assert.False(t, r.CanVisitHome(5), "Expected removed guests to be kept out")
+
+	_, found = r.RemoveHomeGuest("bob")
+	assert.False(t, found)
+}
@@ -0,0 +1,328 @@
+package rooms
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/parties"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+const (
+	// Instance rooms are numbered from here up, well clear of any room saved to disk
+	instanceRoomIdStart = 1000000
+)
+
+// A private copy of a zone, shared by a party (or a single player)
+type ZoneInstance struct {
+	InstanceId      int
+	Zone            string
+	UserIds         []int       // Everyone the instance belongs to
+	RoomIds         map[int]int // source roomId => instance roomId
+	ReturnRoomId    int         // Where players who log out inside the instance are returned to
+	CreatedRound    uint64
+	emptySinceRound uint64
+}
+
+var (
+	zoneInstances      = map[int]*ZoneInstance{}
+	nextInstanceId     = 1
+	nextInstanceRoomId = instanceRoomIdStart
+)
+
+// Whether the zone gives each party their own copy of it
+func IsZoneInstanced(zone string) bool {
+	if zConfig := GetZoneConfig(zone); zConfig != nil {
+		return zConfig.Instanced
+	}
+	return false
+}
+
+// Returns the roomId an instance room was copied from, or the roomId unchanged if it isn't an instance room
+func GetSourceRoomId(roomId int) int {
+	if r, ok := roomManager.rooms[roomId]; ok && r.InstanceOf > 0 {
+		return r.InstanceOf
+	}
+	return roomId
+}
+
+// Returns where a player in the given instance room should be returned to if they leave the world
+func GetInstanceReturnRoomId(roomId int) (int, bool) {
+	r, ok := roomManager.rooms[roomId]
+	if !ok || r.InstanceId == 0 {
+		return 0, false
+	}
+	if inst, ok := zoneInstances[r.InstanceId]; ok {
+		return inst.ReturnRoomId, true
+	}
+	return 0, false
+}
+
+// Whether the roomId is an instance room the user doesn't belong in.
+// Instances don't survive a restart, so an instance room saved before one may be gone,
+// or may now be part of someone else's instance.
+func IsForeignInstanceRoom(userId int, roomId int) bool {
+	if roomId < instanceRoomIdStart {
+		return false
+	}
+	r, ok := roomManager.rooms[roomId]
+	if !ok || r.InstanceId == 0 {
+		return true
+	}
+	inst, ok := zoneInstances[r.InstanceId]
+	return !ok || !inst.HasUser(userId)
+}
+
+// Saves all online users. Anyone inside an instance is saved at the room
+// they'd be returned to, without actually being moved.
+func SaveAllUsersOutsideInstances(isAutoSave ...bool) {
+
+	for _, u := range users.GetAllActiveUsers() {
+
+		roomId := u.Character.RoomId
+
+		returnRoomId, inInstance := GetInstanceReturnRoomId(roomId)
+		if inInstance {
+			u.Character.RoomId = returnRoomId
+		}
+
+		if err := users.SaveUser(*u, isAutoSave...); err != nil {
+			mudlog.Error("SaveAllUsersOutsideInstances()", "error", err.Error())
+		}
+
+		if inInstance {
+			u.Character.RoomId = roomId
+		}
+	}
+}
+
+// Returns all active zone instances
+func GetZoneInstances() []*ZoneInstance {
+	ret := make([]*ZoneInstance, 0, len(zoneInstances))
+	for _, inst := range zoneInstances {
+		ret = append(ret, inst)
+	}
+	return ret
+}
+
+func (z *ZoneInstance) HasUser(userId int) bool {
+	for _, id := range z.UserIds {
+		if id == userId {
+			return true
+		}
+	}
+	return false
+}
+
+func (z *ZoneInstance) addUser(userId int) {
+	if !z.HasUser(userId) {
+		z.UserIds = append(z.UserIds, userId)
+	}
+}
+
+// Returns how many players are currently inside the instance
+func (z *ZoneInstance) GetPlayerCount() int {
+	ct := 0
+	for _, roomId := range z.RoomIds {
+		if r, ok := roomManager.rooms[roomId]; ok {
+			ct += len(r.players)
+		}
+	}
+	return ct
+}
+
+// Finds the instance of a zone that belongs to the user, or anyone in their party
+func findZoneInstance(zone string, userId int) *ZoneInstance {
+
+	memberIds := []int{userId}
+	if party := parties.Get(userId); party != nil {
+		memberIds = party.GetMembers()
+	}
+
+	for _, inst := range zoneInstances {
+		if inst.Zone != zone {
+			continue
+		}
+		for _, memberId := range memberIds {
+			if inst.HasUser(memberId) {
+				return inst
+			}
+		}
+	}
+
+	return nil
+}
+
+// Works out which room a user moving into toRoom should actually end up in.
+// Moving into an instanced zone routes to the copy of the room belonging to the user or their party,
+// creating a new instance of the zone if they don't have one yet.
+func resolveInstanceRoom(userId int, fromRoom *Room, toRoom *Room) (*Room, error) {
+
+	if toRoom.InstanceId > 0 || !IsZoneInstanced(toRoom.Zone) {
+		return toRoom, nil
+	}
+
+	inst := findZoneInstance(toRoom.Zone, userId)
+	if inst == nil {
+
+		// Players who log out inside return to wherever the instance was entered from
+		returnRoomId := StartRoomIdAlias
+		if fromRoom.Zone != toRoom.Zone {
+			returnRoomId = GetSourceRoomId(fromRoom.RoomId)
+		}
+
+		var err error
+		if inst, err = createZoneInstance(toRoom.Zone, returnRoomId); err != nil {
+			return nil, err
+		}
+	}
+
+	inst.addUser(userId)
+
+	instanceRoom, ok := roomManager.rooms[inst.RoomIds[toRoom.RoomId]]
+	if !ok {
+		return nil, fmt.Errorf(`room %d not found in instance %d`, toRoom.RoomId, inst.InstanceId)
+	}
+
+	return instanceRoom, nil
+}
+
+// Copies every room of a zone from disk into memory under new temporary roomIds.
+// Exits between rooms of the zone are pointed at the copies, while exits leading out of the zone are left alone.
+func createZoneInstance(zone string, returnRoomId int) (*ZoneInstance, error) {
+
+	zoneInfo, ok := roomManager.zones[zone]
+	if !ok {
+		return nil, fmt.Errorf(`zone %s does not exist`, zone)
+	}
+
+	inst := &ZoneInstance{
+		InstanceId:   nextInstanceId,
+		Zone:         zone,
+		UserIds:      []int{},
+		RoomIds:      map[int]int{},
+		ReturnRoomId: returnRoomId,
+		CreatedRound: util.GetRoundCount(),
+	}
+
+	// Loading from disk rather than memory means the instance starts out fresh,
+	// and its mobs and items are spawned as players reach each room.
+	instanceRooms := make([]*Room, 0, len(zoneInfo.RoomIds))
+
+	for sourceRoomId := range zoneInfo.RoomIds {
+
+		filePath, ok := roomManager.roomIdToFileCache[sourceRoomId]
+		if !ok {
+			continue
+		}
+
+		r, err := loadRoomFromFile(util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/`, `rooms`, `/`, filePath))
+		if err != nil {
+			return nil, err
+		}
+
+		r.InstanceId = inst.InstanceId
+		r.InstanceOf = sourceRoomId
+		r.RoomId = nextInstanceRoomId
+		r.ZoneConfig = ZoneConfig{}
+		r.Signs = []Sign{}
+
+		nextInstanceRoomId++
+
+		inst.RoomIds[sourceRoomId] = r.RoomId
+		instanceRooms = append(instanceRooms, r)
+	}
+
+	for _, r := range instanceRooms {
+
+		for exitName, exitInfo := range r.Exits {
+			if instanceRoomId, ok := inst.RoomIds[exitInfo.RoomId]; ok {
+				exitInfo.RoomId = instanceRoomId
+				r.Exits[exitName] = exitInfo
+			}
+		}
+
+		cacheRoomDescription(r)
+
+		roomManager.rooms[r.RoomId] = r
+	}
+
+	zoneInstances[inst.InstanceId] = inst
+	nextInstanceId++
+
+	mudlog.Info("Zone instance created", "instanceId", inst.InstanceId, "zone", zone, "roomCount", len(instanceRooms))
+
+	return inst, nil
+}
+
+// Removes an instance and everything in it from memory
+func destroyZoneInstance(inst *ZoneInstance) {
+
+	for _, roomId := range inst.RoomIds {
+
+		r, ok := roomManager.rooms[roomId]
+		if !ok {
+			continue
+		}
+
+		for _, mobInstanceId := range r.mobs {
+			mobs.DestroyInstance(mobInstanceId)
+		}
+
+		// Mobs spawned in the instance don't outlive it, even if they wandered off
+		for _, spawnDetails := range r.SpawnInfo {
+			if spawnDetails.InstanceId == 0 {
+				continue
+			}
+			if m := mobs.GetInstance(spawnDetails.InstanceId); m != nil {
+				if mobRoom, ok := roomManager.rooms[m.Character.RoomId]; ok {
+					mobRoom.RemoveMob(spawnDetails.InstanceId)
+				}
+				mobs.DestroyInstance(spawnDetails.InstanceId)
+			}
+		}
+
+		delete(roomManager.rooms, roomId)
+		delete(roomManager.roomsWithUsers, roomId)
+		delete(roomManager.roomsWithMobs, roomId)
+	}
+
+	delete(zoneInstances, inst.InstanceId)
+
+	mudlog.Info("Zone instance destroyed", "instanceId", inst.InstanceId, "zone", inst.Zone)
+}
+
+// Tears down any instances that have been empty for too long
+func instanceMaintenance() bool {
+
+	if len(zoneInstances) == 0 {
+		return false
+	}
+
+	roundNow := util.GetRoundCount()
+	unloadRounds := uint64(configs.GetMemoryConfig().InstanceUnloadRounds)
+
+	destroyed := false
+	for _, inst := range zoneInstances {
+
+		if inst.GetPlayerCount() > 0 {
+			inst.emptySinceRound = 0
+			continue
+		}
+
+		if inst.emptySinceRound == 0 {
+			inst.emptySinceRound = roundNow
+			continue
+		}
+
+		if roundNow-inst.emptySinceRound >= unloadRounds {
+			destroyZoneInstance(inst)
+			destroyed = true
+		}
+	}
+
+	return destroyed
+}
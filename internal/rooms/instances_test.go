@@ -0,0 +1,230 @@
+package rooms
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Writes a small instanced zone to disk and registers it with the room manager:
+// 9101 -east-> 9102 -east-> 9200 (outside the zone)
+func setupInstancedZone(t *testing.T) {
+
+	mudlog.SetupLogger(nil, `LOW`, ``, false)
+
+	dataDir := t.TempDir()
+	require.NoError(t, configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: dataDir}))
+
+	roomFiles := map[int]string{
+		9101: "roomid: 9101\nzone: Test Dungeon\nzoneconfig:\n  roomid: 9101\n  instanced: true\ntitle: Entrance\ndescription: The way in.\nexits:\n  east:\n    roomid: 9102\n",
+		9102: "roomid: 9102\nzone: Test Dungeon\ntitle: Hall\ndescription: A long hall.\nexits:\n  west:\n    roomid: 9101\n  east:\n    roomid: 9200\n",
+	}
+
+	zoneDir := filepath.Join(dataDir, `rooms`, `test_dungeon`)
+	require.NoError(t, os.MkdirAll(zoneDir, 0755))
+
+	zoneInfo := ZoneInfo{RootRoomId: 9101, RoomIds: map[int]struct{}{}}
+
+	for roomId, contents := range roomFiles {
+		fileName := filepath.Join(zoneDir, (&Room{RoomId: roomId}).Filename())
+		require.NoError(t, os.WriteFile(fileName, []byte(contents), 0644))
+
+		roomManager.roomIdToFileCache[roomId] = `test_dungeon/` + (&Room{RoomId: roomId}).Filename()
+		zoneInfo.RoomIds[roomId] = struct{}{}
+
+		r, err := loadRoomFromFile(fileName)
+		require.NoError(t, err)
+		roomManager.rooms[roomId] = r
+	}
+
+	roomManager.zones[`Test Dungeon`] = zoneInfo
+
+	t.Cleanup(func() {
+		for roomId := range roomFiles {
+			delete(roomManager.rooms, roomId)
+			delete(roomManager.roomIdToFileCache, roomId)
+		}
+		delete(roomManager.zones, `Test Dungeon`)
+		for _, inst := range GetZoneInstances() {
+			destroyZoneInstance(inst)
+		}
+		configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: `_datafiles/world/default`})
+	})
+}
+
+func TestCreateZoneInstance(t *testing.T) {
+
+	setupInstancedZone(t)
+
+	assert.True(t, IsZoneInstanced(`Test Dungeon`))
+
+	_, err := createZoneInstance(`Nowhere`, 1)
+	assert.Error(t, err)
+
+	inst, err := createZoneInstance(`Test Dungeon`, 1)
+	require.NoError(t, err)
+
+	assert.Len(t, inst.RoomIds, 2)
+	assert.Len(t, GetZoneInstances(), 1)
+
+	for sourceRoomId, instanceRoomId := range inst.RoomIds {
+
+		assert.GreaterOrEqual(t, instanceRoomId, instanceRoomIdStart, "Expected instance rooms to be numbered clear of saved rooms")
+
+		r, ok := roomManager.rooms[instanceRoomId]
+		require.True(t, ok, "Expected instance room %d to be loaded", instanceRoomId)
+
+		assert.Equal(t, inst.InstanceId, r.InstanceId)
+		assert.Equal(t, sourceRoomId, r.InstanceOf)
+		assert.Equal(t, sourceRoomId, GetSourceRoomId(instanceRoomId))
+		assert.Equal(t, ZoneConfig{}, r.ZoneConfig, "Expected instance rooms not to carry the zone config")
+		assert.Equal(t, roomManager.rooms[sourceRoomId].GetScriptPath(), r.GetScriptPath(), "Expected instance rooms to use the script of their source room")
+
+		returnRoomId, ok := GetInstanceReturnRoomId(instanceRoomId)
+		assert.True(t, ok)
+		assert.Equal(t, 1, returnRoomId)
+	}
+
+	// A second instance of the same zone gets its own rooms
+	inst2, err := createZoneInstance(`Test Dungeon`, 1)
+	require.NoError(t, err)
+	assert.NotEqual(t, inst.InstanceId, inst2.InstanceId)
+	assert.NotEqual(t, inst.RoomIds[9101], inst2.RoomIds[9101])
+
+	assert.Equal(t, 9101, GetSourceRoomId(9101), "Expected rooms that aren't instance rooms to be unchanged")
+	_, ok := GetInstanceReturnRoomId(9101)
+	assert.False(t, ok)
+}
+
+func TestZoneInstanceExits(t *testing.T) {
+
+	setupInstancedZone(t)
+
+	inst, err := createZoneInstance(`Test Dungeon`, 1)
+	require.NoError(t, err)
+
+	entrance := roomManager.rooms[inst.RoomIds[9101]]
+	hall := roomManager.rooms[inst.RoomIds[9102]]
+
+	assert.Equal(t, hall.RoomId, entrance.Exits[`east`].RoomId, "Expected exits within the zone to lead to the instance copies")
+	assert.Equal(t, entrance.RoomId, hall.Exits[`west`].RoomId)
+	assert.Equal(t, 9200, hall.Exits[`east`].RoomId, "Expected exits out of the zone to be left alone")
+
+	// The source rooms are untouched
+	assert.Equal(t, 9102, roomManager.rooms[9101].Exits[`east`].RoomId)
+	assert.Equal(t, 9101, roomManager.rooms[9102].Exits[`west`].RoomId)
+}
+
+func TestResolveInstanceRoom(t *testing.T) {
+
+	setupInstancedZone(t)
+
+	outside := &Room{RoomId: 9200, Zone: `Outside`}
+
+	r, err := resolveInstanceRoom(5, outside, roomManager.rooms[9101])
+	require.NoError(t, err)
+	require.Len(t, GetZoneInstances(), 1)
+
+	inst := GetZoneInstances()[0]
+	assert.Equal(t, inst.RoomIds[9101], r.RoomId)
+	assert.True(t, inst.HasUser(5))
+	assert.Equal(t, 9200, inst.ReturnRoomId, "Expected players to return to where they entered from")
+
+	// Moving within the instance stays put, and the same user always gets the same instance
+	r2, err := resolveInstanceRoom(5, r, roomManager.rooms[inst.RoomIds[9102]])
+	require.NoError(t, err)
+	assert.Equal(t, inst.RoomIds[9102], r2.RoomId)
+
+	r3, err := resolveInstanceRoom(5, outside, roomManager.rooms[9102])
+	require.NoError(t, err)
+	assert.Equal(t, inst.RoomIds[9102], r3.RoomId)
+	assert.Len(t, GetZoneInstances(), 1)
+
+	// Someone else gets their own
+	r4, err := resolveInstanceRoom(6, outside, roomManager.rooms[9101])
+	require.NoError(t, err)
+	assert.NotEqual(t, r.RoomId, r4.RoomId)
+	assert.Len(t, GetZoneInstances(), 2)
+
+	// Zones that aren't instanced aren't rerouted
+	r5, err := resolveInstanceRoom(5, r, outside)
+	require.NoError(t, err)
+	assert.Equal(t, outside, r5)
+}
+
+func TestDestroyZoneInstance(t *testing.T) {
+
+	setupInstancedZone(t)
+
+	inst, err := createZoneInstance(`Test Dungeon`, 1)
+	require.NoError(t, err)
+
+	instanceRoomIds := []int{}
+	for _, roomId := range inst.RoomIds {
+		instanceRoomIds = append(instanceRoomIds, roomId)
+	}
+
+	assert.Equal(t, 0, inst.GetPlayerCount())
+
+	destroyZoneInstance(inst)
+
+	assert.Empty(t, GetZoneInstances())
+	for _, roomId := range instanceRoomIds {
+		assert.False(t, IsRoomLoaded(roomId), "Expected instance room %d to be unloaded", roomId)
+	}
+
+	assert.True(t, IsRoomLoaded(9101), "Expected the source rooms to stay loaded")
+	assert.True(t, IsRoomLoaded(9102))
+}
+
+func TestIsForeignInstanceRoom(t *testing.T) {
+
+	setupInstancedZone(t)
+
+	r, err := resolveInstanceRoom(5, &Room{RoomId: 9200, Zone: `Outside`}, roomManager.rooms[9101])
+	require.NoError(t, err)
+
+	assert.False(t, IsForeignInstanceRoom(5, 9101), "Expected rooms that aren't instance rooms to be allowed")
+	assert.False(t, IsForeignInstanceRoom(5, r.RoomId), "Expected players to be allowed in their own instance")
+	assert.True(t, IsForeignInstanceRoom(6, r.RoomId), "Expected players to be refused someone else's instance")
+	assert.True(t, IsForeignInstanceRoom(5, nextInstanceRoomId+10), "Expected instance rooms that don't exist to be refused")
+}
+
+func TestSaveAllUsersOutsideInstances(t *testing.T) {
+
+	setupInstancedZone(t)
+
+	store, err := users.NewSQLiteUserStore(filepath.Join(t.TempDir(), `users.db`))
+	require.NoError(t, err)
+	users.SetUserStore(store)
+
+	u := users.NewUserRecord(9001, 9001)
+	u.Username = `Explorer`
+	u.Character.Name = `Explorer`
+	_, _, err = users.LoginUser(u, connections.ConnectionId(9001))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		users.LogOutUserByConnectionId(9001)
+		users.SetUserStore(users.NewYamlUserStore())
+	})
+
+	r, err := resolveInstanceRoom(u.UserId, &Room{RoomId: 9200, Zone: `Outside`}, roomManager.rooms[9102])
+	require.NoError(t, err)
+	u.Character.RoomId = r.RoomId
+
+	SaveAllUsersOutsideInstances(true)
+
+	assert.Equal(t, r.RoomId, u.Character.RoomId, "Expected the player to stay where they are")
+
+	saved, err := store.Load(u.UserId)
+	require.NoError(t, err)
+	assert.Equal(t, 9200, saved.Character.RoomId, "Expected the player to be saved where they'd be returned to")
+}
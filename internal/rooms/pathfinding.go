@@ -0,0 +1,206 @@
+package rooms
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/exit"
+)
+
+type PathFlag uint8
+
+const (
+	PathAllowLocked PathFlag = 1 << iota // Include exits that are currently locked
+	PathAllowSecret                      // Include secret exits
+
+	// Give up searching after this many rooms, so a missing path can't stall a round
+	maxPathSearchRooms = 20000
+)
+
+var (
+	ErrNoPath = errors.New(`no path found`)
+
+	// Details of every room as they are saved on disk, so rooms don't need to be loaded to search through them.
+	roomPathCache = map[int]pathNode{}
+)
+
+type pathNode struct {
+	MapLegend string
+	Exits     map[string]exit.RoomExit
+}
+
+// A single move along a path
+type PathStep struct {
+	ExitName string
+	RoomId   int
+}
+
+func (f PathFlag) Has(flag PathFlag) bool {
+	return f&flag == flag
+}
+
+func cacheRoomPathNode(r *Room) {
+	exits := make(map[string]exit.RoomExit, len(r.Exits))
+	for exitName, exitInfo := range r.Exits {
+		exits[exitName] = exitInfo
+	}
+	roomPathCache[r.RoomId] = pathNode{
+		MapLegend: r.MapLegend,
+		Exits:     exits,
+	}
+}
+
+// Returns the map legend of a room, whether or not it is loaded
+func getPathMapLegend(roomId int) string {
+	if r, ok := roomManager.rooms[roomId]; ok {
+		return r.MapLegend
+	}
+	return roomPathCache[roomId].MapLegend
+}
+
+// Returns the exits that can currently be taken out of a room.
+// Loaded rooms use their live exits (including any active mutator exits and lock state),
+// while rooms that aren't loaded fall back on the exits they were saved with.
+func getPathExits(roomId int, flags PathFlag) map[string]exit.RoomExit {
+
+	exits := map[string]exit.RoomExit{}
+
+	if r, ok := roomManager.rooms[roomId]; ok {
+
+		for exitName, exitInfo := range r.Exits {
+			exits[exitName] = exitInfo
+		}
+
+		// Mutator exits take over regular exits of the same name
+		for mut := range r.ActiveMutators {
+			for exitName, exitInfo := range mut.GetSpec().Exits {
+				exits[exitName] = exitInfo
+			}
+		}
+
+	} else {
+
+		for exitName, exitInfo := range roomPathCache[roomId].Exits {
+			exits[exitName] = exitInfo
+		}
+
+	}
+
+	for exitName, exitInfo := range exits {
+		if exitInfo.Secret && !flags.Has(PathAllowSecret) {
+			delete(exits, exitName)
+			continue
+		}
+		if exitInfo.Lock.IsLocked() && !flags.Has(PathAllowLocked) {
+			delete(exits, exitName)
+		}
+	}
+
+	return exits
+}
+
+// Finds the shortest path from one room to another, following room exits.
+// Returns the steps to take in order, or ErrNoPath if the destination can't be reached.
+func FindPath(fromRoomId int, toRoomId int, flags PathFlag) ([]PathStep, error) {
+	return findPathWhere(fromRoomId, flags, func(roomId int) bool {
+		return roomId == toRoomId
+	})
+}
+
+// Finds the shortest path to the closest room with a map legend matching the landmark, such as "bank" or "inn".
+func FindLandmarkPath(fromRoomId int, landmark string, flags PathFlag) ([]PathStep, error) {
+	return findPathWhere(fromRoomId, flags, func(roomId int) bool {
+		return strings.EqualFold(getPathMapLegend(roomId), landmark)
+	})
+}
+
+// Returns the landmarks (map legends) found in a zone, sorted by name
+func GetZoneLandmarks(zone string) []string {
+
+	found := map[string]struct{}{}
+
+	if zoneInfo, ok := roomManager.zones[zone]; ok {
+		for roomId := range zoneInfo.RoomIds {
+			if legend := getPathMapLegend(roomId); legend != `` {
+				found[legend] = struct{}{}
+			}
+		}
+	}
+
+	landmarks := make([]string, 0, len(found))
+	for legend := range found {
+		landmarks = append(landmarks, legend)
+	}
+	sort.Strings(landmarks)
+
+	return landmarks
+}
+
+// Searches outwards from a room until it finds one that satisfies isDestination
+func findPathWhere(fromRoomId int, flags PathFlag, isDestination func(roomId int) bool) ([]PathStep, error) {
+
+	if isDestination(fromRoomId) {
+		return []PathStep{}, nil
+	}
+
+	// Where each visited room was reached from
+	cameFrom := map[int]PathStep{
+		fromRoomId: {RoomId: 0},
+	}
+
+	queue := []int{fromRoomId}
+
+	for len(queue) > 0 && len(cameFrom) < maxPathSearchRooms {
+
+		roomId := queue[0]
+		queue = queue[1:]
+
+		exits := getPathExits(roomId, flags)
+
+		// Sorted so that ties between equally short paths always resolve the same way
+		exitNames := make([]string, 0, len(exits))
+		for exitName := range exits {
+			exitNames = append(exitNames, exitName)
+		}
+		sort.Strings(exitNames)
+
+		for _, exitName := range exitNames {
+
+			nextRoomId := exits[exitName].RoomId
+
+			if _, ok := cameFrom[nextRoomId]; ok {
+				continue
+			}
+
+			cameFrom[nextRoomId] = PathStep{ExitName: exitName, RoomId: roomId}
+
+			if isDestination(nextRoomId) {
+				return buildPath(cameFrom, fromRoomId, nextRoomId), nil
+			}
+
+			queue = append(queue, nextRoomId)
+		}
+	}
+
+	return nil, ErrNoPath
+}
+
+// Walks back from the destination to put together the steps taken to get there
+func buildPath(cameFrom map[int]PathStep, fromRoomId int, toRoomId int) []PathStep {
+
+	path := []PathStep{}
+
+	for roomId := toRoomId; roomId != fromRoomId; {
+		step := cameFrom[roomId]
+		path = append(path, PathStep{ExitName: step.ExitName, RoomId: roomId})
+		roomId = step.RoomId
+	}
+
+	// Reverse it so the first step is first
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return path
+}
@@ -0,0 +1,58 @@
+package rooms
+
+import (
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/exit"
+	"github.com/GoMudEngine/GoMud/internal/gamelock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindPath(t *testing.T) {
+
+	// 9001 (loaded) -east-> 9002 (not loaded) -north-> 9003 -secret-> 9004
+	//   \-locked door-> 9004
+	roomManager.rooms[9001] = &Room{RoomId: 9001, Exits: map[string]exit.RoomExit{
+		`east`: {RoomId: 9002},
+		`door`: {RoomId: 9004, Lock: gamelock.Lock{Difficulty: 5}},
+	}}
+	roomPathCache[9002] = pathNode{Exits: map[string]exit.RoomExit{
+		`west`:  {RoomId: 9001},
+		`north`: {RoomId: 9003},
+	}}
+	roomPathCache[9003] = pathNode{MapLegend: `Inn`, Exits: map[string]exit.RoomExit{
+		`south`: {RoomId: 9002},
+		`crack`: {RoomId: 9004, Secret: true},
+	}}
+
+	defer func() {
+		delete(roomManager.rooms, 9001)
+		delete(roomPathCache, 9002)
+		delete(roomPathCache, 9003)
+	}()
+
+	path, err := FindPath(9001, 9001, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, path, "Expected no steps to reach the same room")
+
+	path, err = FindPath(9001, 9003, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []PathStep{{ExitName: `east`, RoomId: 9002}, {ExitName: `north`, RoomId: 9003}}, path)
+
+	_, err = FindPath(9001, 9004, 0)
+	assert.ErrorIs(t, err, ErrNoPath, "Expected locked and secret exits to be avoided")
+
+	path, err = FindPath(9001, 9004, PathAllowSecret)
+	assert.NoError(t, err)
+	assert.Len(t, path, 3)
+	assert.Equal(t, `crack`, path[2].ExitName)
+
+	path, err = FindPath(9001, 9004, PathAllowSecret|PathAllowLocked)
+	assert.NoError(t, err)
+	assert.Equal(t, []PathStep{{ExitName: `door`, RoomId: 9004}}, path, "Expected the shortest path")
+
+	path, err = FindLandmarkPath(9001, `inn`, 0)
+	assert.NoError(t, err)
+	assert.Len(t, path, 2, "Expected landmarks to match regardless of case")
+	assert.Equal(t, 9003, path[1].RoomId)
+}
@@ -35,6 +35,7 @@ type RoomTemplateDetails struct {
 	IsDark         bool
 	IsNight        bool
 	TrackingString string
+	Weather        string   // Description of the current weather, if outdoors
 	RoomAlerts     []string // Messages to show below room description as a special alert
 	ShowPvp        bool     // Whether to display that the room is PVP
 }
@@ -80,6 +81,10 @@ func GetDetails(r *Room, user *users.UserRecord, tinymap ...[]string) RoomTempla
 		ShowPvp:        showPvp,
 	}
 
+	if w := r.GetWeather(); w != nil && w.Description != `` {
+		details.Weather = strings.Join(util.SplitString(w.Description, 80), "\n")
+	}
+
 	//
 	// Start Room Alerts
 	//
@@ -96,6 +101,14 @@ func GetDetails(r *Room, user *users.UserRecord, tinymap ...[]string) RoomTempla
 		details.RoomAlerts = append(details.RoomAlerts, ` <ansi fg="yellow-bold">This is an item storage location!</ansi> Type <ansi fg="command">storage</ansi> to store/unstore.`)
 	}
 
+	if r.IsHousing {
+		details.RoomAlerts = append(details.RoomAlerts, `   <ansi fg="yellow-bold">This is a residential area!</ansi> Type <ansi fg="command">house</ansi> to buy or enter a home.`)
+	}
+
+	if r.IsArena {
+		details.RoomAlerts = append(details.RoomAlerts, `         <ansi fg="yellow-bold">This is an arena!</ansi> Type <ansi fg="command">duel</ansi> to challenge or bet.`)
+	}
+
 	if r.IsCharacterRoom {
 		details.RoomAlerts = append(details.RoomAlerts, `      <ansi fg="yellow-bold">This is a character room!</ansi> Type <ansi fg="command">character</ansi> to interact.`)
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/fileloader"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/mutators"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"gopkg.in/yaml.v2"
@@ -150,7 +151,8 @@ func RoomMaintenance() bool {
 		}
 
 		// Consider unloading rooms from memory?
-		if allowedUnloadCt > 0 {
+		// Instance rooms are never saved, so they are only removed along with their instance.
+		if allowedUnloadCt > 0 && room.InstanceId == 0 {
 			if room.lastVisited < unloadRoundThreshold {
 				unloadRooms = append(unloadRooms, room)
 				allowedUnloadCt--
@@ -166,6 +168,10 @@ func RoomMaintenance() bool {
 		roomsUpdated = true
 	}
 
+	if instanceMaintenance() {
+		roomsUpdated = true
+	}
+
 	return roomsUpdated
 }
 
@@ -217,6 +223,13 @@ func MoveToRoom(userId int, toRoomId int, isSpawn ...bool) error {
 		return fmt.Errorf(`room %d not found`, toRoomId)
 	}
 
+	// Instanced zones route to the users own copy of the room
+	newRoom, err := resolveInstanceRoom(userId, currentRoom, newRoom)
+	if err != nil {
+		return err
+	}
+	toRoomId = newRoom.RoomId
+
 	// r.prepare locks, so do it before the upcoming lock
 	if len(newRoom.players) == 0 {
 		newRoom.Prepare(true)
@@ -250,6 +263,14 @@ func MoveToRoom(userId int, toRoomId int, isSpawn ...bool) error {
 	// Done adding mutator buffs
 	//
 
+	if w := newRoom.GetWeather(); w != nil {
+		for _, buffId := range w.PlayerBuffIds {
+			if !user.Character.HasBuff(buffId) {
+				user.AddBuff(buffId, `weather`)
+			}
+		}
+	}
+
 	playerCt := newRoom.AddPlayer(userId)
 	roomManager.roomsWithUsers[newRoom.RoomId] = playerCt
 
@@ -432,9 +453,21 @@ func SaveAllRooms() error {
 		saveModes = append(saveModes, fileloader.SaveCareful)
 	}
 
-	saveCt, err := fileloader.SaveAllFlatFiles[int, *Room](configs.GetFilePathsConfig().DataFiles.String()+`/rooms`, roomManager.rooms, saveModes...)
+	saveRooms := roomManager.rooms
 
-	mudlog.Info("SaveAllRooms()", "savedCount", saveCt, "expectedCt", len(roomManager.rooms), "Time Taken", time.Since(start))
+	// Instance rooms are temporary and never saved
+	if len(zoneInstances) > 0 {
+		saveRooms = make(map[int]*Room, len(roomManager.rooms))
+		for roomId, loadedRoom := range roomManager.rooms {
+			if loadedRoom.InstanceId == 0 {
+				saveRooms[roomId] = loadedRoom
+			}
+		}
+	}
+
+	saveCt, err := fileloader.SaveAllFlatFiles[int, *Room](configs.GetFilePathsConfig().DataFiles.String()+`/rooms`, saveRooms, saveModes...)
+
+	mudlog.Info("SaveAllRooms()", "savedCount", saveCt, "expectedCt", len(saveRooms), "Time Taken", time.Since(start))
 
 	return err
 }
@@ -464,6 +497,11 @@ func loadAllRoomZones() error {
 			continue
 		}
 
+		// Player homes are only entered through the housing command
+		if loadedRoom.HomeOwner > 0 {
+			continue
+		}
+
 		// If it has never been set, set it to the filepath
 		if _, ok := roomsWithoutEntrances[loadedRoom.RoomId]; !ok {
 			roomsWithoutEntrances[loadedRoom.RoomId] = loadedRoom.Filepath()
@@ -495,6 +533,12 @@ func loadAllRoomZones() error {
 		// Cache the file path for every roomId
 		roomManager.roomIdToFileCache[loadedRoom.RoomId] = loadedRoom.Filepath()
 
+		cacheRoomPathNode(loadedRoom)
+
+		if loadedRoom.HomeOwner > 0 {
+			trackHomeRoom(loadedRoom.HomeOwner, loadedRoom.RoomId)
+		}
+
 		// Update the zone info cache
 		if _, ok := roomManager.zones[loadedRoom.Zone]; !ok {
 			roomManager.zones[loadedRoom.Zone] = ZoneInfo{
@@ -576,13 +620,7 @@ func addRoomToMemory(r *Room) {
 		roomManager.roomIdToFileCache[r.RoomId] = r.Filepath()
 	}
 
-	// Hash the descriptions and store centrally.
-	// This saves a lot of memory because many descriptions are duplicates
-	hash := util.Hash(r.Description)
-	if _, ok := roomManager.roomDescriptionCache[hash]; !ok {
-		roomManager.roomDescriptionCache[hash] = r.Description
-	}
-	r.Description = fmt.Sprintf(`h:%s`, hash)
+	cacheRoomDescription(r)
 
 	// Track whatever the last room id created is so we know what to number the next one.
 	if r.RoomId >= GetNextRoomId() {
@@ -608,6 +646,21 @@ func addRoomToMemory(r *Room) {
 
 }
 
+// Hash the descriptions and store centrally.
+// This saves a lot of memory because many descriptions are duplicates
+func cacheRoomDescription(r *Room) {
+
+	if strings.HasPrefix(r.Description, `h:`) {
+		return
+	}
+
+	hash := util.Hash(r.Description)
+	if _, ok := roomManager.roomDescriptionCache[hash]; !ok {
+		roomManager.roomDescriptionCache[hash] = r.Description
+	}
+	r.Description = fmt.Sprintf(`h:%s`, hash)
+}
+
 func findRoomFile(roomId int) string {
 
 	foundFilePath := ``
@@ -692,6 +745,11 @@ func LoadRoom(roomId int) *Room {
 		return room
 	}
 
+	// Instance rooms only exist in memory
+	if roomId >= instanceRoomIdStart {
+		return nil
+	}
+
 	filename := findRoomFile(roomId)
 	if len(filename) == 0 {
 		return nil
@@ -706,6 +764,11 @@ func LoadRoom(roomId int) *Room {
 
 func SaveRoom(r Room) error {
 
+	// Instance rooms only exist in memory
+	if r.InstanceId > 0 {
+		return nil
+	}
+
 	if strings.HasPrefix(r.Description, `h:`) {
 		hash := strings.TrimPrefix(r.Description, `h:`)
 		if description, ok := roomManager.roomDescriptionCache[hash]; ok {
@@ -726,6 +789,8 @@ func SaveRoom(r Room) error {
 		return err
 	}
 
+	cacheRoomPathNode(&r)
+
 	//mudlog.Info("Saved room", "room", r.RoomId)
 
 	return nil
@@ -977,6 +1042,191 @@ func GetRoomCount(zoneName string) int {
 	return len(zoneInfo.RoomIds)
 }
 
+// Returns the roomId's of every room in a zone
+func GetZoneRoomIds(zoneName string) []int {
+
+	zoneInfo, ok := roomManager.zones[zoneName]
+	if !ok {
+		return []int{}
+	}
+
+	roomIds := make([]int, 0, len(zoneInfo.RoomIds))
+	for roomId := range zoneInfo.RoomIds {
+		roomIds = append(roomIds, roomId)
+	}
+
+	return roomIds
+}
+
+// Creates a room in an existing zone from the details provided, assigning it the next roomId.
+// The new room is not connected to anything.
+func CreateNewRoomFile(newRoomInfo Room) (int, error) {
+
+	if _, ok := roomManager.zones[newRoomInfo.Zone]; !ok {
+		return 0, fmt.Errorf("zone %s does not exist.", newRoomInfo.Zone)
+	}
+
+	// Only CreateZone() makes root rooms
+	newRoomInfo.ZoneConfig = ZoneConfig{}
+
+	if err := newRoomInfo.Validate(); err != nil {
+		return 0, err
+	}
+
+	newRoom := NewRoom(newRoomInfo.Zone)
+
+	roomId := newRoom.RoomId
+	players, visitors, tempDataStore := newRoom.players, newRoom.visitors, newRoom.tempDataStore
+
+	*newRoom = newRoomInfo
+
+	newRoom.RoomId = roomId
+	newRoom.players = players
+	newRoom.visitors = visitors
+	newRoom.tempDataStore = tempDataStore
+
+	if newRoom.Exits == nil {
+		newRoom.Exits = make(map[string]exit.RoomExit)
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*Room](configs.GetFilePathsConfig().DataFiles.String()+`/rooms`, newRoom, saveModes...); err != nil {
+		return 0, err
+	}
+
+	addRoomToMemory(newRoom)
+
+	return newRoom.RoomId, nil
+}
+
+// Replaces the saved details of an existing room.
+// Anyone or anything currently in the room stays put, and mutators keep their timing.
+// Use MoveToZone() to change the zone of a room.
+func UpdateRoomFile(roomInfo Room) error {
+
+	room := LoadRoom(roomInfo.RoomId)
+	if room == nil {
+		return fmt.Errorf(`room %d not found`, roomInfo.RoomId)
+	}
+
+	if roomInfo.Zone != room.Zone {
+		return errors.New(`the zone of a room cannot be changed here`)
+	}
+
+	// Whether this is the root room of the zone can't change
+	roomInfo.ZoneConfig.RoomId = room.ZoneConfig.RoomId
+	if roomInfo.ZoneConfig.RoomId != roomInfo.RoomId {
+		roomInfo.ZoneConfig = ZoneConfig{}
+	}
+
+	if err := roomInfo.Validate(); err != nil {
+		return err
+	}
+
+	// Carry over everything that isn't saved
+	roomInfo.ExitsTemp = room.ExitsTemp
+	roomInfo.Corpses = room.Corpses
+	roomInfo.LastIdleMessage = room.LastIdleMessage
+	roomInfo.players = room.players
+	roomInfo.mobs = room.mobs
+	roomInfo.visitors = room.visitors
+	roomInfo.lastVisited = room.lastVisited
+	roomInfo.tempDataStore = room.tempDataStore
+
+	roomInfo.Mutators = keepMutatorTiming(room.Mutators, roomInfo.Mutators)
+	roomInfo.ZoneConfig.Mutators = keepMutatorTiming(room.ZoneConfig.Mutators, roomInfo.ZoneConfig.Mutators)
+
+	if roomInfo.Exits == nil {
+		roomInfo.Exits = make(map[string]exit.RoomExit)
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*Room](configs.GetFilePathsConfig().DataFiles.String()+`/rooms`, &roomInfo, saveModes...); err != nil {
+		return err
+	}
+
+	cacheRoomDescription(&roomInfo)
+
+	// Update in place, since other code may be holding the pointer
+	*room = roomInfo
+
+	if room.ZoneConfig.RoomId == room.RoomId {
+		zoneInfo := roomManager.zones[room.Zone]
+		zoneInfo.DefaultBiome = room.Biome
+		zoneInfo.HasZoneMutators = len(room.ZoneConfig.Mutators) > 0
+		roomManager.zones[room.Zone] = zoneInfo
+	}
+
+	return nil
+}
+
+// Removes a room (and any script) from disk and memory.
+// Rooms with players in them and the root room of a zone cannot be deleted.
+// Exits in other rooms that lead here are not removed.
+func DeleteRoomFile(roomId int) error {
+
+	room := LoadRoom(roomId)
+	if room == nil {
+		return fmt.Errorf(`room %d not found`, roomId)
+	}
+
+	if len(room.players) > 0 {
+		return errors.New(`there are players in the room`)
+	}
+
+	if room.ZoneConfig.RoomId == room.RoomId {
+		return errors.New(`can't delete the root room of a zone`)
+	}
+
+	if err := fileloader.DeleteFlatFile[*Room](configs.GetFilePathsConfig().DataFiles.String()+`/rooms`, room); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(room.GetScriptPath()); err == nil {
+		os.Remove(room.GetScriptPath())
+	}
+
+	for _, mobInstanceId := range room.mobs {
+		mobs.DestroyInstance(mobInstanceId)
+	}
+
+	if zoneInfo, ok := roomManager.zones[room.Zone]; ok {
+		delete(zoneInfo.RoomIds, roomId)
+		roomManager.zones[room.Zone] = zoneInfo
+	}
+
+	delete(roomManager.rooms, roomId)
+	delete(roomManager.roomIdToFileCache, roomId)
+	delete(roomPathCache, roomId)
+
+	return nil
+}
+
+// Mutators that are still listed keep their spawn/despawn rounds
+func keepMutatorTiming(oldList mutators.MutatorList, newList mutators.MutatorList) mutators.MutatorList {
+
+	for i, newMut := range newList {
+		for _, oldMut := range oldList {
+			if oldMut.MutatorId == newMut.MutatorId {
+				newList[i] = oldMut
+				break
+			}
+		}
+	}
+
+	return newList
+}
+
 func LoadDataFiles() {
 
 	if len(roomManager.zones) > 0 {
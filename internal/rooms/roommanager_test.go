@@ -0,0 +1,82 @@
+package rooms
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateNewRoomFile(t *testing.T) {
+
+	setupInstancedZone(t)
+	dataDir := configs.GetFilePathsConfig().DataFiles.String()
+
+	require.NoError(t, configs.AddOverlayOverrides(map[string]any{`Server.NextRoomId`: 9150}))
+
+	tests := []struct {
+		name    string
+		room    Room
+		wantErr bool
+	}{
+		{"New room", Room{Zone: `Test Dungeon`, Title: `Closet`, Description: `A small closet.`}, false},
+		{"Can't make a root room", Room{Zone: `Test Dungeon`, Title: `Throne`, Description: `A throne room.`, ZoneConfig: ZoneConfig{RoomId: 9101}}, false},
+		{"Unknown zone", Room{Zone: `Nowhere`, Title: `Void`, Description: `Nothing at all.`}, true},
+		{"Missing title", Room{Zone: `Test Dungeon`, Description: `No name.`}, true},
+		{"Missing description", Room{Zone: `Test Dungeon`, Title: `Blank`}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+
+			roomId, err := CreateNewRoomFile(tt.room)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				delete(roomManager.rooms, roomId)
+				delete(roomManager.roomIdToFileCache, roomId)
+			})
+
+			assert.FileExists(t, filepath.Join(dataDir, `rooms`, `test_dungeon`, (&Room{RoomId: roomId}).Filename()))
+
+			r := LoadRoom(roomId)
+			require.NotNil(t, r)
+			assert.Equal(t, tt.room.Title, r.Title)
+			assert.Equal(t, ZoneConfig{}, r.ZoneConfig, "Expected new rooms never to be zone roots")
+			assert.NotNil(t, r.Exits)
+			assert.Contains(t, roomManager.zones[`Test Dungeon`].RoomIds, roomId)
+		})
+	}
+}
+
+func TestDeleteRoomFile(t *testing.T) {
+
+	setupInstancedZone(t)
+	dataDir := configs.GetFilePathsConfig().DataFiles.String()
+
+	hallFile := filepath.Join(dataDir, `rooms`, `test_dungeon`, (&Room{RoomId: 9102}).Filename())
+
+	assert.Error(t, DeleteRoomFile(9999999), "Expected rooms that don't exist not to be deleted")
+
+	assert.Error(t, DeleteRoomFile(9101), "Expected the root room of a zone not to be deleted")
+	assert.True(t, IsRoomLoaded(9101))
+
+	hall := roomManager.rooms[9102]
+	hall.AddPlayer(5)
+	assert.Error(t, DeleteRoomFile(9102), "Expected rooms with players in them not to be deleted")
+	assert.FileExists(t, hallFile)
+
+	hall.RemovePlayer(5)
+	require.NoError(t, DeleteRoomFile(9102))
+
+	assert.NoFileExists(t, hallFile)
+	assert.False(t, IsRoomLoaded(9102))
+	assert.NotContains(t, roomManager.roomIdToFileCache, 9102)
+	assert.NotContains(t, roomManager.zones[`Test Dungeon`].RoomIds, 9102)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/audio"
 	"github.com/GoMudEngine/GoMud/internal/buffs"
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/duels"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/exit"
 	"github.com/GoMudEngine/GoMud/internal/gametime"
@@ -19,6 +20,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/mutators"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/weather"
 )
 
 const visitorTrackingTimeout = 180 // 180 seconds (3 minutes?)
@@ -70,6 +72,11 @@ type Room struct {
 	IsBank            bool                              `yaml:"isbank,omitempty"`            // Is this a bank room? If so, players can deposit/withdraw gold here.
 	IsStorage         bool                              `yaml:"isstorage,omitempty"`         // Is this a storage room? If so, players can add/remove objects here.
 	IsCharacterRoom   bool                              `yaml:"ischaracterroom,omitempty"`   // Is this a room where characters can create new characters to swap between them?
+	IsHousing         bool                              `yaml:"ishousing,omitempty"`         // Is this a room where players can buy and enter their homes?
+	IsArena           bool                              `yaml:"isarena,omitempty"`           // Is this an arena? If so, duels here can be watched and bet on.
+	ArenaStands       []int                             `yaml:"arenastands,omitempty"`       // Rooms that spectators can watch (and bet on) duels in this arena from
+	HomeOwner         int                               `yaml:"homeowner,omitempty"`         // If set, this room is part of the home of this userId
+	HomeGuests        []HomeGuest                       `yaml:"homeguests,omitempty"`        // Players allowed to visit the home. Only kept on the entrance room of a home.
 	Title             string                            `yaml:"title"`                       // Title shown to the user
 	Description       string                            `yaml:"description"`                 // Description shown to the user
 	MapSymbol         string                            `yaml:"mapsymbol,omitempty"`         // The symbol to use when generating a map of the zone
@@ -91,6 +98,8 @@ type Room struct {
 	LongTermDataStore map[string]any                    `yaml:"longtermdatastore,omitempty"` // Long term data store for the room
 	Mutators          mutators.MutatorList              `yaml:"mutators,omitempty"`          // mutators this room spawns with.
 	Pvp               bool                              `yaml:"pvp,omitempty"`               // if config pvp is set to `limited`, uses this value
+	InstanceId        int                               `yaml:"-"`                           // If set, this room is a temporary copy belonging to a zone instance
+	InstanceOf        int                               `yaml:"-"`                           // If an instance room, the roomId it was copied from
 	players           []int                             `yaml:"-"`                           // list of user IDs currently in the room
 	mobs              []int                             `yaml:"-"`                           // list of mob instance IDs currently in the room. Does not get saved.
 	visitors          map[VisitorType]map[int]uint64    `yaml:"-"`                           // list of user IDs that have visited this room, and the last round they did
@@ -152,6 +161,11 @@ func (r *Room) GetVisibility() int {
 		}
 	}
 
+	// Apply any weather
+	if w := r.GetWeather(); w != nil {
+		visibility += w.LightMod
+	}
+
 	// min/max visibility
 	if visibility < 0 {
 		visibility = 0
@@ -226,12 +240,15 @@ func (r *Room) UpdateCorpses(roundNow uint64) {
 	}
 }
 
-func (r *Room) SendTextCommunication(txt string, excludeUserIds ...int) {
+// Sends something a user said or did to everyone else in the room.
+// Players that are deafened or ignoring the user won't receive it.
+func (r *Room) SendTextCommunication(txt string, sourceUserId int) {
 
 	events.AddToQueue(events.Message{
 		RoomId:          r.RoomId,
+		SourceUserId:    sourceUserId,
 		Text:            txt + "\n",
-		ExcludeUserIds:  excludeUserIds,
+		ExcludeUserIds:  []int{sourceUserId},
 		IsQuiet:         false,
 		IsCommunication: true,
 	})
@@ -388,8 +405,15 @@ func (r *Room) GetScript() string {
 
 func (r *Room) GetScriptPath() string {
 
+	roomFilepath := r.Filepath()
+
+	// Instance rooms run the script of the room they were copied from
+	if r.InstanceOf > 0 {
+		roomFilepath = util.FilePath(ZoneNameSanitize(r.Zone), `/`, fmt.Sprintf("%d.yaml", r.InstanceOf))
+	}
+
 	// Load any script for the room
-	return strings.Replace(configs.GetFilePathsConfig().DataFiles.String()+`/rooms/`+r.Filepath(), `.yaml`, `.js`, 1)
+	return strings.Replace(configs.GetFilePathsConfig().DataFiles.String()+`/rooms/`+roomFilepath, `.yaml`, `.js`, 1)
 }
 
 func (r *Room) FindTemporaryExitByUserId(userId int) (exit.TemporaryRoomExit, bool) {
@@ -1345,6 +1369,12 @@ func (r *Room) GetDescription() string {
 	return roomManager.roomDescriptionCache[hash]
 }
 
+// Replaces the description of the room, caching it like descriptions loaded from disk.
+func (r *Room) SetDescription(description string) {
+	r.Description = description
+	cacheRoomDescription(r)
+}
+
 func (r *Room) HasRecentVisitors() bool {
 
 	return r.visitors != nil && len(r.visitors) > 0
@@ -2014,6 +2044,11 @@ func (r *Room) RoundTick() {
 	// Done adding mutator buffs
 	//
 
+	if w := r.GetWeather(); w != nil {
+		r.ApplyBuffIdToPlayers(w.PlayerBuffIds, `weather`)
+		r.ApplyBuffIdToMobs(w.MobBuffIds, `weather`)
+	}
+
 	for idx, spawnInfo := range r.SpawnInfo {
 
 		// Make sure to clean up any instances that may be dead
@@ -2266,6 +2301,20 @@ func (r *Room) GetBiome() BiomeInfo {
 	return bInfo
 }
 
+// Rooms are outdoors unless their biome is sheltered (houses, caves etc.)
+func (r *Room) IsOutdoors() bool {
+	return !r.GetBiome().IsSheltered()
+}
+
+// Returns the current weather of the room, or nil if the room is indoors
+// or has no weather.
+func (r *Room) GetWeather() *weather.WeatherSpec {
+	if !r.IsOutdoors() {
+		return nil
+	}
+	return weather.GetZoneWeather(r.Zone)
+}
+
 func (r *Room) ActiveMutators(yield func(mutators.Mutator) bool) {
 
 	var activeMutators mutators.MutatorList
@@ -2301,6 +2350,20 @@ func (r *Room) CanPvp(attUser *users.UserRecord, defUser *users.UserRecord) erro
 		return errors.New(`Fighting is not allowed here.`)
 	}
 
+	// Duels are agreed to by both sides, so they ignore the usual pvp rules
+	if duels.IsDueling(attUser.UserId, defUser.UserId) {
+		return nil
+	}
+
+	// Nobody else gets to join in on a duel
+	if d := duels.Get(defUser.UserId); d != nil && d.State != duels.Challenged {
+		return fmt.Errorf(`%s is fighting a duel.`, defUser.Character.Name)
+	}
+
+	if d := duels.Get(attUser.UserId); d != nil && d.State != duels.Challenged {
+		return errors.New(`You are in the middle of a duel.`)
+	}
+
 	c := configs.GetGamePlayConfig()
 
 	// Possible settings are `enabled`, `disabled`, `limited`
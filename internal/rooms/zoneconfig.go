@@ -14,6 +14,7 @@ type ZoneConfig struct {
 	Mutators     mutators.MutatorList `yaml:"mutators,omitempty"`     // mutators defined here apply to entire zone
 	IdleMessages []string             `yaml:"idlemessages,omitempty"` // list of messages that can be displayed to players in the zone, assuming a room has none defined
 	MusicFile    string               `yaml:"musicfile,omitempty"`    // background music to play when in this zone
+	Instanced    bool                 `yaml:"instanced,omitempty"`    // if true, each party entering the zone gets its own private copy of it
 }
 
 func (z *ZoneConfig) Validate() {
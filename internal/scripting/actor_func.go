@@ -1,6 +1,7 @@
 package scripting
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
@@ -8,6 +9,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/combat"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/items"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/parties"
 	"github.com/GoMudEngine/GoMud/internal/pets"
@@ -294,6 +296,13 @@ func (a ScriptActor) AddHealth(amt int) int {
 	return ret
 }
 
+func (a ScriptActor) TakeElementalDamage(amt int, element string) int {
+	if elem := items.FindElement(element); elem != `` {
+		amt = combat.ElementalDamage(amt, a.characterRecord.GetResistance(elem))
+	}
+	return a.AddHealth(amt*-1) * -1
+}
+
 func (a ScriptActor) AddMana(amt int) int {
 	ret := a.characterRecord.ApplyManaChange(amt)
 
@@ -394,6 +403,42 @@ func (a ScriptActor) MoveRoom(destRoomId int, leaveCharmedMobs ...bool) {
 	}
 }
 
+func (a ScriptActor) GetPathTo(destRoomId int) []string {
+
+	flags := rooms.PathFlag(0)
+	if a.mobRecord != nil {
+		flags = rooms.PathAllowSecret
+	}
+
+	exitNames := []string{}
+
+	path, err := rooms.FindPath(a.characterRecord.RoomId, destRoomId, flags)
+	if err != nil {
+		return exitNames
+	}
+
+	for _, step := range path {
+		exitNames = append(exitNames, step.ExitName)
+	}
+
+	return exitNames
+}
+
+func (a ScriptActor) PathTo(destRoomId int) bool {
+
+	if a.mobRecord == nil {
+		return false
+	}
+
+	if _, err := rooms.FindPath(a.characterRecord.RoomId, destRoomId, rooms.PathAllowSecret); err != nil {
+		return false
+	}
+
+	a.mobRecord.Command(fmt.Sprintf(`pathto %d`, destRoomId))
+
+	return true
+}
+
 func (a ScriptActor) UpdateItem(itm ScriptItem) {
 	a.userRecord.Character.UpdateItem(itm.originalItem, *itm.itemRecord)
 }
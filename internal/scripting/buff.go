@@ -3,6 +3,7 @@ package scripting
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
@@ -231,6 +232,11 @@ func getBuffVM(buffId int) (*VMWrapper, error) {
 
 	vmw := newVMWrapper(vm, 0)
 
+	vmw.setSource(`buff`, strconv.Itoa(buffId), func() error {
+		_, err := getBuffVM(buffId)
+		return err
+	})
+
 	buffVMCache[buffId] = vmw
 
 	return vmw, nil
@@ -1,30 +1,62 @@
 package scripting
 
 import (
+	"fmt"
+
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/dop251/goja"
 )
 
-type console struct{}
+const (
+	maxConsoleHistory = 20
+)
+
+var (
+	consoleListeners = map[int]struct{}{} // userIds following console output live
+	consoleHistory   = []string{}
+
+	consoleLevelColors = map[string]string{
+		`debug`: `13`,
+		`info`:  `2`,
+		`log`:   `2`,
+		`warn`:  `11`,
+		`error`: `1`,
+	}
+)
+
+type console struct {
+	vm *goja.Runtime
+}
 
 func (c *console) log(msg any) {
-	mudlog.Info(`JSVM`, `msg`, msg)
+	mudlog.Info(`JSVM`, `script`, c.source(), `msg`, msg)
+	consoleOutput(`log`, c.source(), msg)
 }
 func (c *console) info(msg any) {
-	mudlog.Info(`JSVM`, `msg`, msg)
+	mudlog.Info(`JSVM`, `script`, c.source(), `msg`, msg)
+	consoleOutput(`info`, c.source(), msg)
 }
 func (c *console) debug(msg any) {
-	mudlog.Debug(`JSVM`, `msg`, msg)
+	mudlog.Debug(`JSVM`, `script`, c.source(), `msg`, msg)
+	consoleOutput(`debug`, c.source(), msg)
 }
 func (c *console) warn(msg any) {
-	mudlog.Warn(`JSVM`, `msg`, msg)
+	mudlog.Warn(`JSVM`, `script`, c.source(), `msg`, msg)
+	consoleOutput(`warn`, c.source(), msg)
 }
 func (c *console) error(msg any) {
-	mudlog.Error(`JSVM`, `msg`, msg)
+	mudlog.Error(`JSVM`, `script`, c.source(), `msg`, msg)
+	consoleOutput(`error`, c.source(), msg)
+}
+
+// The name of the script doing the logging, such as room-1 or mob-2-guard
+func (c *console) source() string {
+	return callingScriptName(c.vm)
 }
 
 func newConsole(vm *goja.Runtime) *goja.Object {
-	c := &console{}
+	c := &console{vm: vm}
 	obj := vm.NewObject()
 	obj.Set(`log`, c.log)
 	obj.Set(`info`, c.info)
@@ -33,3 +65,39 @@ func newConsole(vm *goja.Runtime) *goja.Object {
 	obj.Set(`error`, c.error)
 	return obj
 }
+
+// Keeps a short history of console output, and sends it to anyone following along
+func consoleOutput(level string, source string, msg any) {
+
+	line := fmt.Sprintf(`<ansi fg="black-bold">[%s]</ansi> <ansi fg="%s">%s</ansi> %v`, source, consoleLevelColors[level], level, msg)
+
+	consoleHistory = append(consoleHistory, line)
+	if len(consoleHistory) > maxConsoleHistory {
+		consoleHistory = consoleHistory[len(consoleHistory)-maxConsoleHistory:]
+	}
+
+	for userId := range consoleListeners {
+		if user := users.GetByUserId(userId); user != nil {
+			user.SendText(line)
+		}
+	}
+}
+
+// Starts or stops sending script console output to a user as it happens
+func SetConsoleListener(userId int, listen bool) {
+	if listen {
+		consoleListeners[userId] = struct{}{}
+	} else {
+		delete(consoleListeners, userId)
+	}
+}
+
+func IsConsoleListener(userId int) bool {
+	_, ok := consoleListeners[userId]
+	return ok
+}
+
+// Returns the most recent script console output, oldest first
+func GetConsoleHistory() []string {
+	return append([]string{}, consoleHistory...)
+}
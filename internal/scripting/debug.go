@@ -0,0 +1,114 @@
+package scripting
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+)
+
+// Details of a script currently loaded into a VM
+type ScriptInfo struct {
+	Type  string
+	Id    string
+	Stats ScriptStats
+}
+
+// Returns every script currently loaded, sorted by type and id
+func GetLoadedScripts() []ScriptInfo {
+
+	allVMs := []*VMWrapper{}
+
+	for _, vmw := range roomVMCache {
+		allVMs = append(allVMs, vmw)
+	}
+	for _, vmw := range mobVMCache {
+		allVMs = append(allVMs, vmw)
+	}
+	for _, vmw := range itemVMCache {
+		allVMs = append(allVMs, vmw)
+	}
+	for _, vmw := range buffVMCache {
+		allVMs = append(allVMs, vmw)
+	}
+	for _, vmw := range spellVMCache {
+		allVMs = append(allVMs, vmw)
+	}
+	for _, vmw := range recipeVMCache {
+		allVMs = append(allVMs, vmw)
+	}
+
+	loaded := []ScriptInfo{}
+	for _, vmw := range allVMs {
+		// nil entries are things known to have no script
+		if vmw == nil {
+			continue
+		}
+		loaded = append(loaded, ScriptInfo{Type: vmw.scriptType, Id: vmw.scriptId, Stats: vmw.stats})
+	}
+
+	sort.Slice(loaded, func(i, j int) bool {
+		if loaded[i].Type != loaded[j].Type {
+			return loaded[i].Type < loaded[j].Type
+		}
+		return loaded[i].Id < loaded[j].Id
+	})
+
+	return loaded
+}
+
+// Returns a loaded script along with a function that unloads it
+func findLoadedScript(scriptType string, scriptId string) (*VMWrapper, func()) {
+
+	switch scriptType {
+	case `room`, `buff`, `recipe`:
+
+		id, err := strconv.Atoi(scriptId)
+		if err != nil {
+			return nil, nil
+		}
+
+		if scriptType == `room` {
+			return roomVMCache[id], func() { delete(roomVMCache, id) }
+		} else if scriptType == `buff` {
+			return buffVMCache[id], func() { delete(buffVMCache, id) }
+		}
+		return recipeVMCache[id], func() { delete(recipeVMCache, id) }
+
+	case `mob`:
+		return mobVMCache[scriptId], func() { delete(mobVMCache, scriptId) }
+	case `item`:
+		return itemVMCache[scriptId], func() { delete(itemVMCache, scriptId) }
+	case `spell`:
+		return spellVMCache[scriptId], func() { delete(spellVMCache, scriptId) }
+	}
+
+	return nil, nil
+}
+
+// Returns the details of a single loaded script
+func GetLoadedScript(scriptType string, scriptId string) (ScriptInfo, bool) {
+	vmw, _ := findLoadedScript(scriptType, scriptId)
+	if vmw == nil {
+		return ScriptInfo{}, false
+	}
+	return ScriptInfo{Type: vmw.scriptType, Id: vmw.scriptId, Stats: vmw.stats}, true
+}
+
+// Throws away a loaded script and loads it again from disk.
+// Any error compiling or running the new copy is returned, and it will be retried the next time the script is needed.
+func ReloadScript(scriptType string, scriptId string) error {
+
+	vmw, unload := findLoadedScript(scriptType, scriptId)
+	if vmw == nil {
+		return fmt.Errorf(`no %s script %s is loaded`, scriptType, scriptId)
+	}
+
+	unload()
+
+	if err := vmw.reload(); err != nil {
+		unload()
+		return err
+	}
+
+	return nil
+}
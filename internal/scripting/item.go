@@ -238,6 +238,11 @@ func getItemVM(sItem *ScriptItem) (*VMWrapper, error) {
 
 	vmw := newVMWrapper(vm, 0)
 
+	vmw.setSource(`item`, scriptId, func() error {
+		_, err := getItemVM(sItem)
+		return err
+	})
+
 	itemVMCache[scriptId] = vmw
 
 	return vmw, nil
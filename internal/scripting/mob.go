@@ -275,6 +275,11 @@ func getMobVM(mobActor *ScriptActor) (*VMWrapper, error) {
 
 	vmw := newVMWrapper(vm, 0)
 
+	vmw.setSource(`mob`, scriptId, func() error {
+		_, err := getMobVM(mobActor)
+		return err
+	})
+
 	mobVMCache[scriptId] = vmw
 
 	return vmw, nil
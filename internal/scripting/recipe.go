@@ -0,0 +1,150 @@
+package scripting
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/crafting"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/dop251/goja"
+)
+
+var (
+	recipeVMCache = make(map[int]*VMWrapper)
+)
+
+func ClearRecipeVMs() {
+	clear(recipeVMCache)
+}
+
+func PruneRecipeVMs(instanceIds ...int) {
+	// Do not prune, they don't get a VM per instance.
+}
+
+// Runs a recipe script event for a user and the item they crafted.
+// Any changes the script makes to the item are written back to it.
+func TryRecipeScriptEvent(eventName string, userId int, recipeId int, item *items.Item) (bool, error) {
+
+	vmw, err := getRecipeVM(recipeId)
+	if err != nil {
+		return false, err
+	}
+
+	timestart := time.Now()
+	defer func() {
+		mudlog.Debug("TryRecipeScriptEvent()", "eventName", eventName, "recipeId", recipeId, "time", time.Since(timestart))
+	}()
+
+	if onCommandFunc, ok := vmw.GetFunction(eventName); ok {
+
+		sUser := GetActor(userId, 0)
+		sItem := GetItem(*item)
+		sRoom := GetRoom(sUser.GetRoomId())
+
+		tmr := time.AfterFunc(scriptItemTimeout, func() {
+			vmw.VM.Interrupt(errTimeout)
+		})
+		res, err := onCommandFunc(goja.Undefined(),
+			vmw.VM.ToValue(sUser),
+			vmw.VM.ToValue(sItem),
+			vmw.VM.ToValue(sRoom),
+		)
+		vmw.VM.ClearInterrupt()
+		tmr.Stop()
+
+		if err != nil {
+
+			// Wrap the error
+			finalErr := fmt.Errorf("%s(): %w", eventName, err)
+
+			if _, ok := finalErr.(*goja.Exception); ok {
+				mudlog.Error("JSVM", "exception", finalErr)
+				return false, finalErr
+			} else if errors.Is(finalErr, errTimeout) {
+				mudlog.Error("JSVM", "interrupted", finalErr)
+				return false, finalErr
+			}
+
+			mudlog.Error("JSVM", "error", finalErr)
+			return false, finalErr
+		}
+
+		// Keep any changes made to the item
+		*item = *sItem.itemRecord
+
+		if boolVal, ok := res.Export().(bool); ok {
+			return boolVal, nil
+		}
+	}
+
+	return false, ErrEventNotFound
+}
+
+func getRecipeVM(recipeId int) (*VMWrapper, error) {
+
+	if vm, ok := recipeVMCache[recipeId]; ok {
+		if vm == nil {
+			return nil, errNoScript
+		}
+		return vm, nil
+	}
+
+	recipe := crafting.GetRecipe(recipeId)
+	if recipe == nil {
+		return nil, fmt.Errorf("recipe not found: %d", recipeId)
+	}
+
+	script := recipe.GetScript()
+	if len(script) == 0 {
+		recipeVMCache[recipeId] = nil
+		return nil, errNoScript
+	}
+
+	vm := goja.New()
+	setAllScriptingFunctions(vm)
+
+	prg, err := goja.Compile(fmt.Sprintf(`recipe-%d`, recipeId), script, false)
+	if err != nil {
+		finalErr := fmt.Errorf("Compile: %w", err)
+		return nil, finalErr
+	}
+
+	//
+	// Run the program
+	//
+	tmr := time.AfterFunc(scriptLoadTimeout, func() {
+		vm.Interrupt(errTimeout)
+	})
+	if _, err = vm.RunProgram(prg); err != nil {
+
+		// Wrap the error
+		finalErr := fmt.Errorf("RunProgram: %w", err)
+
+		if _, ok := finalErr.(*goja.Exception); ok {
+			mudlog.Error("JSVM", "exception", finalErr)
+			return nil, finalErr
+		} else if errors.Is(finalErr, errTimeout) {
+			mudlog.Error("JSVM", "interrupted", finalErr)
+			return nil, finalErr
+		}
+
+		mudlog.Error("JSVM", "error", finalErr)
+		return nil, finalErr
+	}
+	vm.ClearInterrupt()
+	tmr.Stop()
+
+	vmw := newVMWrapper(vm, 0)
+
+	vmw.setSource(`recipe`, strconv.Itoa(recipeId), func() error {
+		_, err := getRecipeVM(recipeId)
+		return err
+	})
+
+	recipeVMCache[recipeId] = vmw
+
+	return vmw, nil
+}
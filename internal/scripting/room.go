@@ -3,6 +3,7 @@ package scripting
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
@@ -371,6 +372,11 @@ func getRoomVM(roomId int) (*VMWrapper, error) {
 
 	vmw := newVMWrapper(vm, 0)
 
+	vmw.setSource(`room`, strconv.Itoa(roomId), func() error {
+		_, err := getRoomVM(roomId)
+		return err
+	})
+
 	roomVMCache[roomId] = vmw
 
 	return vmw, nil
@@ -95,6 +95,7 @@ func Setup(scriptLoadTimeoutMs int, scriptRoomTimeoutMs int) {
 
 func setAllScriptingFunctions(vm *goja.Runtime) {
 	setMessagingFunctions(vm)
+	setStoreFunctions(vm)
 	setRoomFunctions(vm)
 	setActorFunctions(vm)
 	setSpellFunctions(vm)
@@ -102,6 +103,17 @@ func setAllScriptingFunctions(vm *goja.Runtime) {
 	setUtilFunctions(vm)
 }
 
+// Returns the name of the script that called into Go, such as room-1 or mob-2-guard
+func callingScriptName(vm *goja.Runtime) string {
+	// The innermost frames belong to native functions, so look for the first script frame
+	for _, frame := range vm.CaptureCallStack(3, nil) {
+		if name := frame.SrcName(); name != `<native>` {
+			return name
+		}
+	}
+	return `unknown`
+}
+
 func PruneVMs(forceClear ...bool) {
 
 	if len(forceClear) > 0 && forceClear[0] {
@@ -110,12 +122,14 @@ func PruneVMs(forceClear ...bool) {
 		ClearBuffVMs()
 		ClearItemVMs()
 		ClearSpellVMs()
+		ClearRecipeVMs()
 	} else {
 		PruneRoomVMs()
 		PruneMobVMs()
 		PruneBuffVMs()
 		PruneItemVMs()
 		PruneSpellVMs()
+		PruneRecipeVMs()
 	}
 
 }
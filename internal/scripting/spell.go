@@ -120,6 +120,7 @@ func TrySpellScriptEvent(eventName string, sourceUserId int, sourceMobInstanceId
 		res, err := onCommandFunc(goja.Undefined(),
 			vmw.VM.ToValue(sourceActor),
 			vmw.VM.ToValue(argValue),
+			vmw.VM.ToValue(string(spellInfo.Element)),
 		)
 		vmw.VM.ClearInterrupt()
 		tmr.Stop()
@@ -157,7 +158,7 @@ func TrySpellScriptEvent(eventName string, sourceUserId int, sourceMobInstanceId
 
 func getSpellVM(scriptId string) (*VMWrapper, error) {
 
-	if vm, ok := itemVMCache[scriptId]; ok {
+	if vm, ok := spellVMCache[scriptId]; ok {
 		if vm == nil {
 			return nil, errNoScript
 		}
@@ -171,7 +172,7 @@ func getSpellVM(scriptId string) (*VMWrapper, error) {
 
 	script := spellData.GetScript()
 	if len(script) == 0 {
-		itemVMCache[scriptId] = nil
+		spellVMCache[scriptId] = nil
 		return nil, errNoScript
 	}
 
@@ -211,7 +212,12 @@ func getSpellVM(scriptId string) (*VMWrapper, error) {
 
 	vmw := newVMWrapper(vm, 0)
 
-	itemVMCache[scriptId] = vmw
+	vmw.setSource(`spell`, scriptId, func() error {
+		_, err := getSpellVM(scriptId)
+		return err
+	})
+
+	spellVMCache[scriptId] = vmw
 
 	return vmw, nil
 }
@@ -0,0 +1,436 @@
+package scripting
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/dop251/goja"
+)
+
+const (
+	storeFilename = `script-store.yaml`
+
+	// Keeps a runaway script from filling up the store
+	maxStoreKeys         = 500
+	maxStoreStringLength = 4096
+
+	storeTypeInt    = `int`
+	storeTypeFloat  = `float`
+	storeTypeString = `string`
+	storeTypeBool   = `bool`
+)
+
+var (
+	scriptStoreLock sync.Mutex
+	scriptStoreData = &scriptStore{Namespaces: map[string]map[string]*storeEntry{}}
+)
+
+// A single stored value. The type is saved alongside it, so that numbers come back
+// from disk exactly as they went in (an int stays an int, a float stays a float).
+type storeEntry struct {
+	Type    string `yaml:"type"`
+	Value   any    `yaml:"value"`
+	Expires int64  `yaml:"expires,omitempty"` // Unix timestamp, or zero to never expire
+}
+
+func (e *storeEntry) expired(now time.Time) bool {
+	return e.Expires > 0 && now.Unix() >= e.Expires
+}
+
+// Values for every script, keyed by the script name (room-1, mob-2-guard, etc.)
+type scriptStore struct {
+	Namespaces map[string]map[string]*storeEntry `yaml:"namespaces"`
+}
+
+func (s *scriptStore) Filepath() string {
+	return storeFilename
+}
+
+func (s *scriptStore) Validate() error {
+
+	if s.Namespaces == nil {
+		s.Namespaces = map[string]map[string]*storeEntry{}
+	}
+
+	// yaml decodes numbers as whatever fits, so put them back to the type they were saved as
+	for namespace, values := range s.Namespaces {
+		for key, entry := range values {
+
+			if entry == nil {
+				delete(values, key)
+				continue
+			}
+
+			value, ok := convertStoreValue(entry.Type, entry.Value)
+			if !ok {
+				mudlog.Warn("scriptStore.Validate()", "namespace", namespace, "key", key, "error", "invalid value for type "+entry.Type)
+				delete(values, key)
+				continue
+			}
+			entry.Value = value
+		}
+	}
+
+	return nil
+}
+
+// Converts a value to the Go type used for a store type
+func convertStoreValue(storeType string, value any) (any, bool) {
+
+	switch storeType {
+	case storeTypeInt:
+		switch v := value.(type) {
+		case int:
+			return int64(v), true
+		case int64:
+			return v, true
+		case float64:
+			if v == math.Trunc(v) {
+				return int64(v), true
+			}
+		}
+	case storeTypeFloat:
+		switch v := value.(type) {
+		case int:
+			return float64(v), true
+		case int64:
+			return float64(v), true
+		case float64:
+			return v, true
+		}
+	case storeTypeString:
+		if v, ok := value.(string); ok {
+			return v, true
+		}
+	case storeTypeBool:
+		if v, ok := value.(bool); ok {
+			return v, true
+		}
+	}
+
+	return nil, false
+}
+
+// Works out the store type of a value handed over from a script
+func newStoreEntry(value any) (*storeEntry, bool) {
+
+	switch v := value.(type) {
+	case int64:
+		return &storeEntry{Type: storeTypeInt, Value: v}, true
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, false
+		}
+		return &storeEntry{Type: storeTypeFloat, Value: v}, true
+	case string:
+		if len(v) > maxStoreStringLength {
+			return nil, false
+		}
+		return &storeEntry{Type: storeTypeString, Value: v}, true
+	case bool:
+		return &storeEntry{Type: storeTypeBool, Value: v}, true
+	}
+
+	return nil, false
+}
+
+func scriptStorePath() string {
+	return util.FilePath(configs.GetFilePathsConfig().DataFiles.String(), `/`, storeFilename)
+}
+
+// Loads script store values saved from a previous run
+func LoadScriptStore() {
+
+	start := time.Now()
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	scriptStoreData = &scriptStore{Namespaces: map[string]map[string]*storeEntry{}}
+
+	if _, err := os.Stat(scriptStorePath()); os.IsNotExist(err) {
+		mudlog.Info("scripting.LoadScriptStore()", "loadedCount", 0, "Time Taken", time.Since(start))
+		return
+	}
+
+	loaded, err := fileloader.LoadFlatFile[*scriptStore](scriptStorePath())
+	if err != nil {
+		mudlog.Error("scripting.LoadScriptStore()", "error", err.Error())
+		return
+	}
+
+	scriptStoreData = loaded
+
+	mudlog.Info("scripting.LoadScriptStore()", "loadedCount", len(scriptStoreData.Namespaces), "Time Taken", time.Since(start))
+}
+
+// Saves all script store values, dropping any that have expired
+func SaveScriptStore() error {
+
+	start := time.Now()
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	now := time.Now()
+	for namespace, values := range scriptStoreData.Namespaces {
+		for key, entry := range values {
+			if entry.expired(now) {
+				delete(values, key)
+			}
+		}
+		if len(values) == 0 {
+			delete(scriptStoreData.Namespaces, namespace)
+		}
+	}
+
+	saveModes := []fileloader.SaveOption{}
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	err := fileloader.SaveFlatFile[*scriptStore](configs.GetFilePathsConfig().DataFiles.String(), scriptStoreData, saveModes...)
+
+	mudlog.Info("SaveScriptStore()", "savedCount", len(scriptStoreData.Namespaces), "Time Taken", time.Since(start))
+
+	return err
+}
+
+// Returns the live entry for a key, clearing it out if it has expired
+// scriptStoreLock must be held
+func getStoreEntry(namespace string, key string) *storeEntry {
+
+	values, ok := scriptStoreData.Namespaces[namespace]
+	if !ok {
+		return nil
+	}
+
+	entry, ok := values[key]
+	if !ok {
+		return nil
+	}
+
+	if entry.expired(time.Now()) {
+		delete(values, key)
+		return nil
+	}
+
+	return entry
+}
+
+// scriptStoreLock must be held
+func setStoreEntry(namespace string, key string, entry *storeEntry, ttlSeconds int) bool {
+
+	values, ok := scriptStoreData.Namespaces[namespace]
+	if !ok {
+		values = map[string]*storeEntry{}
+		scriptStoreData.Namespaces[namespace] = values
+	}
+
+	if _, exists := values[key]; !exists && len(values) >= maxStoreKeys {
+		return false
+	}
+
+	if ttlSeconds > 0 {
+		entry.Expires = time.Now().Add(time.Duration(ttlSeconds) * time.Second).Unix()
+	}
+
+	values[key] = entry
+
+	return true
+}
+
+// The Store object given to scripts.
+// Every script gets its own namespace, so scripts can't see or overwrite each other's values.
+type scriptStoreApi struct {
+	vm *goja.Runtime
+}
+
+func (s *scriptStoreApi) namespace() string {
+	return callingScriptName(s.vm)
+}
+
+// Returns the stored value, or defaultValue (or null) if there isn't one
+func (s *scriptStoreApi) Get(key string, defaultValue ...goja.Value) goja.Value {
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	if entry := getStoreEntry(s.namespace(), key); entry != nil {
+		return s.vm.ToValue(entry.Value)
+	}
+
+	if len(defaultValue) > 0 {
+		return defaultValue[0]
+	}
+
+	return goja.Null()
+}
+
+// Stores a number, string or boolean. An optional ttlSeconds makes the value expire.
+// Setting null or undefined removes the key.
+func (s *scriptStoreApi) Set(key string, value goja.Value, ttlSeconds ...int) bool {
+
+	if goja.IsNull(value) || goja.IsUndefined(value) {
+		s.Delete(key)
+		return true
+	}
+
+	namespace := s.namespace()
+
+	entry, ok := newStoreEntry(value.Export())
+	if !ok {
+		consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Set(): %s must be a number, string (up to %d characters) or boolean`, key, maxStoreStringLength))
+		return false
+	}
+
+	ttl := 0
+	if len(ttlSeconds) > 0 {
+		ttl = ttlSeconds[0]
+	}
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	if !setStoreEntry(namespace, key, entry, ttl) {
+		consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Set(): %s not stored, the limit of %d keys has been reached`, key, maxStoreKeys))
+		return false
+	}
+
+	return true
+}
+
+// Adds to a number and returns the new value, starting from zero if it isn't set yet.
+// ttlSeconds only applies when the value is first created, so counters can reset after a while.
+// Returns null if the existing value isn't a number.
+func (s *scriptStoreApi) Increment(key string, amount goja.Value, ttlSeconds ...int) goja.Value {
+
+	namespace := s.namespace()
+
+	add := any(int64(1))
+	if amount != nil && !goja.IsUndefined(amount) && !goja.IsNull(amount) {
+		add = amount.Export()
+	}
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	entry := getStoreEntry(namespace, key)
+	if entry == nil {
+
+		newEntry, ok := newStoreEntry(add)
+		if !ok || newEntry.Type == storeTypeString || newEntry.Type == storeTypeBool {
+			consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Increment(): %v is not a number`, add))
+			return goja.Null()
+		}
+
+		ttl := 0
+		if len(ttlSeconds) > 0 {
+			ttl = ttlSeconds[0]
+		}
+
+		if !setStoreEntry(namespace, key, newEntry, ttl) {
+			consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Increment(): %s not stored, the limit of %d keys has been reached`, key, maxStoreKeys))
+			return goja.Null()
+		}
+
+		return s.vm.ToValue(newEntry.Value)
+	}
+
+	switch current := entry.Value.(type) {
+	case int64:
+		switch a := add.(type) {
+		case int64:
+			entry.Value = current + a
+		case float64:
+			entry.Type = storeTypeFloat
+			entry.Value = float64(current) + a
+		default:
+			consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Increment(): %v is not a number`, add))
+			return goja.Null()
+		}
+	case float64:
+		switch a := add.(type) {
+		case int64:
+			entry.Value = current + float64(a)
+		case float64:
+			entry.Value = current + a
+		default:
+			consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Increment(): %v is not a number`, add))
+			return goja.Null()
+		}
+	default:
+		consoleOutput(`error`, namespace, fmt.Sprintf(`Store.Increment(): %s holds a %s, not a number`, key, entry.Type))
+		return goja.Null()
+	}
+
+	return s.vm.ToValue(entry.Value)
+}
+
+func (s *scriptStoreApi) Has(key string) bool {
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	return getStoreEntry(s.namespace(), key) != nil
+}
+
+func (s *scriptStoreApi) Delete(key string) {
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	if values, ok := scriptStoreData.Namespaces[s.namespace()]; ok {
+		delete(values, key)
+	}
+}
+
+// Returns all keys currently stored, sorted by name
+func (s *scriptStoreApi) Keys() []string {
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	namespace := s.namespace()
+
+	keys := []string{}
+	for key := range scriptStoreData.Namespaces[namespace] {
+		if getStoreEntry(namespace, key) != nil {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Returns how many seconds are left before a value expires.
+// Returns -1 if it never expires, or -2 if it isn't set.
+func (s *scriptStoreApi) GetTTL(key string) int {
+
+	scriptStoreLock.Lock()
+	defer scriptStoreLock.Unlock()
+
+	entry := getStoreEntry(s.namespace(), key)
+	if entry == nil {
+		return -2
+	}
+
+	if entry.Expires == 0 {
+		return -1
+	}
+
+	return int(entry.Expires - time.Now().Unix())
+}
+
+func setStoreFunctions(vm *goja.Runtime) {
+	vm.Set(`Store`, &scriptStoreApi{vm: vm})
+}
@@ -0,0 +1,79 @@
+package scripting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dop251/goja"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+// Runs a script under a name, the same way rooms/mobs/etc. compile theirs
+func runStoreScript(t *testing.T, name string, script string) goja.Value {
+	vm := goja.New()
+	setStoreFunctions(vm)
+
+	prg, err := goja.Compile(name, script, false)
+	assert.NoError(t, err)
+
+	val, err := vm.RunProgram(prg)
+	assert.NoError(t, err)
+
+	return val
+}
+
+func TestScriptStore(t *testing.T) {
+
+	scriptStoreData = &scriptStore{Namespaces: map[string]map[string]*storeEntry{}}
+
+	runStoreScript(t, `room-1`, `Store.Set("visits", 5); Store.Set("name", "Frostfang"); Store.Set("ratio", 1.5)`)
+
+	assert.Equal(t, int64(6), runStoreScript(t, `room-1`, `Store.Increment("visits")`).Export())
+	assert.Equal(t, int64(16), runStoreScript(t, `room-1`, `Store.Increment("visits", 10)`).Export())
+	assert.Equal(t, float64(2), runStoreScript(t, `room-1`, `Store.Increment("ratio", 0.5)`).ToFloat())
+	assert.Equal(t, storeTypeFloat, scriptStoreData.Namespaces[`room-1`][`ratio`].Type)
+	assert.Nil(t, runStoreScript(t, `room-1`, `Store.Increment("name")`).Export(), "Expected strings not to increment")
+	assert.Equal(t, `Frostfang`, runStoreScript(t, `room-1`, `Store.Get("name")`).Export())
+
+	// Other scripts have their own namespace
+	assert.Equal(t, false, runStoreScript(t, `room-2`, `Store.Has("visits")`).Export())
+	assert.Equal(t, `none`, runStoreScript(t, `room-2`, `Store.Get("name", "none")`).Export())
+	assert.Equal(t, int64(1), runStoreScript(t, `room-2`, `Store.Increment("visits")`).Export())
+
+	assert.Equal(t, false, runStoreScript(t, `room-1`, `Store.Set("list", [1, 2])`).Export(), "Expected only simple types to be stored")
+	assert.Equal(t, []string{`name`, `ratio`, `visits`}, runStoreScript(t, `room-1`, `Store.Keys()`).Export())
+
+	runStoreScript(t, `room-1`, `Store.Set("name", null)`)
+	assert.Equal(t, false, runStoreScript(t, `room-1`, `Store.Has("name")`).Export())
+
+	// Expiry
+	assert.Equal(t, int64(-1), runStoreScript(t, `room-1`, `Store.GetTTL("visits")`).Export())
+	assert.Equal(t, int64(-2), runStoreScript(t, `room-1`, `Store.GetTTL("missing")`).Export())
+
+	runStoreScript(t, `room-1`, `Store.Set("event", true, 60)`)
+	assert.InDelta(t, 60, runStoreScript(t, `room-1`, `Store.GetTTL("event")`).ToInteger(), 1)
+
+	scriptStoreData.Namespaces[`room-1`][`event`].Expires = time.Now().Add(-time.Second).Unix()
+	assert.Equal(t, false, runStoreScript(t, `room-1`, `Store.Has("event")`).Export(), "Expected expired values to be gone")
+}
+
+func TestScriptStoreTypesSurviveSaving(t *testing.T) {
+
+	saved := &scriptStore{Namespaces: map[string]map[string]*storeEntry{
+		`mob-1-guard`: {
+			`count`: {Type: storeTypeInt, Value: int64(3)},
+			`speed`: {Type: storeTypeFloat, Value: float64(2)},
+			`angry`: {Type: storeTypeBool, Value: true},
+		},
+	}}
+
+	bytes, err := yaml.Marshal(saved)
+	assert.NoError(t, err)
+
+	loaded := &scriptStore{}
+	assert.NoError(t, yaml.Unmarshal(bytes, loaded))
+	assert.NoError(t, loaded.Validate())
+
+	assert.Equal(t, saved, loaded)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/gametime"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
 	"github.com/dop251/goja"
@@ -39,6 +40,7 @@ func setUtilFunctions(vm *goja.Runtime) {
 	vm.Set(`UtilSetTimeDay`, UtilSetTimeDay)
 	vm.Set(`UtilSetTimeNight`, UtilSetTimeNight)
 	vm.Set(`UtilIsDay`, UtilIsDay)
+	vm.Set(`UtilGetWeather`, UtilGetWeather)
 	vm.Set(`UtilLocateUser`, UtilLocateUser)
 	vm.Set(`UtilApplyColorPattern`, UtilApplyColorPattern)
 	vm.Set(`UtilGetConfig`, UtilGetConfig)
@@ -137,6 +139,16 @@ func UtilIsDay() bool {
 	return !gametime.IsNight()
 }
 
+// Returns the weatherId in the room, or an empty string if it is indoors or has no weather
+func UtilGetWeather(roomId int) string {
+	if room := rooms.LoadRoom(roomId); room != nil {
+		if w := room.GetWeather(); w != nil {
+			return w.WeatherId
+		}
+	}
+	return ``
+}
+
 func UtilLocateUser(idOrName any) int {
 
 	// check if is string
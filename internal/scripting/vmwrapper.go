@@ -1,6 +1,10 @@
 package scripting
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/dop251/goja"
 )
 
@@ -9,12 +13,45 @@ type VMWrapper struct {
 	callableCache map[string]goja.Callable
 	cacheSize     int
 	maxCacheSize  int
+	scriptType    string       // room, mob, item, buff, spell or recipe
+	scriptId      string       // roomId, mobId-scripttag, itemId etc.
+	reload        func() error // Loads a fresh copy of the script from disk
+	stats         ScriptStats
+}
+
+// Execution stats of a loaded script
+type ScriptStats struct {
+	Calls     int
+	Errors    int
+	Timeouts  int
+	TotalTime time.Duration
+	MaxTime   time.Duration
+	LastError string
+}
+
+func (s ScriptStats) AverageTime() time.Duration {
+	if s.Calls == 0 {
+		return 0
+	}
+	return s.TotalTime / time.Duration(s.Calls)
 }
 
 func newVMWrapper(vm *goja.Runtime, cacheSize int) *VMWrapper {
 	return &VMWrapper{VM: vm, callableCache: make(map[string]goja.Callable, cacheSize), maxCacheSize: cacheSize}
 }
 
+// Records where the script came from, so it can be found and reloaded later
+func (vmw *VMWrapper) setSource(scriptType string, scriptId string, reload func() error) {
+	vmw.scriptType = scriptType
+	vmw.scriptId = scriptId
+	vmw.reload = reload
+}
+
+// The name the script was compiled under, such as room-1 or mob-2-guard
+func (vmw *VMWrapper) Name() string {
+	return vmw.scriptType + `-` + vmw.scriptId
+}
+
 func (vmw *VMWrapper) GetFunction(name string) (goja.Callable, bool) {
 
 	fn, ok := vmw.callableCache[name]
@@ -24,6 +61,9 @@ func (vmw *VMWrapper) GetFunction(name string) (goja.Callable, bool) {
 	}
 
 	fn, ok = goja.AssertFunction(vmw.VM.Get(name))
+	if ok {
+		fn = vmw.trackCalls(name, fn)
+	}
 
 	if vmw.maxCacheSize == 0 || vmw.cacheSize < vmw.maxCacheSize {
 		vmw.cacheSize++
@@ -32,3 +72,33 @@ func (vmw *VMWrapper) GetFunction(name string) (goja.Callable, bool) {
 
 	return fn, ok
 }
+
+// Wraps a script function so that every call to it is timed, and any failure is recorded
+func (vmw *VMWrapper) trackCalls(name string, fn goja.Callable) goja.Callable {
+	return func(this goja.Value, args ...goja.Value) (goja.Value, error) {
+
+		start := time.Now()
+		res, err := fn(this, args...)
+		elapsed := time.Since(start)
+
+		vmw.stats.Calls++
+		vmw.stats.TotalTime += elapsed
+		if elapsed > vmw.stats.MaxTime {
+			vmw.stats.MaxTime = elapsed
+		}
+
+		if err != nil {
+
+			if errors.Is(err, errTimeout) {
+				vmw.stats.Timeouts++
+			} else {
+				vmw.stats.Errors++
+			}
+
+			vmw.stats.LastError = fmt.Sprintf(`%s(): %s`, name, err)
+			consoleOutput(`error`, vmw.Name(), vmw.stats.LastError)
+		}
+
+		return res, err
+	}
+}
@@ -33,7 +33,7 @@ const (
 	Scribe      SkillTag = `scribe`      // [LVL 1-4] Dark Acolyte's Chamber - ROOM 160
 	Protection  SkillTag = `protection`  // TODO
 	Tame        SkillTag = `tame`        // [LVL 1-4] Give mushroom to fairie in ROOM 558, train in ROOM 830
-	Trading     SkillTag = `trading`     // TODO
+	Trading     SkillTag = `trading`     // [LVL 1-4] Bank of Frostfang - ROOM 166
 )
 
 var (
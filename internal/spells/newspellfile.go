@@ -2,6 +2,7 @@ package spells
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -13,6 +14,10 @@ import (
 
 func CreateNewSpellFile(newSpellInfo SpellData) (string, error) {
 
+	if err := ValidateSpellId(newSpellInfo.SpellId); err != nil {
+		return ``, err
+	}
+
 	if sp := GetSpell(newSpellInfo.SpellId); sp != nil {
 		return ``, errors.New(`Spell already exists.`)
 	}
@@ -42,3 +47,55 @@ func CreateNewSpellFile(newSpellInfo SpellData) (string, error) {
 
 	return newSpellInfo.SpellId, nil
 }
+
+// Replaces an existing spell, saving it to its file and updating the in-memory cache.
+func SaveSpell(spellInfo SpellData) error {
+
+	if err := ValidateSpellId(spellInfo.SpellId); err != nil {
+		return err
+	}
+
+	if sp := GetSpell(spellInfo.SpellId); sp == nil {
+		return fmt.Errorf(`spell %s does not exist`, spellInfo.SpellId)
+	}
+
+	if err := spellInfo.Validate(); err != nil {
+		return err
+	}
+
+	saveModes := []fileloader.SaveOption{}
+
+	if configs.GetFilePathsConfig().CarefulSaveFiles {
+		saveModes = append(saveModes, fileloader.SaveCareful)
+	}
+
+	if err := fileloader.SaveFlatFile[*SpellData](string(configs.GetFilePathsConfig().DataFiles)+`/spells`, &spellInfo, saveModes...); err != nil {
+		return err
+	}
+
+	allSpells[spellInfo.Id()] = &spellInfo
+
+	return nil
+}
+
+// Removes a spell (and its script) from disk and memory.
+// Anyone who knows the spell will no longer be able to cast it.
+func DeleteSpell(spellId string) error {
+
+	spellInfo := GetSpell(spellId)
+	if spellInfo == nil {
+		return fmt.Errorf(`spell %s does not exist`, spellId)
+	}
+
+	if err := fileloader.DeleteFlatFile[*SpellData](string(configs.GetFilePathsConfig().DataFiles)+`/spells`, spellInfo); err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(spellInfo.GetScriptPath()); err == nil {
+		os.Remove(spellInfo.GetScriptPath())
+	}
+
+	delete(allSpells, spellId)
+
+	return nil
+}
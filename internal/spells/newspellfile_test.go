@@ -0,0 +1,113 @@
+package spells
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Points the data files at an empty directory and starts with no spells loaded
+func setupSpellFiles(t *testing.T) string {
+
+	dataDir := t.TempDir()
+	require.NoError(t, configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: dataDir}))
+
+	savedSpells := allSpells
+	allSpells = map[string]*SpellData{}
+
+	t.Cleanup(func() {
+		allSpells = savedSpells
+		configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: `_datafiles/world/default`})
+	})
+
+	return dataDir
+}
+
+func TestValidateSpellId(t *testing.T) {
+
+	tests := []struct {
+		spellId string
+		valid   bool
+	}{
+		{`sparks`, true},
+		{`fire_bolt`, true},
+		{`ice-lance2`, true},
+		{``, false},
+		{`Sparks`, false},
+		{`fire bolt`, false},
+		{`../../x`, false},
+		{`spells/sparks`, false},
+		{`sparks.yaml`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spellId, func(t *testing.T) {
+			err := ValidateSpellId(tt.spellId)
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrInvalidSpellId)
+			}
+		})
+	}
+}
+
+func TestCreateNewSpellFile(t *testing.T) {
+
+	dataDir := setupSpellFiles(t)
+
+	spellId, err := CreateNewSpellFile(SpellData{SpellId: `zap`, Name: `Zap`, Type: HarmSingle})
+	require.NoError(t, err)
+	assert.Equal(t, `zap`, spellId)
+	assert.FileExists(t, filepath.Join(dataDir, `spells`, `zap.yaml`))
+	assert.NotNil(t, GetSpell(`zap`))
+
+	_, err = CreateNewSpellFile(SpellData{SpellId: `zap`, Name: `Zap Again`, Type: HarmSingle})
+	assert.Error(t, err, "Expected spell ids to be unique")
+
+	_, err = CreateNewSpellFile(SpellData{SpellId: `../../escape`, Name: `Escape`, Type: HarmSingle})
+	assert.ErrorIs(t, err, ErrInvalidSpellId)
+	assert.NoFileExists(t, filepath.Join(dataDir, `..`, `escape.yaml`), "Expected nothing to be written outside of the spells folder")
+	assert.Nil(t, GetSpell(`../../escape`))
+
+	_, err = CreateNewSpellFile(SpellData{SpellId: `bad`, Name: `Bad`, Type: HarmSingle, Element: `nonsense`})
+	assert.Error(t, err, "Expected the spell to be validated")
+	assert.NoFileExists(t, filepath.Join(dataDir, `spells`, `bad.yaml`))
+}
+
+func TestSaveSpell(t *testing.T) {
+
+	setupSpellFiles(t)
+
+	assert.Error(t, SaveSpell(SpellData{SpellId: `zap`, Name: `Zap`}), "Expected only existing spells to be saved")
+	assert.ErrorIs(t, SaveSpell(SpellData{SpellId: `../zap`, Name: `Zap`}), ErrInvalidSpellId)
+
+	_, err := CreateNewSpellFile(SpellData{SpellId: `zap`, Name: `Zap`, Type: HarmSingle, Cost: 5})
+	require.NoError(t, err)
+
+	require.NoError(t, SaveSpell(SpellData{SpellId: `zap`, Name: `Big Zap`, Type: HarmSingle, Cost: 10}))
+	assert.Equal(t, `Big Zap`, GetSpell(`zap`).Name)
+	assert.Equal(t, 10, GetSpell(`zap`).Cost)
+}
+
+func TestDeleteSpell(t *testing.T) {
+
+	dataDir := setupSpellFiles(t)
+
+	assert.Error(t, DeleteSpell(`zap`))
+
+	_, err := CreateNewSpellFile(SpellData{SpellId: `zap`, Name: `Zap`, Type: HarmSingle})
+	require.NoError(t, err)
+
+	scriptPath := GetSpell(`zap`).GetScriptPath()
+	require.NoError(t, os.WriteFile(scriptPath, []byte(`function onMagic() {}`), 0644))
+
+	require.NoError(t, DeleteSpell(`zap`))
+	assert.NoFileExists(t, filepath.Join(dataDir, `spells`, `zap.yaml`))
+	assert.NoFileExists(t, scriptPath, "Expected the script to be removed with the spell")
+	assert.Nil(t, GetSpell(`zap`))
+}
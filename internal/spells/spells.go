@@ -1,13 +1,16 @@
 package spells
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/items"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/util"
 )
@@ -16,14 +19,15 @@ type SpellType string
 type SpellSchool string
 
 type SpellData struct {
-	SpellId     string      `yaml:"spellid,omitempty"`
-	Name        string      `yaml:"name,omitempty"`
-	Description string      `yaml:"description,omitempty"`
-	Type        SpellType   `yaml:"type,omitempty"`
-	School      SpellSchool `yaml:"school,omitempty"`
-	Cost        int         `yaml:"cost,omitempty"`
-	WaitRounds  int         `yaml:"waitrounds,omitempty"`
-	Difficulty  int         `yaml:"difficulty,omitempty"` // Augments final success chance by this %
+	SpellId     string        `yaml:"spellid,omitempty"`
+	Name        string        `yaml:"name,omitempty"`
+	Description string        `yaml:"description,omitempty"`
+	Type        SpellType     `yaml:"type,omitempty"`
+	School      SpellSchool   `yaml:"school,omitempty"`
+	Cost        int           `yaml:"cost,omitempty"`
+	WaitRounds  int           `yaml:"waitrounds,omitempty"`
+	Difficulty  int           `yaml:"difficulty,omitempty"` // Augments final success chance by this %
+	Element     items.Element `yaml:"element,omitempty"`    // Elemental damage type, if any
 }
 
 const (
@@ -44,6 +48,11 @@ const (
 
 var (
 	allSpells = map[string]*SpellData{}
+
+	// Spell ids become file names, so they're kept to characters that are safe in one
+	spellIdRegex = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+	ErrInvalidSpellId = errors.New(`spell ids may only contain lowercase letters, numbers, dashes and underscores`)
 )
 
 func (s SpellType) HelpOrHarmString() string {
@@ -157,6 +166,22 @@ func (s *SpellData) Validate() error {
 		s.Difficulty = 100
 	}
 
+	if s.Element != `` {
+		elem := items.FindElement(string(s.Element))
+		if elem == `` {
+			return fmt.Errorf(`invalid element: %s`, s.Element)
+		}
+		s.Element = elem
+	}
+
+	return nil
+}
+
+// Whether the spell id can safely be used as a file name
+func ValidateSpellId(spellId string) error {
+	if !spellIdRegex.MatchString(spellId) {
+		return ErrInvalidSpellId
+	}
 	return nil
 }
 
@@ -22,6 +22,7 @@ var (
 	XPScale        StatName = `xpscale`        // Used for scaling xp after kills
 	HealthRecovery StatName = `healthrecovery` // Augments HP recovery speed
 	ManaRecovery   StatName = `manarecovery`   // Augments MP recovery speed
+	ResistPrefix   StatName = `resist-`        // followed by an element. % of that damage resisted, negative for a vulnerability
 
 	// Stat based
 	Strength   StatName = `strength`
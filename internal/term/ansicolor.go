@@ -0,0 +1,268 @@
+package term
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+//
+// Color downgrading for clients that cannot display everything the server renders.
+// Colors are rendered as xterm 256 color codes, and are converted to the nearest
+// match for clients that have reported lesser (or no) color support.
+//
+
+type ColorMode uint8
+
+const (
+	ColorModeUnknown   ColorMode = iota // Nothing reported, send colors unchanged
+	ColorModeNone                       // No color support
+	ColorMode16                         // Basic 8/16 ANSI colors
+	ColorMode256                        // xterm 256 colors
+	ColorModeTrueColor                  // 24 bit RGB colors
+)
+
+var (
+	// Default xterm values of the 16 basic colors
+	basicColors = [16][3]int{
+		{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+		{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+	}
+	// Channel values used by the 6x6x6 color cube (colors 16-231)
+	cubeLevels = [6]int{0, 95, 135, 175, 215, 255}
+)
+
+func (m ColorMode) String() string {
+	switch m {
+	case ColorModeNone:
+		return `none`
+	case ColorMode16:
+		return `16`
+	case ColorMode256:
+		return `256`
+	case ColorModeTrueColor:
+		return `truecolor`
+	}
+	return `unknown`
+}
+
+// Guesses color support from a terminal type name such as "XTERM-256COLOR"
+// Names that don't clearly indicate support return ColorModeUnknown
+func ColorModeFromTerminalType(ttype string) ColorMode {
+
+	ttype = strings.ToUpper(ttype)
+
+	switch {
+	case strings.Contains(ttype, `TRUECOLOR`), strings.Contains(ttype, `24BIT`), strings.Contains(ttype, `DIRECT`):
+		return ColorModeTrueColor
+	case strings.Contains(ttype, `256COLOR`):
+		return ColorMode256
+	case ttype == `DUMB`:
+		return ColorModeNone
+	case ttype == `ANSI`, strings.HasPrefix(ttype, `VT100`), strings.HasPrefix(ttype, `VT102`), strings.HasPrefix(ttype, `VT220`):
+		return ColorMode16
+	}
+
+	return ColorModeUnknown
+}
+
+// Rewrites ANSI color sequences (ESC[...m) so they can be displayed with the provided color mode
+func DowngradeColors(input []byte, mode ColorMode) []byte {
+
+	if mode == ColorModeUnknown || mode == ColorModeTrueColor {
+		return input
+	}
+
+	if bytes.IndexByte(input, ANSI_ESC) == -1 {
+		return input
+	}
+
+	out := make([]byte, 0, len(input))
+
+	for i := 0; i < len(input); i++ {
+
+		if input[i] != ANSI_ESC || i+1 >= len(input) || input[i+1] != '[' {
+			out = append(out, input[i])
+			continue
+		}
+
+		// Find the end of the sequence
+		end := i + 2
+		for end < len(input) && (input[end] == ';' || (input[end] >= '0' && input[end] <= '9')) {
+			end++
+		}
+
+		// Not a complete color sequence, leave it alone
+		if end >= len(input) || input[end] != 'm' {
+			out = append(out, input[i])
+			continue
+		}
+
+		paramStr := string(input[i+2 : end])
+		i = end
+
+		// ESC[m is a reset
+		if paramStr == `` {
+			out = append(out, ANSI_ESC, '[', 'm')
+			continue
+		}
+
+		params := downgradeParams(strings.Split(paramStr, `;`), mode)
+		if len(params) == 0 {
+			continue
+		}
+
+		out = append(out, ANSI_ESC, '[')
+		out = append(out, []byte(strings.Join(params, `;`))...)
+		out = append(out, 'm')
+	}
+
+	return out
+}
+
+func downgradeParams(params []string, mode ColorMode) []string {
+
+	result := make([]string, 0, len(params))
+
+	nums := make([]int, len(params))
+	for i, p := range params {
+		nums[i], _ = strconv.Atoi(p)
+	}
+
+	for i := 0; i < len(nums); i++ {
+
+		p := nums[i]
+
+		// Extended colors
+		if (p == 38 || p == 48) && i+1 < len(nums) {
+
+			fg := p == 38
+
+			// 256 color
+			if nums[i+1] == 5 && i+2 < len(nums) {
+				n := nums[i+2]
+				i += 2
+
+				switch mode {
+				case ColorMode256:
+					result = append(result, strconv.Itoa(p), `5`, strconv.Itoa(n))
+				case ColorMode16:
+					result = append(result, strconv.Itoa(basicColorCode(colorTo16(n), fg)))
+				}
+				continue
+			}
+
+			// Truecolor
+			if nums[i+1] == 2 && i+4 < len(nums) {
+				r, g, b := nums[i+2], nums[i+3], nums[i+4]
+				i += 4
+
+				switch mode {
+				case ColorMode256:
+					result = append(result, strconv.Itoa(p), `5`, strconv.Itoa(rgbTo256(r, g, b)))
+				case ColorMode16:
+					result = append(result, strconv.Itoa(basicColorCode(nearestBasicColor(r, g, b), fg)))
+				}
+				continue
+			}
+		}
+
+		// Basic colors
+		if mode == ColorModeNone && isBasicColorParam(p) {
+			continue
+		}
+
+		result = append(result, strconv.Itoa(p))
+	}
+
+	return result
+}
+
+func isBasicColorParam(p int) bool {
+	return (p >= 30 && p <= 39) || (p >= 40 && p <= 49) || (p >= 90 && p <= 97) || (p >= 100 && p <= 107)
+}
+
+// Returns the SGR code for one of the 16 basic colors
+func basicColorCode(idx int, fg bool) int {
+	base := 30
+	if idx >= 8 {
+		base = 90
+		idx -= 8
+	}
+	if !fg {
+		base += 10
+	}
+	return base + idx
+}
+
+// Converts an xterm 256 color to the closest of the 16 basic colors
+func colorTo16(n int) int {
+	if n < 0 || n > 255 {
+		return 7
+	}
+	if n < 16 {
+		return n
+	}
+	r, g, b := color256ToRGB(n)
+	return nearestBasicColor(r, g, b)
+}
+
+func color256ToRGB(n int) (r, g, b int) {
+	if n < 16 {
+		c := basicColors[n]
+		return c[0], c[1], c[2]
+	}
+	if n >= 232 {
+		v := 8 + 10*(n-232)
+		return v, v, v
+	}
+	n -= 16
+	return cubeLevels[n/36], cubeLevels[(n/6)%6], cubeLevels[n%6]
+}
+
+func nearestBasicColor(r, g, b int) int {
+	best, bestDist := 0, -1
+	for i, c := range basicColors {
+		if d := colorDistance(r, g, b, c[0], c[1], c[2]); bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+// Converts an RGB color to the closest xterm 256 color (cube or grayscale ramp)
+func rgbTo256(r, g, b int) int {
+
+	ri, gi, bi := nearestCubeLevel(r), nearestCubeLevel(g), nearestCubeLevel(b)
+	cube := 16 + 36*ri + 6*gi + bi
+	cubeDist := colorDistance(r, g, b, cubeLevels[ri], cubeLevels[gi], cubeLevels[bi])
+
+	avg := (r + g + b) / 3
+	grayIdx := (avg - 8 + 5) / 10
+	if grayIdx < 0 {
+		grayIdx = 0
+	} else if grayIdx > 23 {
+		grayIdx = 23
+	}
+	gv := 8 + 10*grayIdx
+
+	if colorDistance(r, g, b, gv, gv, gv) < cubeDist {
+		return 232 + grayIdx
+	}
+	return cube
+}
+
+func nearestCubeLevel(v int) int {
+	best, bestDist := 0, -1
+	for i, l := range cubeLevels {
+		d := (v - l) * (v - l)
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func colorDistance(r1, g1, b1, r2, g2, b2 int) int {
+	return (r1-r2)*(r1-r2) + (g1-g2)*(g1-g2) + (b1-b2)*(b1-b2)
+}
@@ -0,0 +1,46 @@
+package term
+
+import (
+	"testing"
+)
+
+func TestDowngradeColors(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		input    string
+		mode     ColorMode
+		expected string
+	}{
+		{"Unknown is unchanged", "\033[38;5;196mred\033[0m", ColorModeUnknown, "\033[38;5;196mred\033[0m"},
+		{"256 keeps 256", "\033[38;5;196mred\033[0m", ColorMode256, "\033[38;5;196mred\033[0m"},
+		{"256 to 16 fg", "\033[38;5;196mred\033[0m", ColorMode16, "\033[91mred\033[0m"},
+		{"256 to 16 bg", "\033[48;5;4mblue", ColorMode16, "\033[44mblue"},
+		{"256 gray to 16", "\033[38;5;232mdark", ColorMode16, "\033[30mdark"},
+		{"Truecolor to 256", "\033[38;2;255;0;0mred", ColorMode256, "\033[38;5;196mred"},
+		{"Truecolor to 16", "\033[1;38;2;0;0;0mblack", ColorMode16, "\033[1;30mblack"},
+		{"None strips colors", "\033[1;38;5;196mbold\033[0m", ColorModeNone, "\033[1mbold\033[0m"},
+		{"None drops color only sequences", "\033[38;5;196mred\033[0m", ColorModeNone, "red\033[0m"},
+		{"Non color sequences untouched", "\033[2Jclear", ColorMode16, "\033[2Jclear"},
+		{"Reset untouched", "\033[mplain", ColorModeNone, "\033[mplain"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(DowngradeColors([]byte(tt.input), tt.mode)); got != tt.expected {
+				t.Errorf("DowngradeColors(%q) = %q; want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseMTTS(t *testing.T) {
+
+	if f, ok := ParseMTTS(`MTTS 2831`); !ok || !f.Has(MTTS_256COLORS) || !f.Has(MTTS_TRUECOLOR) || f.Has(MTTS_SCREENREADER) {
+		t.Errorf("ParseMTTS(MTTS 2831) = %d, %v", f, ok)
+	}
+
+	if _, ok := ParseMTTS(`XTERM-256COLOR`); ok {
+		t.Errorf("ParseMTTS(XTERM-256COLOR) should not parse")
+	}
+}
@@ -0,0 +1,38 @@
+package term
+
+const (
+	MCCP2 IACByte = 86 // https://tintin.mudhalla.net/protocols/mccp/
+	MCCP3 IACByte = 87 // https://tintin.mudhalla.net/protocols/mccp/
+)
+
+/*
+MCCP2 Handshake (server to client compression)
+The server sends IAC WILL MCCP2.
+The client responds with IAC DO MCCP2 or IAC DONT MCCP2.
+After IAC DO MCCP2 the server sends IAC SB MCCP2 IAC SE, and everything
+it sends after that is a zlib stream. Ending the zlib stream ends compression.
+
+MCCP3 Handshake (client to server compression)
+The server sends IAC WILL MCCP3.
+The client responds with IAC DO MCCP3 or IAC DONT MCCP3.
+After IAC DO MCCP3 the client sends IAC SB MCCP3 IAC SE whenever it is ready,
+and everything it sends after that is a zlib stream.
+*/
+
+var (
+	Mccp2Enable  = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, MCCP2}, []byte{}} // Indicates the server wants to compress output.
+	Mccp2Disable = TerminalCommand{[]byte{TELNET_IAC, TELNET_WONT, MCCP2}, []byte{}} // Indicates the server will stop compressing output.
+	Mccp2Accept  = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, MCCP2}, []byte{}}   // Indicates the client accepts compressed output.
+	Mccp2Refuse  = TerminalCommand{[]byte{TELNET_IAC, TELNET_DONT, MCCP2}, []byte{}} // Indicates the client refuses compressed output.
+	Mccp2Start   = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, MCCP2, TELNET_IAC, TELNET_SE}, []byte{}}
+
+	Mccp3Enable  = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, MCCP3}, []byte{}} // Indicates the server can accept compressed input.
+	Mccp3Disable = TerminalCommand{[]byte{TELNET_IAC, TELNET_WONT, MCCP3}, []byte{}} // Indicates the server will no longer accept compressed input.
+	Mccp3Accept  = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, MCCP3}, []byte{}}   // Indicates the client will compress input.
+	Mccp3Refuse  = TerminalCommand{[]byte{TELNET_IAC, TELNET_DONT, MCCP3}, []byte{}} // Indicates the client will not compress input.
+	Mccp3Start   = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, MCCP3, TELNET_IAC, TELNET_SE}, []byte{}}
+)
+
+func IsMCCPCommand(b []byte) bool {
+	return len(b) > 2 && b[0] == TELNET_IAC && (b[2] == MCCP2 || b[2] == MCCP3)
+}
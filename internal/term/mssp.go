@@ -0,0 +1,54 @@
+package term
+
+import "sort"
+
+const (
+	MSSP     IACByte = 70 // https://tintin.mudhalla.net/protocols/mssp/
+	MSSP_VAR IACByte = 1
+	MSSP_VAL IACByte = 2
+)
+
+/*
+Handshake
+The server sends IAC WILL MSSP.
+The client (usually a crawler) responds with IAC DO MSSP or IAC DONT MSSP.
+After IAC DO MSSP the server sends all of its variables in a single subnegotiation:
+IAC SB MSSP MSSP_VAR "name" MSSP_VAL "value" ... IAC SE
+*/
+
+var (
+	MsspEnable = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, MSSP}, []byte{}} // Indicates the server can report MSSP.
+	MsspAccept = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, MSSP}, []byte{}}   // Indicates the client wants the MSSP report.
+	MsspRefuse = TerminalCommand{[]byte{TELNET_IAC, TELNET_DONT, MSSP}, []byte{}} // Indicates the client does not want the MSSP report.
+
+	MsspCommand = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, MSSP}, []byte{TELNET_IAC, TELNET_SE}} // Send via TELNET MSSP Command
+)
+
+// Encodes MSSP variables into a payload for MsspCommand.
+// Variables are sorted by name so that output is consistent.
+// Empty values are omitted.
+func MsspPayload(vars map[string]string) []byte {
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	payload := []byte{}
+	for _, name := range names {
+		if vars[name] == `` {
+			continue
+		}
+		payload = append(payload, MSSP_VAR)
+		payload = append(payload, []byte(name)...)
+		payload = append(payload, MSSP_VAL)
+		payload = append(payload, []byte(vars[name])...)
+	}
+
+	return payload
+}
+
+func IsMSSPCommand(b []byte) bool {
+	return len(b) > 2 && b[0] == TELNET_IAC && b[2] == MSSP
+}
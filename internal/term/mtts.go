@@ -0,0 +1,71 @@
+package term
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	TTYPE_IS   IACByte = 0
+	TTYPE_SEND IACByte = 1
+
+	MTTSPrefix = `MTTS `
+)
+
+/*
+Handshake
+The server sends IAC DO TTYPE.
+The client responds with IAC WILL TTYPE or IAC WONT TTYPE.
+The server then sends IAC SB TTYPE SEND IAC SE, and the client answers with IAC SB TTYPE IS <value> IAC SE.
+
+MTTS (https://tintin.mudhalla.net/protocols/mtts/) defines what each repeated request returns:
+ 1st: The client name, such as "MUDLET"
+ 2nd: The terminal type, such as "XTERM-256COLOR"
+ 3rd: "MTTS <bitmask>" describing the client capabilities
+A client that does not support MTTS repeats its last value, which ends the cycle.
+*/
+
+var (
+	TelnetTermTypeRequest  = TerminalCommand{[]byte{TELNET_IAC, TELNET_DO, TELNET_OPT_TERM_TYPE}, []byte{}}                                    // Ask the client to report terminal types
+	TelnetTermTypeWill     = TerminalCommand{[]byte{TELNET_IAC, TELNET_WILL, TELNET_OPT_TERM_TYPE}, []byte{}}                                  // Client agrees to report terminal types
+	TelnetTermTypeWont     = TerminalCommand{[]byte{TELNET_IAC, TELNET_WONT, TELNET_OPT_TERM_TYPE}, []byte{}}                                  // Client refuses to report terminal types
+	TelnetTermTypeSend     = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, TELNET_OPT_TERM_TYPE, TTYPE_SEND, TELNET_IAC, TELNET_SE}, []byte{}} // Request the next terminal type
+	TelnetTermTypeResponse = TerminalCommand{[]byte{TELNET_IAC, TELNET_SB, TELNET_OPT_TERM_TYPE, TTYPE_IS}, []byte{TELNET_IAC, TELNET_SE}}     // Client reporting a terminal type
+)
+
+type MTTSFlag uint16
+
+const (
+	MTTS_ANSI          MTTSFlag = 1    // Client supports all common ANSI color codes.
+	MTTS_VT100         MTTSFlag = 2    // Client supports all common VT100 codes.
+	MTTS_UTF8          MTTSFlag = 4    // Client is using UTF-8 character encoding.
+	MTTS_256COLORS     MTTSFlag = 8    // Client supports all 256 color codes.
+	MTTS_MOUSETRACKING MTTSFlag = 16   // Client supports xterm mouse tracking.
+	MTTS_OSCPALETTE    MTTSFlag = 32   // Client supports OSC color palette sequences.
+	MTTS_SCREENREADER  MTTSFlag = 64   // Client is using a screen reader.
+	MTTS_PROXY         MTTSFlag = 128  // Client is a proxy allowing different users to connect from the same IP address.
+	MTTS_TRUECOLOR     MTTSFlag = 256  // Client supports truecolor codes using semicolon notation.
+	MTTS_MNES          MTTSFlag = 512  // Client supports the Mud New Environment Standard.
+	MTTS_MSLP          MTTSFlag = 1024 // Client supports the Mud Server Link Protocol (OSC hyperlinks).
+	MTTS_SSL           MTTSFlag = 2048 // Client supports SSL for data encryption.
+)
+
+func (f MTTSFlag) Has(flag MTTSFlag) bool {
+	return f&flag == flag
+}
+
+// Parses a terminal type response in the form of "MTTS 2831"
+func ParseMTTS(ttype string) (MTTSFlag, bool) {
+
+	numStr, ok := strings.CutPrefix(strings.ToUpper(ttype), MTTSPrefix)
+	if !ok {
+		return 0, false
+	}
+
+	num, err := strconv.Atoi(strings.TrimSpace(numStr))
+	if err != nil || num < 0 {
+		return 0, false
+	}
+
+	return MTTSFlag(num), true
+}
@@ -228,6 +228,12 @@ func iacByteString(b byte) string {
 	// Random have come up
 	case TELNET_OPT_NEW_ENV: // 39
 		return "OPT_NEW_ENV"
+	case MSSP: // 70
+		return "OPT_MSSP"
+	case MCCP2: // 86
+		return "OPT_MCCP2"
+	case MCCP3: // 87
+		return "OPT_MCCP3"
 	}
 
 	return "?"
@@ -5,6 +5,7 @@ import (
 	"math"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
@@ -164,6 +165,18 @@ func Locate(rest string, user *users.UserRecord, room *rooms.Room, flags events.
 		return true, nil
 	}
 
+	// Not in the world, but maybe a player that is offline
+	if results, err := users.QueryUsers(users.UserQuery{Name: rest}); err == nil {
+		for _, s := range results {
+			if strings.EqualFold(s.CharacterName, rest) {
+				user.SendText(
+					fmt.Sprintf(`<ansi fg="username">%s</ansi> (user <ansi fg="username">%s</ansi>) is offline. Last logged in %s.`, s.CharacterName, s.Username, s.LastLogin.Format(time.DateTime)),
+				)
+				return true, nil
+			}
+		}
+	}
+
 	user.SendText(
 		fmt.Sprintf("No user or mob found with the name %s", rest),
 	)
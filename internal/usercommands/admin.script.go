@@ -0,0 +1,145 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+* Role Permissions:
+* script 				(All)
+ */
+func Script(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) == 0 {
+		infoOutput, _ := templates.Process("admincommands/help/command.script", nil, user.UserId)
+		user.SendText(infoOutput)
+		return true, nil
+	}
+
+	// Script ids can be mixed case, so only the subcommand and script type are lowercased
+	for i := 0; i < len(args) && i < 2; i++ {
+		args[i] = strings.ToLower(args[i])
+	}
+
+	switch args[0] {
+
+	case `list`:
+
+		filterType := ``
+		if len(args) > 1 {
+			filterType = args[1]
+		}
+
+		headers := []string{`Type`, `Id`, `Calls`, `Avg`, `Max`, `Errors`, `Timeouts`}
+		rows := [][]string{}
+
+		for _, info := range scripting.GetLoadedScripts() {
+
+			if filterType != `` && info.Type != filterType {
+				continue
+			}
+
+			rows = append(rows, []string{
+				info.Type,
+				info.Id,
+				fmt.Sprintf(`%d`, info.Stats.Calls),
+				formatScriptTime(info.Stats.AverageTime()),
+				formatScriptTime(info.Stats.MaxTime),
+				fmt.Sprintf(`%d`, info.Stats.Errors),
+				fmt.Sprintf(`%d`, info.Stats.Timeouts),
+			})
+		}
+
+		scriptTableData := templates.GetTable(fmt.Sprintf(`Loaded Scripts (%d)`, len(rows)), headers, rows)
+		tplTxt, _ := templates.Process("tables/generic", scriptTableData, user.UserId, user.UserId)
+		user.SendText(tplTxt)
+
+	case `info`:
+
+		if len(args) < 3 {
+			user.SendText(`Usage: <ansi fg="command">script info [type] [id]</ansi>`)
+			return true, nil
+		}
+
+		info, found := scripting.GetLoadedScript(args[1], args[2])
+		if !found {
+			user.SendText(fmt.Sprintf(`No %s script %s is loaded.`, args[1], args[2]))
+			return true, nil
+		}
+
+		lastError := info.Stats.LastError
+		if lastError == `` {
+			lastError = `None`
+		}
+
+		user.SendText(fmt.Sprintf(`<ansi fg="yellow-bold">%s-%s</ansi>`, info.Type, info.Id))
+		user.SendText(fmt.Sprintf(`  Calls:      %d`, info.Stats.Calls))
+		user.SendText(fmt.Sprintf(`  Total Time: %s`, formatScriptTime(info.Stats.TotalTime)))
+		user.SendText(fmt.Sprintf(`  Avg Time:   %s`, formatScriptTime(info.Stats.AverageTime())))
+		user.SendText(fmt.Sprintf(`  Max Time:   %s`, formatScriptTime(info.Stats.MaxTime)))
+		user.SendText(fmt.Sprintf(`  Errors:     %d`, info.Stats.Errors))
+		user.SendText(fmt.Sprintf(`  Timeouts:   %d`, info.Stats.Timeouts))
+		user.SendText(fmt.Sprintf(`  Last Error: %s`, lastError))
+
+	case `reload`:
+
+		if len(args) == 2 && args[1] == `all` {
+			scripting.PruneVMs(true)
+			user.SendText(`All scripts have been unloaded, and will be loaded from disk the next time they are needed.`)
+			return true, nil
+		}
+
+		if len(args) < 3 {
+			user.SendText(`Usage: <ansi fg="command">script reload [type] [id]</ansi> or <ansi fg="command">script reload all</ansi>`)
+			return true, nil
+		}
+
+		if err := scripting.ReloadScript(args[1], args[2]); err != nil {
+			user.SendText(fmt.Sprintf(`<ansi fg="red">Reload failed:</ansi> %s`, err))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`Reloaded <ansi fg="yellow-bold">%s-%s</ansi> from disk.`, args[1], args[2]))
+
+	case `tail`:
+
+		listen := !scripting.IsConsoleListener(user.UserId)
+		if len(args) > 1 {
+			listen = args[1] == `on`
+		}
+
+		scripting.SetConsoleListener(user.UserId, listen)
+
+		if !listen {
+			user.SendText(`Script console output stopped.`)
+			return true, nil
+		}
+
+		for _, line := range scripting.GetConsoleHistory() {
+			user.SendText(line)
+		}
+
+		user.SendText(`Following script console output. Use <ansi fg="command">script tail off</ansi> to stop.`)
+
+	default:
+		infoOutput, _ := templates.Process("admincommands/help/command.script", nil, user.UserId)
+		user.SendText(infoOutput)
+	}
+
+	return true, nil
+}
+
+func formatScriptTime(d time.Duration) string {
+	return fmt.Sprintf(`%.2fms`, float64(d.Microseconds())/1000)
+}
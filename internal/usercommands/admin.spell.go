@@ -140,6 +140,12 @@ func spell_Create(rest string, user *users.UserRecord, room *rooms.Room, flags e
 			return true, nil
 		}
 
+		if err := spells.ValidateSpellId(question.Response); err != nil {
+			user.SendText(err.Error())
+			question.RejectResponse()
+			return true, nil
+		}
+
 		newSpell.SpellId = question.Response
 	}
 
@@ -88,7 +88,8 @@ func Teleport(rest string, user *users.UserRecord, room *rooms.Room, flags event
 
 			user.SendText(fmt.Sprintf("Moved to room %d.", gotoRoomId))
 
-			gotoRoom := rooms.LoadRoom(gotoRoomId)
+			// Entering an instanced zone leads to a copy of the room
+			gotoRoom := rooms.LoadRoom(user.Character.RoomId)
 			gotoRoom.SendText(
 				fmt.Sprintf(`<ansi fg="username">%s</ansi> appears in a flash of light!`, user.Character.Name),
 				user.UserId,
@@ -99,7 +100,7 @@ func Teleport(rest string, user *users.UserRecord, room *rooms.Room, flags event
 				// Party leaders can move the whole party.
 				if party.LeaderUserId == user.UserId {
 
-					newRoom := rooms.LoadRoom(gotoRoomId)
+					newRoom := gotoRoom
 					for _, uid := range room.GetPlayers() {
 						if party.IsMember(uid) {
 
@@ -135,7 +136,7 @@ func Teleport(rest string, user *users.UserRecord, room *rooms.Room, flags event
 
 			Look(``, user, gotoRoom, flags)
 
-			scripting.TryRoomScriptEvent(`onEnter`, user.UserId, gotoRoomId)
+			scripting.TryRoomScriptEvent(`onEnter`, user.UserId, gotoRoom.RoomId)
 
 		}
 	} else {
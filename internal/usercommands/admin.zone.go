@@ -62,6 +62,18 @@ func Zone(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 			user.SendText(fmt.Sprintf(`  <ansi fg="yellow-bold">Mob AutoScale:</ansi>    <ansi fg="red">%d</ansi> - <ansi fg="red">%d</ansi>`, zoneConfig.MobAutoScale.Minimum, zoneConfig.MobAutoScale.Maximum))
 		}
 
+		if !zoneConfig.Instanced {
+			user.SendText(`  <ansi fg="yellow-bold">Instanced:</ansi>        <ansi fg="red">[disabled]</ansi>`)
+		} else {
+			instanceCt := 0
+			for _, inst := range rooms.GetZoneInstances() {
+				if inst.Zone == room.Zone {
+					instanceCt++
+				}
+			}
+			user.SendText(fmt.Sprintf(`  <ansi fg="yellow-bold">Instanced:</ansi>        <ansi fg="red">%d</ansi> active`, instanceCt))
+		}
+
 		user.SendText(``)
 
 		return true, nil
@@ -101,6 +113,27 @@ func Zone(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 			return true, nil
 		}
 
+		if setWhat == `instanced` {
+
+			setTo := ``
+			if len(args) > 0 {
+				setTo = strings.ToLower(args[0])
+			}
+
+			switch setTo {
+			case `on`, `true`, `yes`:
+				zoneConfig.Instanced = true
+			case `off`, `false`, `no`:
+				zoneConfig.Instanced = false
+			default:
+				user.SendText(`Use <ansi fg="command">zone set instanced on</ansi> or <ansi fg="command">zone set instanced off</ansi>.`)
+				return true, nil
+			}
+
+			user.SendText(`Done! Existing instances are left alone until they empty out.`)
+			return true, nil
+		}
+
 	}
 
 	return true, nil
@@ -23,6 +23,7 @@ func Broadcast(rest string, user *users.UserRecord, room *rooms.Room, flags even
 		Text:            msg + term.CRLFStr,
 		IsCommunication: true,
 		SourceIsMod:     user.Role != users.RoleUser,
+		SourceUserId:    user.UserId,
 	})
 
 	events.AddToQueue(events.Communication{
@@ -111,6 +111,11 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 	petNames := []string{}
 	petPrices := map[string]int{}
 
+	if shopMob != nil && user.Character.GetMerchantReputation(int(shopMob.MobId)) <= characters.MerchantReputationRefuse {
+		shopMob.Command(`say I don't do business with thieves.`)
+		return false
+	}
+
 	var saleItems characters.Shop
 	if shopMob != nil {
 		saleItems = shopMob.Character.Shop.GetInstock()
@@ -257,6 +262,12 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 		price = petPrices[matchedShopItem.PetType]
 	}
 
+	// Merchants adjust their prices based on trading skill and reputation
+	basePrice := price
+	if shopMob != nil {
+		price = user.Character.GetBuyPrice(price, int(shopMob.MobId))
+	}
+
 	if user.Character.Gold < price {
 		if shopMob != nil {
 			shopMob.Command(`say You don't have enough gold for that.`)
@@ -308,6 +319,12 @@ func tryPurchase(request string, user *users.UserRecord, room *rooms.Room, shopM
 	user.Character.Gold -= price
 	if shopMob != nil {
 		shopMob.Character.Gold += 1 // only gains 1 gold with each sale
+
+		user.Character.AdjustMerchantReputation(int(shopMob.MobId), characters.MerchantReputationTrade)
+
+		if price < basePrice && user.Character.GetSkillLevel(skills.Trading) > 0 {
+			user.SendText(fmt.Sprintf(`You haggle the price down from <ansi fg="gold">%d gold</ansi> to <ansi fg="gold">%d gold</ansi>.`, basePrice, price))
+		}
 	} else if shopUser != nil {
 		shopUser.Character.Gold += price
 
@@ -0,0 +1,399 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/channels"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+Chat channels. Once joined, talk on a channel by typing its name.
+Usage:
+
+	channel
+	channel join trade
+	channel create guild-chat [password]
+	channel mute guild-chat Bob
+	ooc hello everyone!
+*/
+func Channel(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	channelCommand := `list`
+	if len(args) > 0 {
+		channelCommand = strings.ToLower(args[0])
+		args = args[1:]
+	}
+
+	isStaff := user.Role != users.RoleUser
+
+	if channelCommand == `list` {
+
+		headers := []string{`Channel`, `Description`, `Owner`, `Joined`}
+		formatting := []string{
+			`<ansi fg="channel-prefix">%s</ansi>`,
+			`<ansi fg="white-bold">%s</ansi>`,
+			`<ansi fg="username">%s</ansi>`,
+			`<ansi fg="yellow">%s</ansi>`,
+		}
+
+		rows := [][]string{}
+		for _, c := range channels.GetAll() {
+
+			if c.StaffOnly && !isStaff {
+				continue
+			}
+
+			description := c.Description
+			if c.HasPassword() {
+				description = strings.TrimSpace(description + ` (password)`)
+			}
+
+			owner := c.OwnerName
+			if c.BuiltIn {
+				owner = `-`
+			}
+
+			joined := `no`
+			if c.IsBanned(user.UserId) {
+				joined = `banned`
+			} else if c.IsMember(user.UserId, isStaff) {
+				joined = `yes`
+			}
+
+			rows = append(rows, []string{c.Name, description, owner, joined})
+		}
+
+		tblData := templates.GetTable(`Chat Channels`, headers, rows, formatting)
+		tplTxt, _ := templates.Process("tables/generic", tblData, user.UserId)
+		user.SendText(tplTxt)
+		user.SendText(`Talk on a channel you've joined by typing its name, such as <ansi fg="command">ooc hello!</ansi> See <ansi fg="command">help channel</ansi> for more.`)
+
+		return true, nil
+	}
+
+	if len(args) < 1 {
+		user.SendText(fmt.Sprintf(`Usage: <ansi fg="command">channel %s [channel]</ansi>`, channelCommand))
+		return true, nil
+	}
+
+	channelName := strings.ToLower(args[0])
+	args = args[1:]
+
+	if channelCommand == `create` {
+
+		if isChannelNameTaken(channelName) {
+			user.SendText(fmt.Sprintf(`<ansi fg="channel-prefix">%s</ansi> is already a command, so it can't be used for a channel.`, channelName))
+			return true, nil
+		}
+
+		password := ``
+		if len(args) > 0 {
+			password = args[0]
+		}
+
+		c, err := channels.Create(channelName, user.UserId, user.Character.Name, password)
+		if err != nil {
+			user.SendText(fmt.Sprintf(`Could not create the channel: %s.`, err.Error()))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You created the <ansi fg="channel-prefix">%s</ansi> channel. Type <ansi fg="command">%s [message]</ansi> to talk on it.`, c.Name, c.Name))
+		if c.HasPassword() {
+			user.SendText(`Others will need the password to join.`)
+		}
+
+		return true, nil
+	}
+
+	c := channels.Get(channelName)
+	if c == nil || (c.StaffOnly && !isStaff) {
+		user.SendText(fmt.Sprintf(`There is no channel named <ansi fg="channel-prefix">%s</ansi>.`, channelName))
+		return true, nil
+	}
+
+	switch channelCommand {
+
+	case `join`:
+
+		password := ``
+		if len(args) > 0 {
+			password = args[0]
+		}
+
+		if err := c.Join(user.UserId, password, isStaff); err != nil {
+			user.SendText(fmt.Sprintf(`You can't join <ansi fg="channel-prefix">%s</ansi>: %s.`, c.Name, err.Error()))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You joined the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+
+	case `leave`:
+
+		if err := c.Leave(user.UserId, isStaff); err != nil {
+			user.SendText(fmt.Sprintf(`You aren't on the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You left the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+
+	case `who`:
+
+		if !c.IsMember(user.UserId, isStaff) {
+			user.SendText(fmt.Sprintf(`You aren't on the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+			return true, nil
+		}
+
+		names := []string{}
+		for _, u := range users.GetAllActiveUsers() {
+			if !c.IsMember(u.UserId, u.Role != users.RoleUser) {
+				continue
+			}
+			name := fmt.Sprintf(`<ansi fg="username">%s</ansi>`, u.Character.Name)
+			if c.IsMuted(u.UserId) {
+				name += ` <ansi fg="alert-5">(muted)</ansi>`
+			}
+			names = append(names, name)
+		}
+
+		user.SendText(fmt.Sprintf(`Listening to <ansi fg="channel-prefix">%s</ansi> (%d): %s`, c.Name, len(names), strings.Join(names, `, `)))
+
+	case `history`:
+
+		if !c.IsMember(user.UserId, isStaff) {
+			user.SendText(fmt.Sprintf(`You aren't on the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+			return true, nil
+		}
+
+		sendChannelHistory(c, user)
+
+	case `delete`:
+
+		if c.BuiltIn || !c.CanModerate(user.UserId, isStaff) {
+			user.SendText(fmt.Sprintf(`You can't delete the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+			return true, nil
+		}
+
+		if err := channels.Delete(c.Name); err != nil {
+			user.SendText(fmt.Sprintf(`Could not delete the channel: %s.`, err.Error()))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`The <ansi fg="channel-prefix">%s</ansi> channel has been deleted.`, c.Name))
+
+	case `password`:
+
+		if c.BuiltIn || !c.CanModerate(user.UserId, isStaff) {
+			user.SendText(fmt.Sprintf(`You can't change the password of the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+			return true, nil
+		}
+
+		password := ``
+		if len(args) > 0 {
+			password = args[0]
+		}
+
+		if err := c.SetPassword(password); err != nil {
+			user.SendText(fmt.Sprintf(`Could not change the password: %s.`, err.Error()))
+			return true, nil
+		}
+
+		if password == `` {
+			user.SendText(fmt.Sprintf(`The <ansi fg="channel-prefix">%s</ansi> channel no longer needs a password.`, c.Name))
+		} else {
+			user.SendText(fmt.Sprintf(`The <ansi fg="channel-prefix">%s</ansi> channel password has been changed.`, c.Name))
+		}
+
+	case `mute`, `unmute`, `ban`, `unban`:
+
+		if !c.CanModerate(user.UserId, isStaff) {
+			user.SendText(fmt.Sprintf(`You don't moderate the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+			return true, nil
+		}
+
+		if len(args) < 1 {
+			user.SendText(fmt.Sprintf(`Usage: <ansi fg="command">channel %s %s [player]</ansi>`, channelCommand, c.Name))
+			return true, nil
+		}
+
+		targetUserId, targetName, targetIsStaff := findPlayerByName(args[0])
+		if targetUserId == 0 {
+			user.SendText("You can't find anyone by that name.")
+			return true, nil
+		}
+
+		// Owners can't moderate staff, or themselves
+		if targetUserId == user.UserId || (!isStaff && targetIsStaff) {
+			user.SendText(fmt.Sprintf(`You can't %s <ansi fg="username">%s</ansi>.`, channelCommand, targetName))
+			return true, nil
+		}
+
+		changed := false
+		switch channelCommand {
+		case `mute`:
+			changed = c.Mute(targetUserId)
+		case `unmute`:
+			changed = c.Unmute(targetUserId)
+		case `ban`:
+			changed = c.Ban(targetUserId)
+		case `unban`:
+			changed = c.Unban(targetUserId)
+		}
+
+		if !changed {
+			user.SendText(fmt.Sprintf(`Nothing changed for <ansi fg="username">%s</ansi> on <ansi fg="channel-prefix">%s</ansi>.`, targetName, c.Name))
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You <ansi fg="alert-5">%s</ansi> <ansi fg="username">%s</ansi> on <ansi fg="channel-prefix">%s</ansi>.`, pastTense(channelCommand), targetName, c.Name))
+
+		if targetUser := users.GetByUserId(targetUserId); targetUser != nil {
+			targetUser.SendText(fmt.Sprintf(`You have been <ansi fg="alert-5">%s</ansi> on the <ansi fg="channel-prefix">%s</ansi> channel.`, pastTense(channelCommand), c.Name))
+		}
+
+	default:
+		user.SendText(`Try <ansi fg="command">help channel</ansi> for more information about channels.`)
+	}
+
+	return true, nil
+}
+
+// Sends a message to everyone listening to a channel.
+// Returns false if the user isn't on the channel, so that the input can be treated as something else.
+func tryChannelMessage(channelName string, message string, user *users.UserRecord) bool {
+
+	c := channels.Get(channelName)
+	if c == nil {
+		return false
+	}
+
+	sourceIsMod := user.Role != users.RoleUser
+
+	if !c.IsMember(user.UserId, sourceIsMod) {
+		return false
+	}
+
+	if message == `` {
+		sendChannelHistory(c, user)
+		return true
+	}
+
+	if user.Muted {
+		user.SendText(`You are <ansi fg="alert-5">MUTED</ansi>. You can only send <ansi fg="command">whisper</ansi>'s to Admins and Moderators.`)
+		return true
+	}
+
+	if c.IsMuted(user.UserId) {
+		user.SendText(fmt.Sprintf(`You are <ansi fg="alert-5">MUTED</ansi> on the <ansi fg="channel-prefix">%s</ansi> channel.`, c.Name))
+		return true
+	}
+
+	msg := fmt.Sprintf(`<ansi fg="channel-prefix">(%s)</ansi> <ansi fg="username">%s</ansi>: <ansi fg="channel-body">%s</ansi>`, c.Name, user.Character.Name, message)
+
+	recipients := []int{}
+	for _, u := range users.GetAllActiveUsers() {
+
+		if !c.IsMember(u.UserId, u.Role != users.RoleUser) {
+			continue
+		}
+
+		// Deafened users still hear admins and moderators, same as broadcasts
+		if u.Deafened && !sourceIsMod {
+			continue
+		}
+
+		if u.IsIgnoring(user.UserId) {
+			continue
+		}
+
+		u.SendText(msg)
+		recipients = append(recipients, u.UserId)
+	}
+
+	c.AddHistory(user.Character.Name, message)
+
+	events.AddToQueue(events.Communication{
+		SourceUserId: user.UserId,
+		CommType:     c.Name,
+		Name:         user.Character.Name,
+		Message:      message,
+		Recipients:   recipients,
+	})
+
+	return true
+}
+
+func sendChannelHistory(c *channels.Channel, user *users.UserRecord) {
+
+	history := c.GetHistory()
+	if len(history) == 0 {
+		user.SendText(fmt.Sprintf(`Nothing has been said on <ansi fg="channel-prefix">%s</ansi> recently.`, c.Name))
+		return
+	}
+
+	user.SendText(fmt.Sprintf(`Recent messages on <ansi fg="channel-prefix">%s</ansi>:`, c.Name))
+	for _, h := range history {
+		user.SendText(fmt.Sprintf(`  <ansi fg="black-bold">[%s]</ansi> <ansi fg="username">%s</ansi>: <ansi fg="channel-body">%s</ansi>`, h.Time.Format(`15:04`), h.Name, h.Message))
+	}
+}
+
+// Set in init() since userCommands refers to Channel, which would be an initialization cycle
+var isUserCommand func(name string) bool
+
+func init() {
+	isUserCommand = func(name string) bool {
+		_, ok := userCommands[name]
+		return ok
+	}
+}
+
+// Channel names are typed like commands, so they can't hide an existing one
+func isChannelNameTaken(name string) bool {
+
+	if isUserCommand(name) {
+		return true
+	}
+
+	if _, ok := emoteAliases[name]; ok {
+		return true
+	}
+
+	return keywords.TryCommandAlias(name) != name || keywords.IsDirection(name)
+}
+
+// Finds an online or offline player by character name
+func findPlayerByName(name string) (userId int, characterName string, isStaff bool) {
+
+	if u := users.GetByCharacterName(name); u != nil {
+		return u.UserId, u.Character.Name, u.Role != users.RoleUser
+	}
+
+	userId, username := users.CharacterNameSearch(name)
+	if userId == 0 {
+		return 0, ``, false
+	}
+
+	if u, err := users.LoadUser(username, true); err == nil {
+		return userId, name, u.Role != users.RoleUser
+	}
+
+	return userId, name, false
+}
+
+func pastTense(channelCommand string) string {
+	switch channelCommand {
+	case `ban`:
+		return `banned`
+	case `unban`:
+		return `unbanned`
+	}
+	return channelCommand + `d`
+}
@@ -0,0 +1,514 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/clans"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func Clan(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	clanCommand := `info`
+	if len(args) > 0 {
+		clanCommand = strings.ToLower(args[0])
+		rest, _ = strings.CutPrefix(rest, args[0])
+		rest = strings.TrimSpace(rest)
+		args = args[1:]
+	}
+
+	currentClan := clans.GetClanByUserId(user.UserId)
+
+	if clanCommand == `list` {
+
+		headers := []string{`Tag`, `Name`, `Members`, `Founded`}
+		formatting := []string{
+			`<ansi fg="clantag">%s</ansi>`,
+			`<ansi fg="white-bold">%s</ansi>`,
+			`<ansi fg="red">%s</ansi>`,
+			`<ansi fg="magenta">%s</ansi>`,
+		}
+
+		rows := [][]string{}
+		for _, c := range clans.GetAllClans() {
+			rows = append(rows, []string{
+				c.ClanTag,
+				c.ClanName,
+				strconv.Itoa(len(c.Members)),
+				c.Created.Format(`2006-01-02`),
+			})
+		}
+
+		if len(rows) == 0 {
+			user.SendText(`There are no clans yet. Why not <ansi fg="command">clan create</ansi> one?`)
+			return true, nil
+		}
+
+		tblData := templates.GetTable(`Clans`, headers, rows, formatting)
+		tplTxt, _ := templates.Process("tables/generic", tblData, user.UserId)
+		user.SendText(tplTxt)
+
+		return true, nil
+	}
+
+	if clanCommand == `create` {
+
+		if currentClan != nil {
+			user.SendText(fmt.Sprintf(`You are already a member of <ansi fg="clantag">%s</ansi>.`, currentClan.String()))
+			return true, nil
+		}
+
+		if len(args) < 2 {
+			user.SendText(`Usage: <ansi fg="command">clan create [tag] [full clan name]</ansi>`)
+			return true, nil
+		}
+
+		clanCfg := configs.GetGamePlayConfig().Clans
+
+		if user.Character.Level < int(clanCfg.MinimumCreateLevel) {
+			user.SendText(fmt.Sprintf(`You must be at least level <ansi fg="red">%d</ansi> to found a clan.`, clanCfg.MinimumCreateLevel))
+			return true, nil
+		}
+
+		if user.Character.Gold < int(clanCfg.CreateCost) {
+			user.SendText(fmt.Sprintf(`It costs <ansi fg="gold">%d gold</ansi> to found a clan. You don't have enough gold on hand.`, clanCfg.CreateCost))
+			return true, nil
+		}
+
+		clanTag := args[0]
+		clanName := strings.TrimSpace(strings.Join(args[1:], ` `))
+
+		newClan, err := clans.Create(clanTag, clanName, user.UserId, user.Character.Name, int(clanCfg.CreateCost))
+		if err != nil {
+			user.SendText(fmt.Sprintf(`Could not create the clan: %s`, err.Error()))
+			return true, nil
+		}
+
+		user.Character.Gold -= int(clanCfg.CreateCost)
+
+		events.AddToQueue(events.EquipmentChange{
+			UserId:     user.UserId,
+			GoldChange: -int(clanCfg.CreateCost),
+		})
+
+		user.EventLog.Add(`clan`, fmt.Sprintf(`Founded the clan <ansi fg="clantag">%s</ansi>`, newClan.String()))
+
+		user.SendText(fmt.Sprintf(`You founded the clan <ansi fg="clantag">%s</ansi>! Your <ansi fg="gold">%d gold</ansi> has been placed in the clan treasury.`, newClan.String(), clanCfg.CreateCost))
+
+		events.AddToQueue(events.Broadcast{
+			Text: fmt.Sprintf(`<ansi fg="username">%s</ansi> has founded the clan <ansi fg="clantag">%s</ansi>!`, user.Character.Name, newClan.String()),
+		})
+
+		return true, nil
+	}
+
+	if clanCommand == `apply` {
+
+		if currentClan != nil {
+			user.SendText(fmt.Sprintf(`You are already a member of <ansi fg="clantag">%s</ansi>.`, currentClan.String()))
+			return true, nil
+		}
+
+		if rest == `` {
+			user.SendText(`Apply to which clan?`)
+			return true, nil
+		}
+
+		applyClan := clans.FindClan(rest)
+		if applyClan == nil {
+			user.SendText(fmt.Sprintf(`No clan named "%s" could be found.`, rest))
+			return true, nil
+		}
+
+		if !applyClan.AddApplication(user.UserId, user.Character.Name) {
+			user.SendText(`You have already applied to that clan.`)
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You have applied to join <ansi fg="clantag">%s</ansi>.`, applyClan.String()))
+
+		clanMessage(applyClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> has applied to join the clan. Type <ansi fg="command">clan accept %s</ansi> to accept.`, user.Character.Name, user.Character.Name), clans.ClanRankLieutenant)
+
+		return true, nil
+	}
+
+	if clanCommand == `accept` || clanCommand == `join` {
+
+		// Not in a clan? They must be accepting an invitation.
+		if currentClan == nil {
+
+			invites := clans.GetInvitations(user.UserId)
+
+			if len(invites) == 0 {
+				user.SendText(`You have not been invited to any clans.`)
+				return true, nil
+			}
+
+			joinClan := invites[0]
+			if rest != `` {
+				if joinClan = clans.FindClan(rest); joinClan == nil || !joinClan.HasInvite(user.UserId) {
+					user.SendText(fmt.Sprintf(`You have not been invited to "%s".`, rest))
+					return true, nil
+				}
+			} else if len(invites) > 1 {
+				user.SendText(`You have been invited to more than one clan. Specify which one with <ansi fg="command">clan accept [tag]</ansi>.`)
+				return true, nil
+			}
+
+			if err := clans.Join(joinClan.ClanTag, user.UserId, user.Character.Name); err != nil {
+				user.SendText(fmt.Sprintf(`Could not join the clan: %s`, err.Error()))
+				return true, nil
+			}
+
+			user.EventLog.Add(`clan`, fmt.Sprintf(`Joined the clan <ansi fg="clantag">%s</ansi>`, joinClan.String()))
+			user.SendText(fmt.Sprintf(`You joined <ansi fg="clantag">%s</ansi>!`, joinClan.String()))
+			clanMessage(joinClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> has joined the clan!`, user.Character.Name), clans.ClanRankMember, user.UserId)
+
+			return true, nil
+		}
+
+		if !currentClan.HasRank(user.UserId, clans.ClanRankLieutenant) {
+			user.SendText(`Only lieutenants and leaders can accept applications.`)
+			return true, nil
+		}
+
+		if rest == `` {
+			user.SendText(`Accept whose application?`)
+			return true, nil
+		}
+
+		application, found := currentClan.FindApplication(rest)
+		if !found {
+			user.SendText(fmt.Sprintf(`There is no application from "%s".`, rest))
+			return true, nil
+		}
+
+		if err := clans.Join(currentClan.ClanTag, application.UserId, application.CharacterName); err != nil {
+			clans.Decline(currentClan.ClanTag, application.UserId)
+			user.SendText(fmt.Sprintf(`Could not accept the application: %s`, err.Error()))
+			return true, nil
+		}
+
+		clanMessage(currentClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> accepted the application of <ansi fg="username">%s</ansi>.`, user.Character.Name, application.CharacterName), clans.ClanRankMember, application.UserId)
+
+		if appUser := users.GetByUserId(application.UserId); appUser != nil {
+			appUser.EventLog.Add(`clan`, fmt.Sprintf(`Joined the clan <ansi fg="clantag">%s</ansi>`, currentClan.String()))
+			appUser.SendText(fmt.Sprintf(`Your application to <ansi fg="clantag">%s</ansi> was accepted!`, currentClan.String()))
+		}
+
+		return true, nil
+	}
+
+	if clanCommand == `decline` {
+
+		if currentClan == nil {
+			invites := clans.GetInvitations(user.UserId)
+			if len(invites) == 0 {
+				user.SendText(`You have not been invited to any clans.`)
+				return true, nil
+			}
+			for _, c := range invites {
+				if rest == `` || c == clans.FindClan(rest) {
+					clans.Decline(c.ClanTag, user.UserId)
+					user.SendText(fmt.Sprintf(`You declined the invitation to <ansi fg="clantag">%s</ansi>.`, c.String()))
+				}
+			}
+			return true, nil
+		}
+
+		if !currentClan.HasRank(user.UserId, clans.ClanRankLieutenant) {
+			user.SendText(`Only lieutenants and leaders can decline applications.`)
+			return true, nil
+		}
+
+		application, found := currentClan.FindApplication(rest)
+		if !found {
+			user.SendText(fmt.Sprintf(`There is no application from "%s".`, rest))
+			return true, nil
+		}
+
+		clans.Decline(currentClan.ClanTag, application.UserId)
+		user.SendText(fmt.Sprintf(`You declined the application of <ansi fg="username">%s</ansi>.`, application.CharacterName))
+
+		return true, nil
+	}
+
+	//
+	// Everything after this point requires being in a clan
+	//
+
+	if currentClan == nil {
+		if invites := clans.GetInvitations(user.UserId); len(invites) > 0 {
+			for _, c := range invites {
+				user.SendText(fmt.Sprintf(`You have been invited to join <ansi fg="clantag">%s</ansi>. Type <ansi fg="command">clan accept %s</ansi> to join.`, c.String(), c.ClanTag))
+			}
+			return true, nil
+		}
+		user.SendText(`You are not a member of a clan. See <ansi fg="command">help clan</ansi> for more information.`)
+		return true, nil
+	}
+
+	if clanCommand == `info` {
+
+		headers := []string{`Name`, `Rank`, `Joined`, `Online`}
+		formatting := []string{
+			`<ansi fg="username">%s</ansi>`,
+			`<ansi fg="white-bold">%s</ansi>`,
+			`<ansi fg="magenta">%s</ansi>`,
+			`<ansi fg="green">%s</ansi>`,
+		}
+
+		rows := [][]string{}
+		for _, m := range currentClan.Members {
+			online := ``
+			if users.GetByUserId(m.UserId) != nil {
+				online = `yes`
+			}
+			rows = append(rows, []string{
+				m.CharacterName,
+				string(m.Rank),
+				m.Joined.Format(`2006-01-02`),
+				online,
+			})
+		}
+
+		tblData := templates.GetTable(currentClan.String(), headers, rows, formatting)
+		tplTxt, _ := templates.Process("tables/generic", tblData, user.UserId)
+		user.SendText(tplTxt)
+
+		user.SendText(fmt.Sprintf(`  Treasury: <ansi fg="gold">%d gold</ansi>  Daily Upkeep: <ansi fg="gold">%d gold</ansi>`, currentClan.Gold, currentClan.DailyCost()))
+
+		if currentClan.HasRank(user.UserId, clans.ClanRankLieutenant) {
+			if len(currentClan.Applications) > 0 {
+				names := []string{}
+				for _, a := range currentClan.Applications {
+					names = append(names, `<ansi fg="username">`+a.CharacterName+`</ansi>`)
+				}
+				user.SendText(`  Applications: ` + strings.Join(names, `, `))
+			}
+			if len(currentClan.Invites) > 0 {
+				names := []string{}
+				for _, a := range currentClan.Invites {
+					names = append(names, `<ansi fg="username">`+a.CharacterName+`</ansi>`)
+				}
+				user.SendText(`  Invited: ` + strings.Join(names, `, `))
+			}
+		}
+
+		user.SendText(``)
+
+		return true, nil
+	}
+
+	if clanCommand == `donate` {
+
+		amountStr := strings.TrimSuffix(strings.ToLower(rest), ` gold`)
+		amount, _ := strconv.Atoi(amountStr)
+		if amountStr == `all` {
+			amount = user.Character.Gold
+		}
+
+		if amount < 1 {
+			user.SendText(`Donate how much gold?`)
+			return true, nil
+		}
+
+		if amount > user.Character.Gold {
+			user.SendText(`You don't have that much gold on hand.`)
+			return true, nil
+		}
+
+		user.Character.Gold -= amount
+		currentClan.AddDonation(clans.Donation{
+			UserId: user.UserId,
+			Gold:   amount,
+		})
+
+		events.AddToQueue(events.EquipmentChange{
+			UserId:     user.UserId,
+			GoldChange: -amount,
+		})
+
+		user.EventLog.Add(`clan`, fmt.Sprintf(`Donated <ansi fg="gold">%d gold</ansi> to the clan`, amount))
+
+		user.SendText(fmt.Sprintf(`You donate <ansi fg="gold">%d gold</ansi> to the clan treasury.`, amount))
+		clanMessage(currentClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> donated <ansi fg="gold">%d gold</ansi> to the clan treasury.`, user.Character.Name, amount), clans.ClanRankMember, user.UserId)
+
+		return true, nil
+	}
+
+	if clanCommand == `leave` || clanCommand == `quit` {
+
+		clans.Leave(user.UserId)
+
+		user.EventLog.Add(`clan`, fmt.Sprintf(`Left the clan <ansi fg="clantag">%s</ansi>`, currentClan.String()))
+		user.SendText(fmt.Sprintf(`You have left <ansi fg="clantag">%s</ansi>.`, currentClan.String()))
+		clanMessage(currentClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> has left the clan.`, user.Character.Name), clans.ClanRankMember)
+
+		return true, nil
+	}
+
+	//
+	// Everything after this point requires being a leader
+	//
+
+	if !currentClan.HasRank(user.UserId, clans.ClanRankLeader) {
+		user.SendText(`Only clan leaders can do that. Try <ansi fg="command">help clan</ansi>.`)
+		return true, nil
+	}
+
+	if clanCommand == `invite` {
+
+		if rest == `` {
+			user.SendText(`Invite who?`)
+			return true, nil
+		}
+
+		invitedUser := users.GetByCharacterName(rest)
+		if invitedUser == nil {
+			user.SendText(fmt.Sprintf(`%s is not online.`, rest))
+			return true, nil
+		}
+
+		if clans.GetClanByUserId(invitedUser.UserId) != nil {
+			user.SendText(`That player is already in a clan.`)
+			return true, nil
+		}
+
+		if !currentClan.AddInvite(invitedUser.UserId, invitedUser.Character.Name) {
+			user.SendText(`That player has already been invited.`)
+			return true, nil
+		}
+
+		user.SendText(fmt.Sprintf(`You invited <ansi fg="username">%s</ansi> to join the clan.`, invitedUser.Character.Name))
+		invitedUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> invited you to join <ansi fg="clantag">%s</ansi>. Type <ansi fg="command">clan accept %s</ansi> or <ansi fg="command">clan decline %s</ansi> to respond.`, user.Character.Name, currentClan.String(), currentClan.ClanTag, currentClan.ClanTag))
+
+		return true, nil
+	}
+
+	if clanCommand == `kick` || clanCommand == `promote` {
+
+		if rest == `` {
+			if clanCommand == `kick` {
+				user.SendText(`Kick who?`)
+			} else {
+				user.SendText(`Promote who?`)
+			}
+			return true, nil
+		}
+
+		member, found := currentClan.FindMember(rest)
+		if !found {
+			user.SendText(fmt.Sprintf(`"%s" is not a member of the clan.`, rest))
+			return true, nil
+		}
+
+		if member.UserId == user.UserId {
+			user.SendText(fmt.Sprintf(`You can't %s yourself.`, clanCommand))
+			return true, nil
+		}
+
+		if clanCommand == `kick` {
+
+			if member.Rank == clans.ClanRankLeader {
+				user.SendText(`You can't kick another leader.`)
+				return true, nil
+			}
+
+			clans.Leave(member.UserId)
+
+			user.SendText(fmt.Sprintf(`You kicked <ansi fg="username">%s</ansi> out of the clan.`, member.CharacterName))
+			clanMessage(currentClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> was kicked out of the clan by <ansi fg="username">%s</ansi>.`, member.CharacterName, user.Character.Name), clans.ClanRankMember, user.UserId)
+
+			if kickedUser := users.GetByUserId(member.UserId); kickedUser != nil {
+				kickedUser.EventLog.Add(`clan`, fmt.Sprintf(`Kicked from the clan <ansi fg="clantag">%s</ansi>`, currentClan.String()))
+				kickedUser.SendText(fmt.Sprintf(`You have been kicked out of <ansi fg="clantag">%s</ansi>.`, currentClan.String()))
+			}
+
+			return true, nil
+		}
+
+		if member.Rank == clans.ClanRankLeader {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is already a leader.`, member.CharacterName))
+			return true, nil
+		}
+
+		newRank := member.Rank.Next()
+		currentClan.SetRank(member.UserId, newRank)
+
+		clanMessage(currentClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> has been promoted to <ansi fg="white-bold">%s</ansi>.`, member.CharacterName, newRank), clans.ClanRankMember)
+
+		return true, nil
+	}
+
+	if clanCommand == `disband` {
+
+		cmdPrompt, _ := user.StartPrompt(`clan`, `disband`)
+		question := cmdPrompt.Ask(`Disband `+currentClan.String()+`? The treasury will be lost.`, []string{`yes`, `no`}, `no`)
+		if !question.Done {
+			return true, nil
+		}
+
+		user.ClearPrompt()
+
+		if question.Response != `yes` {
+			user.SendText(`Aborted.`)
+			return true, nil
+		}
+
+		clanMessage(currentClan, fmt.Sprintf(`<ansi fg="username">%s</ansi> has disbanded the clan.`, user.Character.Name), clans.ClanRankMember)
+
+		for _, m := range clans.Disband(currentClan.ClanTag) {
+			if u := users.GetByUserId(m.UserId); u != nil {
+				u.EventLog.Add(`clan`, fmt.Sprintf(`The clan <ansi fg="clantag">%s</ansi> was disbanded`, currentClan.String()))
+			}
+		}
+
+		events.AddToQueue(events.Broadcast{
+			Text: fmt.Sprintf(`The clan <ansi fg="clantag">%s</ansi> has been disbanded.`, currentClan.String()),
+		})
+
+		return true, nil
+	}
+
+	user.SendText(`Try <ansi fg="command">help clan</ansi> for more information about clans.`)
+
+	return true, nil
+}
+
+// Sends a message to all online clan members of at least minRank
+func clanMessage(c *clans.ClanInfo, msg string, minRank clans.ClanRank, excludeUserIds ...int) {
+
+	for _, m := range c.Members {
+
+		if m.Rank.Value() < minRank.Value() {
+			continue
+		}
+
+		skip := false
+		for _, exId := range excludeUserIds {
+			if exId == m.UserId {
+				skip = true
+				break
+			}
+		}
+
+		if skip {
+			continue
+		}
+
+		if u := users.GetByUserId(m.UserId); u != nil {
+			u.SendText(`<ansi fg="clantag">[` + c.ClanTag + `]</ansi> ` + msg)
+		}
+	}
+
+}
@@ -0,0 +1,91 @@
+package usercommands
+
+import (
+	"fmt"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+/*
+* Turns MCCP compression on or off for the current connection
+* compress 		- show status
+* compress on	- offer compression to the client
+* compress off	- stop compressing output
+ */
+func Compress(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	cd := connections.Get(user.ConnectionId())
+	if cd == nil {
+		return true, nil
+	}
+
+	if cd.IsWebSocket() || cd.IsSSH() {
+		user.SendText(`Compression is only available to telnet clients.`)
+		return true, nil
+	}
+
+	switch rest {
+	case `on`:
+
+		if !configs.GetNetworkConfig().Compression {
+			user.SendText(`Compression is disabled on this server.`)
+			return true, nil
+		}
+
+		if cd.IsCompressing() {
+			user.SendText(`Compression is already on.`)
+			return true, nil
+		}
+
+		// The client must agree to it before it begins
+		connections.SendTo(term.Mccp2Enable.BytesWithPayload(nil), user.ConnectionId())
+		user.SendText(`Compression has been offered to your client.`)
+
+		return true, nil
+
+	case `off`:
+
+		if !cd.IsCompressing() {
+			user.SendText(`Compression is already off.`)
+			return true, nil
+		}
+
+		if err := cd.StopCompression(); err != nil {
+			return true, err
+		}
+		connections.SendTo(term.Mccp2Disable.BytesWithPayload(nil), user.ConnectionId())
+		user.SendText(`Compression is now <ansi fg="red">off</ansi>.`)
+
+		return true, nil
+	}
+
+	status := `<ansi fg="red">off</ansi>`
+	if cd.IsCompressing() {
+		status = `<ansi fg="green">on</ansi>`
+	}
+
+	inStatus := `<ansi fg="red">off</ansi>`
+	if cd.IsDecompressing() {
+		inStatus = `<ansi fg="green">on</ansi>`
+	}
+
+	s := cd.CompressionStats()
+
+	user.SendText(``)
+	user.SendText(fmt.Sprintf(`Output compression (MCCP2): %s`, status))
+	user.SendText(fmt.Sprintf(`Input compression (MCCP3):  %s`, inStatus))
+	user.SendText(fmt.Sprintf(`Sent:     <ansi fg="yellow">%s</ansi> (<ansi fg="yellow">%s</ansi> uncompressed)`, util.FormatBytes(s.BytesOutSent), util.FormatBytes(s.BytesOutRaw)))
+	user.SendText(fmt.Sprintf(`Received: <ansi fg="yellow">%s</ansi> (<ansi fg="yellow">%s</ansi> uncompressed)`, util.FormatBytes(s.BytesInRecv), util.FormatBytes(s.BytesInRaw)))
+	user.SendText(fmt.Sprintf(`Saved:    <ansi fg="green">%s</ansi> (%.1f%%)`, util.FormatBytes(s.BytesSaved()), s.PercentSaved()))
+	user.SendText(``)
+	user.SendText(`Type <ansi fg="command">compress on</ansi> or <ansi fg="command">compress off</ansi> to change it.`)
+	user.SendText(``)
+
+	return true, nil
+}
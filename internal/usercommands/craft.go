@@ -0,0 +1,220 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/crafting"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func Recipes(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	allRecipes := crafting.GetAllRecipes()
+
+	if len(allRecipes) == 0 {
+		user.SendText(`There is nothing to craft.`)
+		return true, nil
+	}
+
+	backpackCounts := backpackItemCounts(user)
+
+	headers := []string{`Name`, `Makes`, `Ingredients`, `Requires`, `Chance`}
+	rows := [][]string{}
+
+	for _, recipe := range allRecipes {
+
+		outputName := craftItemName(recipe.OutputItemId)
+		if recipe.OutputQuantity > 1 {
+			outputName = fmt.Sprintf(`%dx %s`, recipe.OutputQuantity, outputName)
+		}
+
+		ingredientCounts := recipe.IngredientCounts()
+		ingredients := []string{}
+		for _, ing := range recipe.Ingredients {
+			color := `itemname`
+			if backpackCounts[ing.ItemId] < ingredientCounts[ing.ItemId] {
+				color = `red`
+			}
+			ingredients = append(ingredients, fmt.Sprintf(`<ansi fg="%s">%dx %s</ansi>`, color, ing.Quantity, craftItemName(ing.ItemId)))
+		}
+
+		requires := []string{}
+		for _, toolId := range recipe.Tools {
+			color := `itemname`
+			if backpackCounts[toolId] < 1 {
+				color = `red`
+			}
+			requires = append(requires, fmt.Sprintf(`<ansi fg="%s">%s</ansi>`, color, craftItemName(toolId)))
+		}
+		if recipe.Skill != `` {
+			color := `skill`
+			if user.Character.GetSkillLevel(recipe.Skill) < recipe.SkillLevel {
+				color = `red`
+			}
+			requires = append(requires, fmt.Sprintf(`<ansi fg="%s">%s %d</ansi>`, color, recipe.Skill, recipe.SkillLevel))
+		}
+		if recipe.RoomId > 0 {
+			color := `room-title`
+			if recipe.RoomId != room.RoomId {
+				color = `red`
+			}
+			if craftRoom := rooms.LoadRoom(recipe.RoomId); craftRoom != nil {
+				requires = append(requires, fmt.Sprintf(`<ansi fg="%s">%s</ansi>`, color, craftRoom.Title))
+			}
+		}
+
+		rows = append(rows, []string{
+			recipe.Name,
+			`<ansi fg="itemname">` + outputName + `</ansi>`,
+			strings.Join(ingredients, `, `),
+			strings.Join(requires, `, `),
+			strconv.Itoa(recipe.Chance) + `%`,
+		})
+	}
+
+	recipeTableData := templates.GetTable(`Recipes`, headers, rows)
+	tplTxt, _ := templates.Process("tables/generic", recipeTableData, user.UserId, user.UserId)
+	user.SendText(tplTxt)
+	user.SendText(`To craft something, type: <ansi fg="command">craft [recipe name]</ansi>`)
+
+	return true, nil
+}
+
+func Craft(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	if rest == `` {
+		return Recipes(rest, user, room, flags)
+	}
+
+	recipe := crafting.FindRecipe(rest)
+	if recipe == nil {
+		user.SendText(fmt.Sprintf(`You don't know how to craft "%s". Type <ansi fg="command">recipes</ansi> to see what you can make.`, rest))
+		return true, nil
+	}
+
+	if user.Character.Aggro != nil {
+		user.SendText(`You can't do that while in combat!`)
+		return true, nil
+	}
+
+	if recipe.RoomId > 0 && recipe.RoomId != room.RoomId {
+		where := `somewhere else`
+		if craftRoom := rooms.LoadRoom(recipe.RoomId); craftRoom != nil {
+			where = fmt.Sprintf(`in <ansi fg="room-title">%s</ansi>`, craftRoom.Title)
+		}
+		user.SendText(fmt.Sprintf(`A <ansi fg="itemname">%s</ansi> can only be crafted %s.`, recipe.Name, where))
+		return true, nil
+	}
+
+	if recipe.Skill != `` && user.Character.GetSkillLevel(recipe.Skill) < recipe.SkillLevel {
+		user.SendText(fmt.Sprintf(`You need level %d <ansi fg="skill">%s</ansi> skill to craft that.`, recipe.SkillLevel, recipe.Skill))
+		return true, nil
+	}
+
+	backpackCounts := backpackItemCounts(user)
+
+	for _, toolId := range recipe.Tools {
+		if backpackCounts[toolId] < 1 {
+			user.SendText(fmt.Sprintf(`You need a <ansi fg="itemname">%s</ansi> to craft that.`, craftItemName(toolId)))
+			return true, nil
+		}
+	}
+
+	ingredientCounts := recipe.IngredientCounts()
+	for itemId, qty := range ingredientCounts {
+		if backpackCounts[itemId] < qty {
+			user.SendText(fmt.Sprintf(`You need %d <ansi fg="itemname">%s</ansi> to craft that, but only have %d.`, qty, craftItemName(itemId), backpackCounts[itemId]))
+			return true, nil
+		}
+	}
+
+	user.Character.CancelBuffsWithFlag(buffs.Hidden)
+
+	// Ingredients are used up whether or not it works out
+	for itemId, qty := range ingredientCounts {
+		for _, itm := range user.Character.GetAllBackpackItems() {
+			if qty < 1 {
+				break
+			}
+			if itm.ItemId != itemId {
+				continue
+			}
+
+			user.Character.RemoveItem(itm)
+			qty--
+
+			events.AddToQueue(events.ItemOwnership{
+				UserId: user.UserId,
+				Item:   itm,
+				Gained: false,
+			})
+		}
+	}
+
+	roll := util.Rand(100)
+
+	util.LogRoll(`Craft`, roll, recipe.Chance)
+
+	if roll >= recipe.Chance {
+
+		user.SendText(fmt.Sprintf(`You try to craft a <ansi fg="itemname">%s</ansi>, but ruin the ingredients.`, recipe.Name))
+		room.SendText(
+			fmt.Sprintf(`<ansi fg="username">%s</ansi> tries to craft something, but ruins it.`, user.Character.Name),
+			user.UserId,
+		)
+
+		return true, nil
+	}
+
+	for i := 0; i < recipe.OutputQuantity; i++ {
+
+		newItm := items.New(recipe.OutputItemId)
+		if newItm.ItemId == 0 {
+			break
+		}
+
+		// Give any script a chance to modify the crafted item
+		scripting.TryRecipeScriptEvent(`onCraft`, user.UserId, recipe.RecipeId, &newItm)
+
+		user.Character.StoreItem(newItm)
+
+		events.AddToQueue(events.ItemOwnership{
+			UserId: user.UserId,
+			Item:   newItm,
+			Gained: true,
+		})
+
+		user.EventLog.Add(`craft`, fmt.Sprintf(`Crafted a <ansi fg="itemname">%s</ansi>`, newItm.DisplayName()))
+
+		user.SendText(fmt.Sprintf(`You craft a <ansi fg="itemname">%s</ansi>.`, newItm.DisplayName()))
+		room.SendText(
+			fmt.Sprintf(`<ansi fg="username">%s</ansi> crafts a <ansi fg="itemname">%s</ansi>.`, user.Character.Name, newItm.DisplayName()),
+			user.UserId,
+		)
+	}
+
+	return true, nil
+}
+
+// Returns a map of itemId => how many of that item are in the users backpack
+func backpackItemCounts(user *users.UserRecord) map[int]int {
+	counts := map[int]int{}
+	for _, itm := range user.Character.GetAllBackpackItems() {
+		counts[itm.ItemId]++
+	}
+	return counts
+}
+
+func craftItemName(itemId int) string {
+	itm := items.New(itemId)
+	return itm.DisplayName()
+}
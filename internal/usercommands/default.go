@@ -34,6 +34,18 @@ func Default(rest string, user *users.UserRecord, room *rooms.Room, flags events
 		return true, nil
 	}
 
+	// If a residential area, "house"
+	if room.IsHousing {
+		House(``, user, room, flags)
+		return true, nil
+	}
+
+	// If an arena, "duel"
+	if room.IsArena {
+		Duel(``, user, room, flags)
+		return true, nil
+	}
+
 	// Default to "look"
 	Look(``, user, room, flags)
 
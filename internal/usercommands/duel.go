@@ -0,0 +1,358 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/duels"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/templates"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func Duel(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) == 0 {
+		return duelStatus(user, room)
+	}
+
+	duelCmd := strings.ToLower(args[0])
+
+	switch duelCmd {
+	case `accept`:
+		return duelAccept(user, room)
+	case `decline`, `cancel`:
+		return duelDecline(user)
+	case `yield`, `surrender`, `forfeit`:
+		return duelYield(user)
+	case `bet`:
+		return duelBet(args[1:], user, room)
+	}
+
+	return duelChallenge(rest, user, room)
+}
+
+func duelChallenge(targetName string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if room.RoomId == -1 || room.RoomId == int(configs.GetSpecialRoomsConfig().DeathRecoveryRoom) {
+		user.SendText(`Fighting is not allowed here.`)
+		return true, nil
+	}
+
+	targetUserId, _ := room.FindByName(targetName, rooms.FindAll)
+	targetUser := users.GetByUserId(targetUserId)
+	if targetUser == nil {
+		user.SendText(fmt.Sprintf(`%s not found.`, targetName))
+		return true, nil
+	}
+
+	if targetUser.UserId == user.UserId {
+		user.SendText(`You can't duel yourself.`)
+		return true, nil
+	}
+
+	if targetUser.IsIgnoring(user.UserId) {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is ignoring you.`, targetUser.Character.Name))
+		return true, nil
+	}
+
+	if duels.Get(user.UserId) != nil {
+		user.SendText(`You are already in a duel. Type <ansi fg="command">duel</ansi> to see how it stands.`)
+		return true, nil
+	}
+
+	if duels.Get(targetUser.UserId) != nil {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is already in a duel.`, targetUser.Character.Name))
+		return true, nil
+	}
+
+	if user.Character.Aggro != nil || duels.IsBeaten(user) {
+		user.SendText(`You're in no state to start a duel.`)
+		return true, nil
+	}
+
+	if targetUser.Character.Aggro != nil || duels.IsBeaten(targetUser) {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is in no state to fight a duel.`, targetUser.Character.Name))
+		return true, nil
+	}
+
+	duelConfig := configs.GetGamePlayConfig().Duels
+
+	if _, err := duels.Challenge(user.UserId, targetUser.UserId, room.RoomId, util.GetRoundCount()+uint64(duelConfig.ChallengeRounds)); err != nil {
+		user.SendText(fmt.Sprintf(`You can't challenge <ansi fg="username">%s</ansi>: %s.`, targetUser.Character.Name, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`You challenge <ansi fg="username">%s</ansi> to a duel!`, targetUser.Character.Name))
+	targetUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> challenges you to a duel! Type <ansi fg="command">duel accept</ansi> or <ansi fg="command">duel decline</ansi> to respond.`, user.Character.Name))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> challenges <ansi fg="username">%s</ansi> to a duel!`, user.Character.Name, targetUser.Character.Name), user.UserId, targetUser.UserId)
+
+	return true, nil
+}
+
+func duelAccept(user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	d := duels.Get(user.UserId)
+	if d == nil || d.State != duels.Challenged || d.DefenderId != user.UserId {
+		user.SendText(`Nobody has challenged you to a duel.`)
+		return true, nil
+	}
+
+	challenger := users.GetByUserId(d.ChallengerId)
+	if challenger == nil || challenger.Character.RoomId != room.RoomId {
+		duels.Cancel(d)
+		user.SendText(`Whoever challenged you isn't here anymore.`)
+		return true, nil
+	}
+
+	if user.Character.Aggro != nil || duels.IsBeaten(user) {
+		user.SendText(`You're in no state to fight a duel.`)
+		return true, nil
+	}
+
+	duelConfig := configs.GetGamePlayConfig().Duels
+
+	// Arenas hold off the fight so spectators can place their bets
+	roundNow := util.GetRoundCount()
+	startRound := roundNow
+	standRoomIds := []int{}
+
+	if room.IsArena {
+		standRoomIds = room.ArenaStands
+		if duelConfig.MaxBet > 0 {
+			startRound += uint64(duelConfig.BettingRounds)
+		}
+	}
+
+	if err := d.Accept(room.IsArena, standRoomIds, startRound, roundNow); err != nil {
+		user.SendText(fmt.Sprintf(`You can't accept: %s.`, err))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`You accept the duel with <ansi fg="username">%s</ansi>. It ends when one of you is beaten, but nobody will die.`, challenger.Character.Name))
+	challenger.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> accepts your duel. It ends when one of you is beaten, but nobody will die.`, user.Character.Name))
+
+	if d.State == duels.Starting {
+		d.Announce(fmt.Sprintf(`<ansi fg="yellow-bold">***</ansi> <ansi fg="username">%s</ansi> will duel <ansi fg="username">%s</ansi> in <ansi fg="white-bold">%d</ansi> rounds! Type <ansi fg="command">duel bet [name] [gold]</ansi> to place your bets. <ansi fg="yellow-bold">***</ansi>`,
+			challenger.Character.Name, user.Character.Name, startRound-roundNow), challenger.UserId, user.UserId)
+		return true, nil
+	}
+
+	challenger.Character.SetAggro(user.UserId, 0, characters.DefaultAttack)
+	user.Character.SetAggro(challenger.UserId, 0, characters.DefaultAttack)
+
+	d.Announce(fmt.Sprintf(`<ansi fg="yellow-bold">***</ansi> The duel between <ansi fg="username">%s</ansi> and <ansi fg="username">%s</ansi> begins! <ansi fg="yellow-bold">***</ansi>`, challenger.Character.Name, user.Character.Name))
+
+	return true, nil
+}
+
+func duelDecline(user *users.UserRecord) (bool, error) {
+
+	d := duels.Get(user.UserId)
+	if d == nil {
+		user.SendText(`You aren't in a duel.`)
+		return true, nil
+	}
+
+	if d.State != duels.Challenged {
+		user.SendText(`It's too late to back out now. Type <ansi fg="command">duel yield</ansi> to give up.`)
+		return true, nil
+	}
+
+	duels.Cancel(d)
+
+	opponent := users.GetByUserId(d.Opponent(user.UserId))
+	if opponent == nil {
+		user.SendText(`The duel is called off.`)
+		return true, nil
+	}
+
+	if d.ChallengerId == user.UserId {
+		user.SendText(fmt.Sprintf(`You withdraw your challenge to <ansi fg="username">%s</ansi>.`, opponent.Character.Name))
+		opponent.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> withdraws their duel challenge.`, user.Character.Name))
+	} else {
+		user.SendText(fmt.Sprintf(`You decline the duel with <ansi fg="username">%s</ansi>.`, opponent.Character.Name))
+		opponent.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> declines your duel challenge.`, user.Character.Name))
+	}
+
+	return true, nil
+}
+
+func duelYield(user *users.UserRecord) (bool, error) {
+
+	d := duels.Get(user.UserId)
+	if d == nil {
+		user.SendText(`You aren't in a duel.`)
+		return true, nil
+	}
+
+	if d.State == duels.Challenged {
+		user.SendText(`The duel hasn't started. Type <ansi fg="command">duel decline</ansi> to call it off.`)
+		return true, nil
+	}
+
+	user.SendText(`You yield the duel.`)
+	d.Announce(fmt.Sprintf(`<ansi fg="username">%s</ansi> yields!`, user.Character.Name), user.UserId)
+
+	duels.End(d, d.Opponent(user.UserId))
+
+	return true, nil
+}
+
+func duelBet(args []string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	maxBet := int(configs.GetGamePlayConfig().Duels.MaxBet)
+	if maxBet < 1 {
+		user.SendText(`Betting on duels isn't allowed.`)
+		return true, nil
+	}
+
+	if len(args) < 2 {
+		user.SendText(`Bet how much on who? Example: <ansi fg="command">duel bet bob 100</ansi>`)
+		return true, nil
+	}
+
+	gold, err := strconv.Atoi(strings.TrimSuffix(strings.ToLower(args[1]), `gold`))
+	if err != nil || gold < 1 {
+		user.SendText(`Bet how much gold?`)
+		return true, nil
+	}
+
+	// Find the duelist being bet on among the arena duels that can be seen from here
+	var betDuel *duels.Duel
+	var duelist *users.UserRecord
+
+	for _, d := range duels.GetAll() {
+
+		if !d.Arena || !d.IsWatchedFrom(room.RoomId) {
+			continue
+		}
+
+		challenger := users.GetByUserId(d.ChallengerId)
+		defender := users.GetByUserId(d.DefenderId)
+		if challenger == nil || defender == nil {
+			continue
+		}
+
+		match, closeMatch := util.FindMatchIn(args[0], challenger.Character.Name, defender.Character.Name)
+		if match == `` {
+			match = closeMatch
+		}
+
+		if match == challenger.Character.Name {
+			betDuel, duelist = d, challenger
+		} else if match == defender.Character.Name {
+			betDuel, duelist = d, defender
+		}
+
+		if betDuel != nil {
+			break
+		}
+	}
+
+	if betDuel == nil {
+		user.SendText(fmt.Sprintf(`There's no arena duel with %s that you can bet on here.`, args[0]))
+		return true, nil
+	}
+
+	if gold > maxBet {
+		user.SendText(fmt.Sprintf(`The most you can bet is <ansi fg="gold">%d gold</ansi>.`, maxBet))
+		return true, nil
+	}
+
+	if gold > user.Character.Gold {
+		user.SendText(`You don't have that much gold.`)
+		return true, nil
+	}
+
+	if err := betDuel.PlaceBet(user.UserId, user.Username, duelist.UserId, gold); err != nil {
+		user.SendText(fmt.Sprintf(`You can't bet: %s.`, err))
+		return true, nil
+	}
+
+	user.Character.Gold -= gold
+
+	user.SendText(fmt.Sprintf(`You bet <ansi fg="gold">%d gold</ansi> on <ansi fg="username">%s</ansi>.`, gold, duelist.Character.Name))
+	betDuel.Announce(fmt.Sprintf(`<ansi fg="username">%s</ansi> bets <ansi fg="gold">%d gold</ansi> on <ansi fg="username">%s</ansi>.`, user.Character.Name, gold, duelist.Character.Name), user.UserId)
+
+	return true, nil
+}
+
+func duelStatus(user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if d := duels.Get(user.UserId); d != nil {
+
+		opponentName := `someone`
+		if opponent := users.GetByUserId(d.Opponent(user.UserId)); opponent != nil {
+			opponentName = opponent.Character.Name
+		}
+
+		switch {
+		case d.State == duels.Challenged && d.ChallengerId == user.UserId:
+			user.SendText(fmt.Sprintf(`You have challenged <ansi fg="username">%s</ansi> to a duel. Type <ansi fg="command">duel cancel</ansi> to withdraw it.`, opponentName))
+		case d.State == duels.Challenged:
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has challenged you to a duel. Type <ansi fg="command">duel accept</ansi> or <ansi fg="command">duel decline</ansi> to respond.`, opponentName))
+		case d.State == duels.Starting:
+			user.SendText(fmt.Sprintf(`Your duel with <ansi fg="username">%s</ansi> starts once the betting closes.`, opponentName))
+		default:
+			user.SendText(fmt.Sprintf(`You are fighting a duel with <ansi fg="username">%s</ansi>. Type <ansi fg="command">duel yield</ansi> to give up.`, opponentName))
+		}
+	}
+
+	headers := []string{`Duel`, `Status`, `Bets`}
+	rows := [][]string{}
+	roundNow := util.GetRoundCount()
+
+	for _, d := range duels.GetAll() {
+
+		if d.State == duels.Challenged || !d.IsWatchedFrom(room.RoomId) {
+			continue
+		}
+
+		challenger := users.GetByUserId(d.ChallengerId)
+		defender := users.GetByUserId(d.DefenderId)
+		if challenger == nil || defender == nil {
+			continue
+		}
+
+		status := `Fighting`
+		if d.State == duels.Starting {
+			roundsLeft := uint64(0)
+			if d.StateRound > roundNow {
+				roundsLeft = d.StateRound - roundNow
+			}
+			status = fmt.Sprintf(`Betting (%d rounds)`, roundsLeft)
+		}
+
+		bets := `-`
+		if d.Arena {
+			bets = fmt.Sprintf(`%d / %d gold`, d.BetTotal(challenger.UserId), d.BetTotal(defender.UserId))
+		}
+
+		rows = append(rows, []string{
+			fmt.Sprintf(`%s vs %s`, challenger.Character.Name, defender.Character.Name),
+			status,
+			bets,
+		})
+	}
+
+	if len(rows) > 0 {
+		duelTable := templates.GetTable(`Duels`, headers, rows)
+		tplTxt, _ := templates.Process("tables/generic", duelTable, user.UserId)
+		user.SendText(tplTxt)
+		return true, nil
+	}
+
+	if duels.Get(user.UserId) == nil {
+		user.SendText(`You aren't in a duel. Type <ansi fg="command">duel [player]</ansi> to challenge someone.`)
+	}
+
+	return true, nil
+}
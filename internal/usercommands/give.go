@@ -66,10 +66,15 @@ func Give(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 
 	if playerId > 0 {
 
-		user.Character.CancelBuffsWithFlag(buffs.Hidden)
-
 		targetUser := users.GetByUserId(playerId)
 
+		if targetUser.IsIgnoring(user.UserId) {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is ignoring you.`, targetUser.Character.Name))
+			return true, nil
+		}
+
+		user.Character.CancelBuffsWithFlag(buffs.Hidden)
+
 		// Swap the item location
 		if giveItem.ItemId > 0 {
 			targetUser.Character.StoreItem(giveItem)
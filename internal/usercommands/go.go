@@ -44,16 +44,11 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room, flags events.Even
 			return true, nil
 		}
 
-		actionCost := 10
-		encumbered := false
-		if len(user.Character.Items) > user.Character.CarryCapacity() {
-			actionCost = 50
-			encumbered = true
-		}
+		actionCost := user.Character.MoveActionCost()
 
 		if !user.Character.DeductActionPoints(actionCost) {
 
-			if encumbered {
+			if user.Character.IsEncumbered() {
 				user.SendText("You're too encumbered to move (<ansi fg=\"command\">help encumbrance</ansi>)!")
 			} else {
 				user.SendText("You're too tired to move (slow down)!")
@@ -183,6 +178,13 @@ func Go(rest string, user *users.UserRecord, room *rooms.Room, flags events.Even
 			user.SendText("Oops, couldn't move there!")
 		} else {
 
+			// Entering an instanced zone may have led somewhere else
+			if user.Character.RoomId != destRoom.RoomId {
+				if instanceRoom := rooms.LoadRoom(user.Character.RoomId); instanceRoom != nil {
+					destRoom = instanceRoom
+				}
+			}
+
 			scripting.TryRoomScriptEvent(`onExit`, user.UserId, originRoomId)
 
 			// Tell the player they are moving
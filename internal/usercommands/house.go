@@ -0,0 +1,533 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/mapper"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/term"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/ansitags"
+)
+
+const (
+	// Homes are seen by everyone who visits, so players only get so much room to work with
+	houseTitleSizeMax       = 40
+	houseDescriptionSizeMax = 1000
+)
+
+func House(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	housingConfig := configs.GetGamePlayConfig().Housing
+
+	if !housingConfig.Enabled {
+		user.SendText(`Player housing is not available.`)
+		return true, nil
+	}
+
+	args := util.SplitButRespectQuotes(rest)
+
+	if len(args) == 0 {
+		houseInfo(user, room)
+		return true, nil
+	}
+
+	cmd := strings.ToLower(args[0])
+	args = args[1:]
+
+	switch cmd {
+	case `buy`:
+		return houseBuy(user, room)
+	case `enter`:
+		return houseEnter(strings.Join(args, ` `), user, room)
+	}
+
+	// Everything else is the owner changing their home
+	if room.HomeOwner != user.UserId {
+		user.SendText(`You can only do that inside your own home.`)
+		return true, nil
+	}
+
+	switch cmd {
+	case `title`:
+		return houseTitle(strings.Join(args, ` `), user, room)
+	case `describe`:
+		return houseDescribe(strings.Join(args, ` `), user, room)
+	case `expand`:
+		return houseExpand(strings.Join(args, ` `), user, room)
+	case `lock`:
+		return houseLock(args, user, room)
+	case `guest`, `guests`:
+		return houseGuest(args, user, room)
+	case `furnish`:
+		return houseFurnish(strings.Join(args, ` `), user, room)
+	case `unfurnish`:
+		return houseUnfurnish(strings.Join(args, ` `), user, room)
+	}
+
+	user.SendText(`Try <ansi fg="command">help house</ansi> for more information about player housing.`)
+	return true, nil
+}
+
+func houseInfo(user *users.UserRecord, room *rooms.Room) {
+
+	housingConfig := configs.GetGamePlayConfig().Housing
+
+	user.SendText(``)
+
+	if homeRoomIds := rooms.GetHomeRoomIds(user.UserId); len(homeRoomIds) > 0 {
+		user.SendText(fmt.Sprintf(`You own a home with <ansi fg="white-bold">%d</ansi> of <ansi fg="white-bold">%d</ansi> rooms.`, len(homeRoomIds), housingConfig.MaxRooms))
+		if room.IsHousing {
+			user.SendText(`Type <ansi fg="command">house enter</ansi> to go home.`)
+		}
+	} else {
+		user.SendText(fmt.Sprintf(`A home costs <ansi fg="gold">%d gold</ansi>.`, housingConfig.Price))
+		if room.IsHousing {
+			user.SendText(`Type <ansi fg="command">house buy</ansi> to buy one here.`)
+		} else {
+			user.SendText(`Homes can only be bought in residential areas.`)
+		}
+	}
+
+	if room.HomeOwner == user.UserId {
+		user.SendText(fmt.Sprintf(`Additional rooms cost <ansi fg="gold">%d gold</ansi>, and furniture costs <ansi fg="gold">%d gold</ansi>.`, housingConfig.RoomPrice, housingConfig.FurniturePrice))
+	}
+
+	user.SendText(`Try <ansi fg="command">help house</ansi> for more information about player housing.` + term.CRLFStr)
+}
+
+func houseBuy(user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	housingConfig := configs.GetGamePlayConfig().Housing
+
+	if !room.IsHousing {
+		user.SendText(`Homes can only be bought in residential areas.`)
+		return true, nil
+	}
+
+	if rooms.GetHomeEntranceId(user.UserId) > 0 {
+		user.SendText(`You already own a home. Type <ansi fg="command">house enter</ansi> to go there.`)
+		return true, nil
+	}
+
+	price := int(housingConfig.Price)
+	if price > user.Character.Gold {
+		user.SendText(fmt.Sprintf(`A home costs <ansi fg="gold">%d gold</ansi>, which you don't have.`, price))
+		return true, nil
+	}
+
+	home, err := rooms.CreateHome(user.UserId, room.RoomId)
+	if err != nil {
+		user.SendText(`Something went wrong buying your home.`)
+		return true, err
+	}
+
+	user.Character.Gold -= price
+
+	events.AddToQueue(events.EquipmentChange{
+		UserId:     user.UserId,
+		GoldChange: -price,
+	})
+
+	user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> and receive the keys to your new home!`, price))
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> just bought a home.`, user.Character.Name), user.UserId)
+
+	return houseMoveIn(user, room, home)
+}
+
+func houseEnter(ownerName string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if !room.IsHousing {
+		user.SendText(`You can only enter homes from residential areas.`)
+		return true, nil
+	}
+
+	ownerId := user.UserId
+	if ownerName != `` {
+		if ownerId, _ = users.CharacterNameSearch(ownerName); ownerId == 0 {
+			user.SendText(fmt.Sprintf(`Nobody named <ansi fg="username">%s</ansi> could be found.`, ownerName))
+			return true, nil
+		}
+	}
+
+	home := rooms.GetHomeEntrance(ownerId)
+	if home == nil {
+		if ownerId == user.UserId {
+			user.SendText(`You don't own a home. Type <ansi fg="command">house buy</ansi> to buy one.`)
+		} else {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> doesn't own a home.`, ownerName))
+		}
+		return true, nil
+	}
+
+	if !home.CanVisitHome(user.UserId) && !user.HasRolePermission(`room`) {
+		user.SendText(fmt.Sprintf(`You aren't on the guest list of <ansi fg="username">%s</ansi>.`, ownerName))
+		return true, nil
+	}
+
+	return houseMoveIn(user, room, home)
+}
+
+// Moves a user into a home, making sure owners have a key for every lock in it.
+func houseMoveIn(user *users.UserRecord, room *rooms.Room, home *rooms.Room) (bool, error) {
+
+	if home.HomeOwner == user.UserId {
+		for _, lockId := range rooms.GetHomeLockIds(user.UserId) {
+			user.Character.SetKey(`key-`+lockId, `home`)
+		}
+	}
+
+	if err := rooms.MoveToRoom(user.UserId, home.RoomId); err != nil {
+		user.SendText(err.Error())
+		return true, err
+	}
+
+	room.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> heads inside a home.`, user.Character.Name), user.UserId)
+	home.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> comes in from outside.`, user.Character.Name), user.UserId)
+
+	events.AddToQueue(events.Input{
+		UserId:    user.UserId,
+		InputText: `look`,
+	}, -1)
+
+	return true, nil
+}
+
+func houseTitle(title string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	title = strings.TrimSpace(ansitags.Parse(title, ansitags.StripTags))
+	if title == `` {
+		user.SendText(`What should the title of this room be?`)
+		return true, nil
+	}
+
+	if utf8.RuneCountInString(title) > houseTitleSizeMax {
+		user.SendText(fmt.Sprintf(`Room titles can't be longer than <ansi fg="white-bold">%d</ansi> characters.`, houseTitleSizeMax))
+		return true, nil
+	}
+
+	room.Title = title
+	rooms.SaveRoom(*room)
+
+	user.SendText(fmt.Sprintf(`This room is now called <ansi fg="room-title">%s</ansi>.`, title))
+
+	return true, nil
+}
+
+func houseDescribe(description string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	description = strings.TrimSpace(ansitags.Parse(description, ansitags.StripTags))
+	if description == `` {
+		user.SendText(`How should this room be described?`)
+		return true, nil
+	}
+
+	if utf8.RuneCountInString(description) > houseDescriptionSizeMax {
+		user.SendText(fmt.Sprintf(`Room descriptions can't be longer than <ansi fg="white-bold">%d</ansi> characters.`, houseDescriptionSizeMax))
+		return true, nil
+	}
+
+	room.SetDescription(description)
+	rooms.SaveRoom(*room)
+
+	user.SendText(`You update the description of this room.`)
+
+	return true, nil
+}
+
+func houseExpand(direction string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	housingConfig := configs.GetGamePlayConfig().Housing
+
+	direction = strings.ToLower(strings.TrimSpace(direction))
+	if direction == `` {
+		user.SendText(`Which direction should the new room be built in?`)
+		return true, nil
+	}
+
+	if len(rooms.GetHomeRoomIds(user.UserId)) >= int(housingConfig.MaxRooms) {
+		user.SendText(fmt.Sprintf(`Your home can't have more than <ansi fg="white-bold">%d</ansi> rooms.`, housingConfig.MaxRooms))
+		return true, nil
+	}
+
+	price := int(housingConfig.RoomPrice)
+	if price > user.Character.Gold {
+		user.SendText(fmt.Sprintf(`A new room costs <ansi fg="gold">%d gold</ansi>, which you don't have.`, price))
+		return true, nil
+	}
+
+	// Homes share a zone but aren't connected to each other, so map just this home
+	homeMapper := mapper.NewMapper(rooms.GetHomeEntranceId(user.UserId))
+	homeMapper.Start()
+
+	if adjacentRoomId, _ := homeMapper.FindAdjacentRoom(room.RoomId, direction, 1); adjacentRoomId > 0 {
+		user.SendText(`There is already a room in that direction.`)
+		return true, nil
+	}
+
+	if _, err := rooms.ExpandHome(room.RoomId, direction); err != nil {
+		user.SendText(fmt.Sprintf(`You can't build there: %s.`, err.Error()))
+		return true, nil
+	}
+
+	user.Character.Gold -= price
+
+	events.AddToQueue(events.EquipmentChange{
+		UserId:     user.UserId,
+		GoldChange: -price,
+	})
+
+	user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> to have a new room built to the <ansi fg="exit">%s</ansi>.`, price, direction))
+	room.SendText(fmt.Sprintf(`Workers quickly build a new room to the <ansi fg="exit">%s</ansi>.`, direction), user.UserId)
+
+	return true, nil
+}
+
+func houseLock(args []string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	if len(args) < 2 || (args[0] != `add` && args[0] != `remove`) {
+		user.SendText(`Try <ansi fg="command">house lock add [exit/furniture]</ansi> or <ansi fg="command">house lock remove [exit/furniture]</ansi>.`)
+		return true, nil
+	}
+
+	addLock := args[0] == `add`
+	lockName := strings.Join(args[1:], ` `)
+
+	difficulty := uint8(0)
+	if addLock {
+		difficulty = rooms.HomeLockDifficulty
+	}
+
+	lockId := ``
+	lockDisplay := ``
+
+	if containerName := room.FindContainerByName(lockName); containerName != `` {
+
+		container := room.Containers[containerName]
+		if container.HasLock() == addLock {
+			if addLock {
+				user.SendText(fmt.Sprintf(`The <ansi fg="container">%s</ansi> already has a lock.`, containerName))
+			} else {
+				user.SendText(fmt.Sprintf(`The <ansi fg="container">%s</ansi> has no lock.`, containerName))
+			}
+			return true, nil
+		}
+
+		container.Lock.Difficulty = difficulty
+		container.Lock.SetLocked()
+		room.Containers[containerName] = container
+
+		lockId = fmt.Sprintf(`%d-%s`, room.RoomId, containerName)
+		lockDisplay = fmt.Sprintf(`<ansi fg="container">%s</ansi>`, containerName)
+
+	} else if exitName, _ := room.FindExitByName(lockName); exitName != `` {
+
+		exitInfo, ok := room.Exits[exitName]
+		if !ok {
+			user.SendText(`That exit can't be locked.`)
+			return true, nil
+		}
+
+		if exitName == rooms.HomeExitName {
+			user.SendText(fmt.Sprintf(`The <ansi fg="exit">%s</ansi> exit can't be locked.`, exitName))
+			return true, nil
+		}
+
+		if exitInfo.HasLock() == addLock {
+			if addLock {
+				user.SendText(fmt.Sprintf(`The <ansi fg="exit">%s</ansi> exit already has a lock.`, exitName))
+			} else {
+				user.SendText(fmt.Sprintf(`The <ansi fg="exit">%s</ansi> exit has no lock.`, exitName))
+			}
+			return true, nil
+		}
+
+		exitInfo.Lock.Difficulty = difficulty
+		exitInfo.Lock.SetLocked()
+		room.Exits[exitName] = exitInfo
+
+		lockId = fmt.Sprintf(`%d-%s`, room.RoomId, exitName)
+		lockDisplay = fmt.Sprintf(`<ansi fg="exit">%s</ansi> exit`, exitName)
+
+	} else {
+		user.SendText(fmt.Sprintf(`There is no exit or furniture called "%s" here.`, lockName))
+		return true, nil
+	}
+
+	rooms.SaveRoom(*room)
+
+	if addLock {
+		user.Character.SetKey(`key-`+lockId, `home`)
+		user.SendText(fmt.Sprintf(`You have a lock fitted to the %s, and add its key to your key ring.`, lockDisplay))
+	} else {
+		user.Character.SetKey(`key-`+lockId, ``)
+		user.SendText(fmt.Sprintf(`You have the lock removed from the %s.`, lockDisplay))
+	}
+
+	return true, nil
+}
+
+func houseGuest(args []string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	housingConfig := configs.GetGamePlayConfig().Housing
+
+	entrance := rooms.GetHomeEntrance(user.UserId)
+	if entrance == nil {
+		return true, nil
+	}
+
+	if len(args) < 2 || (args[0] != `add` && args[0] != `remove`) {
+
+		if len(entrance.HomeGuests) == 0 {
+			user.SendText(`Your guest list is empty.`)
+		} else {
+			guestNames := []string{}
+			for _, guest := range entrance.HomeGuests {
+				guestNames = append(guestNames, fmt.Sprintf(`<ansi fg="username">%s</ansi>`, guest.CharacterName))
+			}
+			user.SendText(fmt.Sprintf(`Your guest list: %s`, strings.Join(guestNames, `, `)))
+		}
+		user.SendText(`Type <ansi fg="command">house guest add [name]</ansi> or <ansi fg="command">house guest remove [name]</ansi> to change it.`)
+
+		return true, nil
+	}
+
+	guestName := strings.Join(args[1:], ` `)
+
+	if args[0] == `remove` {
+
+		guest, found := entrance.RemoveHomeGuest(guestName)
+		if !found {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> isn't on your guest list.`, guestName))
+			return true, nil
+		}
+
+		rooms.SaveRoom(*entrance)
+
+		user.SendText(fmt.Sprintf(`You remove <ansi fg="username">%s</ansi> from your guest list.`, guest.CharacterName))
+
+		// Show them the door if they are still here
+		if guestUser := users.GetByUserId(guest.UserId); guestUser != nil {
+			if guestRoom := rooms.LoadRoom(guestUser.Character.RoomId); guestRoom != nil && guestRoom.HomeOwner == user.UserId {
+				if outRoomId := entrance.Exits[rooms.HomeExitName].RoomId; outRoomId != 0 {
+					guestUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has asked you to leave.`, user.Character.Name))
+					rooms.MoveToRoom(guestUser.UserId, outRoomId)
+				}
+			}
+		}
+
+		return true, nil
+	}
+
+	if len(entrance.HomeGuests) >= int(housingConfig.MaxGuests) {
+		user.SendText(fmt.Sprintf(`Your guest list can't have more than <ansi fg="white-bold">%d</ansi> names on it.`, housingConfig.MaxGuests))
+		return true, nil
+	}
+
+	guestId := 0
+	if guestUser := users.GetByCharacterName(guestName); guestUser != nil {
+		guestId = guestUser.UserId
+		guestName = guestUser.Character.Name
+	} else if foundUserId, foundUsername := users.CharacterNameSearch(guestName); foundUserId > 0 {
+		guestId = foundUserId
+		if guestUser, err := users.LoadUser(foundUsername, true); err == nil && strings.EqualFold(guestUser.Character.Name, guestName) {
+			guestName = guestUser.Character.Name
+		}
+	}
+
+	if guestId == 0 {
+		user.SendText(fmt.Sprintf(`Nobody named <ansi fg="username">%s</ansi> could be found.`, guestName))
+		return true, nil
+	}
+
+	if guestId == user.UserId {
+		user.SendText(`You don't need to invite yourself.`)
+		return true, nil
+	}
+
+	if !entrance.AddHomeGuest(guestId, guestName) {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is already on your guest list.`, guestName))
+		return true, nil
+	}
+
+	rooms.SaveRoom(*entrance)
+
+	user.SendText(fmt.Sprintf(`You add <ansi fg="username">%s</ansi> to your guest list.`, guestName))
+
+	if guestUser := users.GetByUserId(guestId); guestUser != nil {
+		guestUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> has added you to the guest list of their home. Type <ansi fg="command">house enter %s</ansi> from any residential area to visit.`, user.Character.Name, user.Character.Name))
+	}
+
+	return true, nil
+}
+
+func houseFurnish(furnitureName string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	housingConfig := configs.GetGamePlayConfig().Housing
+
+	furnitureName = strings.ToLower(strings.TrimSpace(furnitureName))
+	if furnitureName == `` {
+		user.SendText(`What furniture would you like? For example: <ansi fg="command">house furnish chest</ansi>`)
+		return true, nil
+	}
+
+	if _, ok := room.Containers[furnitureName]; ok {
+		user.SendText(fmt.Sprintf(`There is already a <ansi fg="container">%s</ansi> here.`, furnitureName))
+		return true, nil
+	}
+
+	price := int(housingConfig.FurniturePrice)
+	if price > user.Character.Gold {
+		user.SendText(fmt.Sprintf(`Furniture costs <ansi fg="gold">%d gold</ansi>, which you don't have.`, price))
+		return true, nil
+	}
+
+	user.Character.Gold -= price
+
+	events.AddToQueue(events.EquipmentChange{
+		UserId:     user.UserId,
+		GoldChange: -price,
+	})
+
+	if room.Containers == nil {
+		room.Containers = map[string]rooms.Container{}
+	}
+	room.Containers[furnitureName] = rooms.Container{}
+
+	rooms.SaveRoom(*room)
+
+	user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> and a <ansi fg="container">%s</ansi> is delivered.`, price, furnitureName))
+	room.SendText(fmt.Sprintf(`A <ansi fg="container">%s</ansi> is delivered.`, furnitureName), user.UserId)
+
+	return true, nil
+}
+
+func houseUnfurnish(furnitureName string, user *users.UserRecord, room *rooms.Room) (bool, error) {
+
+	containerName := room.FindContainerByName(furnitureName)
+	if containerName == `` {
+		user.SendText(fmt.Sprintf(`There is no furniture called "%s" here.`, furnitureName))
+		return true, nil
+	}
+
+	container := room.Containers[containerName]
+	if len(container.Items) > 0 || container.Gold > 0 {
+		user.SendText(fmt.Sprintf(`The <ansi fg="container">%s</ansi> must be emptied first.`, containerName))
+		return true, nil
+	}
+
+	delete(room.Containers, containerName)
+	user.Character.SetKey(fmt.Sprintf(`key-%d-%s`, room.RoomId, containerName), ``)
+
+	rooms.SaveRoom(*room)
+
+	user.SendText(fmt.Sprintf(`You have the <ansi fg="container">%s</ansi> taken away.`, containerName))
+	room.SendText(fmt.Sprintf(`The <ansi fg="container">%s</ansi> is taken away.`, containerName), user.UserId)
+
+	return true, nil
+}
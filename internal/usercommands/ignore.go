@@ -0,0 +1,83 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+/*
+Stops another player's tells, says, shouts, channel messages, party invites and gifts from reaching you.
+Usage:
+
+	ignore
+	ignore Bob
+	unignore Bob
+*/
+func Ignore(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	rest = strings.TrimSpace(rest)
+
+	if rest == `` {
+
+		ignored := user.GetIgnored()
+		if len(ignored) == 0 {
+			user.SendText(`You aren't ignoring anyone.`)
+			return true, nil
+		}
+
+		names := make([]string, 0, len(ignored))
+		for _, i := range ignored {
+			names = append(names, fmt.Sprintf(`<ansi fg="username">%s</ansi>`, i.CharacterName))
+		}
+
+		user.SendText(fmt.Sprintf(`You are ignoring (%d/%d): %s`, len(ignored), users.MaxIgnored, strings.Join(names, `, `)))
+
+		return true, nil
+	}
+
+	targetUserId, targetName, targetIsStaff := findPlayerByName(rest)
+	if targetUserId == 0 {
+		user.SendText("You can't find anyone by that name.")
+		return true, nil
+	}
+
+	if err := user.Ignore(targetUserId, targetName, targetIsStaff); err != nil {
+		user.SendText(fmt.Sprintf(`You can't ignore <ansi fg="username">%s</ansi>: %s.`, targetName, err.Error()))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`You are now ignoring <ansi fg="username">%s</ansi>. Use <ansi fg="command">unignore %s</ansi> to hear from them again.`, targetName, targetName))
+
+	return true, nil
+}
+
+func Unignore(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	rest = strings.TrimSpace(rest)
+
+	if rest == `` {
+		user.SendText(`Unignore who?`)
+		return true, nil
+	}
+
+	// Check the saved names first, in case they have since been renamed or deleted
+	ignored, found := user.FindIgnored(rest)
+	if !found {
+		if targetUserId, targetName, _ := findPlayerByName(rest); targetUserId > 0 {
+			ignored = users.IgnoredUser{UserId: targetUserId, CharacterName: targetName}
+		}
+	}
+
+	if ignored.UserId == 0 || !user.Unignore(ignored.UserId) {
+		user.SendText(fmt.Sprintf(`You aren't ignoring <ansi fg="username">%s</ansi>.`, rest))
+		return true, nil
+	}
+
+	user.SendText(fmt.Sprintf(`You are no longer ignoring <ansi fg="username">%s</ansi>.`, ignored.CharacterName))
+
+	return true, nil
+}
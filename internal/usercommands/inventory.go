@@ -124,6 +124,9 @@ func Inventory(rest string, user *users.UserRecord, room *rooms.Room, flags even
 				iNameFormatted = fmt.Sprintf(`%s <ansi fg="uses-left">(%d)</ansi>`, iNameFormatted, item.Uses) // Display uses left
 			}
 		}
+		if conditionStr := item.ConditionTag(); conditionStr != `` {
+			iNameFormatted = fmt.Sprintf(`%s %s`, iNameFormatted, conditionStr) // Display condition if worn down
+		}
 		itemNames = append(itemNames, iName)
 		itemNamesFormatted = append(itemNamesFormatted, iNameFormatted)
 	}
@@ -2,6 +2,7 @@ package usercommands
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/GoMudEngine/GoMud/internal/events"
@@ -14,6 +15,10 @@ import (
 
 func Killstats(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
 
+	if rest == `duel` || rest == `duels` {
+		return killstatsDuels(user)
+	}
+
 	otherSuggestions := []string{}
 
 	var headers []string
@@ -99,6 +104,8 @@ func Killstats(rest string, user *users.UserRecord, room *rooms.Room, flags even
 		otherSuggestions = append(otherSuggestions, `<ansi fg="command">killstats race</ansi>`)
 	}
 
+	otherSuggestions = append(otherSuggestions, `<ansi fg="command">killstats duels</ansi>`)
+
 	headers = []string{strings.Title(rest), `Quantity`, `%`}
 
 	for name, killCt := range renderStats {
@@ -145,3 +152,80 @@ func Killstats(rest string, user *users.UserRecord, room *rooms.Room, flags even
 
 	return true, nil
 }
+
+// Duels are tallied separately since nobody actually dies
+func killstatsDuels(user *users.UserRecord) (bool, error) {
+
+	headers := []string{`Opponent`, `Wins`, `Losses`}
+
+	formatting := []string{
+		`<ansi fg="username">%s</ansi>`,
+		`<ansi fg="green">%s</ansi>`,
+		`<ansi fg="red">%s</ansi>`,
+	}
+
+	wins := map[string]int{}
+	losses := map[string]int{}
+
+	for userIdNameStr, winCount := range user.Character.KD.DuelWins {
+		parts := strings.Split(userIdNameStr, `:`)
+		wins[parts[1]] += winCount
+	}
+
+	for userIdNameStr, lossCount := range user.Character.KD.DuelLosses {
+		parts := strings.Split(userIdNameStr, `:`)
+		losses[parts[1]] += lossCount
+	}
+
+	names := []string{}
+	for name := range wins {
+		names = append(names, name)
+	}
+	for name := range losses {
+		if _, ok := wins[name]; !ok {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	rows := [][]string{}
+	for _, name := range names {
+		rows = append(rows, []string{
+			name,
+			fmt.Sprintf("%d", wins[name]),
+			fmt.Sprintf("%d", losses[name]),
+		})
+	}
+
+	rows = append(rows, []string{
+		``,
+		``,
+		``,
+	})
+
+	rows = append(rows, []string{
+		`Total`,
+		fmt.Sprintf("%d", user.Character.KD.TotalDuelWins),
+		fmt.Sprintf("%d", user.Character.KD.TotalDuelLosses),
+	})
+
+	if user.Character.KD.TotalDuelLosses > 0 {
+		rows = append(rows, []string{
+			`Win Ratio`,
+			fmt.Sprintf("%.2f:1", user.Character.KD.GetDuelRatio()),
+			``,
+		})
+	}
+
+	searchResultsTable := templates.GetTable(`Kill Stats by Duel`, headers, rows, formatting)
+	tplTxt, _ := templates.Process("tables/generic", searchResultsTable, user.UserId)
+	tplTxt += fmt.Sprintf("Also try: %s\n", strings.Join([]string{
+		`<ansi fg="command">killstats pvp</ansi>`,
+		`<ansi fg="command">killstats area</ansi>`,
+		`<ansi fg="command">killstats race</ansi>`,
+	}, `, `))
+	user.SendText(tplTxt)
+
+	return true, nil
+}
@@ -37,6 +37,11 @@ func List(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 
 		listedSomething = true
 
+		if user.Character.GetMerchantReputation(int(mob.MobId)) <= characters.MerchantReputationRefuse {
+			mob.Command(`say I don't do business with thieves.`)
+			continue
+		}
+
 		itemsAvailable := characters.Shop{}
 		mercsAvailable := characters.Shop{}
 		buffsAvailable := characters.Shop{}
@@ -106,6 +111,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 				} else if price < 0 {
 					price = 0
 				}
+				price = user.Character.GetBuyPrice(price, int(mob.MobId))
 
 				entryRow := []string{
 					qtyStr,
@@ -191,6 +197,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 				} else if price < 0 {
 					price = 0
 				}
+				price = user.Character.GetBuyPrice(price, int(mob.MobId))
 
 				entryRow := []string{
 					qtyStr,
@@ -274,7 +281,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 
 				if hasGoldItems {
 					if stockBuff.Price > 0 {
-						entryRow = append(entryRow, strconv.Itoa(stockBuff.Price))
+						entryRow = append(entryRow, strconv.Itoa(user.Character.GetBuyPrice(stockBuff.Price, int(mob.MobId))))
 					} else {
 						entryRow = append(entryRow, ``)
 					}
@@ -346,6 +353,7 @@ func List(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 				} else if price < 0 {
 					price = 0
 				}
+				price = user.Character.GetBuyPrice(price, int(mob.MobId))
 
 				entryRow := []string{
 					qtyStr,
@@ -481,9 +481,9 @@ func lookRoom(user *users.UserRecord, roomId int, secretLook bool) {
 				UserId:    user.UserId,
 			}
 
-			c.OverrideSymbol(roomId, '@', ``)
+			c.OverrideSymbol(rooms.GetSourceRoomId(roomId), '@', ``)
 
-			output := zMapper.GetLimitedMap(room.RoomId, c)
+			output := zMapper.GetLimitedMap(rooms.GetSourceRoomId(room.RoomId), c)
 			tinyMap := []string{}
 			tinyMap = append(tinyMap, `╔═════╗`)
 			for _, mapLine := range output.Render {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
@@ -48,6 +49,13 @@ func Offer(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 			continue
 		}
 
+		if user.Character.GetMerchantReputation(int(mob.MobId)) <= characters.MerchantReputationRefuse {
+			mob.Command(`say I don't do business with thieves.`)
+			continue
+		}
+
+		sellValue = user.Character.GetSellPrice(sellValue, int(mob.MobId))
+
 		mob.Command(fmt.Sprintf(`say I can give you <ansi fg="gold">%d gold</ansi> for that <ansi fg="itemname">%s</ansi>.`, sellValue, item.DisplayName()))
 
 		break
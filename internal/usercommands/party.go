@@ -96,6 +96,11 @@ func Party(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 
 		invitedUser := users.GetByUserId(invitePlayerId)
 
+		if invitedUser != nil && invitedUser.IsIgnoring(user.UserId) {
+			user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is ignoring you.`, invitedUser.Character.Name))
+			return true, nil
+		}
+
 		if invitedUser != nil && currentParty.InvitePlayer(invitePlayerId) {
 			user.SendText(fmt.Sprintf(`You invited <ansi fg="username">%s</ansi> to your party.`, invitedUser.Character.Name))
 			invitedUser.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> invited you to their party. Type <ansi fg="command">party accept</ansi> or <ansi fg="command">party decline</ansi> to respond.`, user.Character.Name))
@@ -586,7 +591,7 @@ func Party(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 				continue
 			}
 			if u := users.GetByUserId(uId); u != nil {
-				u.SendText(fmt.Sprintf(`<ansi fg="magenta">(party)</ansi> <ansi fg="username">%s</ansi> says, "<ansi fg="yellow">%s</ansi>`, user.Character.Name, rest))
+				u.SendTextFrom(user.UserId, fmt.Sprintf(`<ansi fg="magenta">(party)</ansi> <ansi fg="username">%s</ansi> says, "<ansi fg="yellow">%s</ansi>`, user.Character.Name, rest))
 			}
 		}
 
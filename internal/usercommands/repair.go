@@ -0,0 +1,137 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/skills"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+func Repair(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	var smith *mobs.Mob
+	for _, mobId := range room.GetMobs(rooms.FindMerchant) {
+		if mob := mobs.GetInstance(mobId); mob != nil && mob.Blacksmith {
+			smith = mob
+			break
+		}
+	}
+
+	if smith == nil {
+		user.SendText(`There is nobody here who can repair things.`)
+		return true, nil
+	}
+
+	smithMobId := int(smith.MobId)
+
+	if user.Character.GetMerchantReputation(smithMobId) <= characters.MerchantReputationRefuse {
+		smith.Command(`say I don't do business with thieves.`)
+		return true, nil
+	}
+
+	rest = strings.ToLower(strings.TrimSpace(rest))
+
+	// Gather up whatever is being repaired
+	toRepair := []items.Item{}
+
+	if rest == `` || rest == `all` {
+
+		for _, itm := range user.Character.GetAllWornItems() {
+			if itm.IsDamaged() {
+				toRepair = append(toRepair, itm)
+			}
+		}
+		for _, itm := range user.Character.GetAllBackpackItems() {
+			if itm.IsDamaged() {
+				toRepair = append(toRepair, itm)
+			}
+		}
+
+		if len(toRepair) == 0 {
+			smith.Command(`say You've got nothing that needs fixing.`)
+			return true, nil
+		}
+
+		// Just quote the prices
+		if rest == `` {
+			totalPrice := 0
+			for _, itm := range toRepair {
+				price := user.Character.GetBuyPrice(itm.GetRepairCost(), smithMobId)
+				totalPrice += price
+				user.SendText(fmt.Sprintf(`  <ansi fg="itemname">%s</ansi> %s - <ansi fg="gold">%d gold</ansi>`, itm.DisplayName(), itm.ConditionTag(), price))
+			}
+			user.SendText(fmt.Sprintf(`To repair something, type: <ansi fg="command">repair [item]</ansi>, or <ansi fg="command">repair all</ansi> for <ansi fg="gold">%d gold</ansi>.`, totalPrice))
+			return true, nil
+		}
+
+	} else {
+
+		itm, found := user.Character.FindOnBody(rest)
+		if !found {
+			itm, found = user.Character.FindInBackpack(rest)
+		}
+
+		if !found {
+			user.SendText(`You don't have that item.`)
+			return true, nil
+		}
+
+		if !itm.HasDurability() {
+			smith.Command(fmt.Sprintf(`say I can't do anything with %s.`, itm.DisplayName()))
+			return true, nil
+		}
+
+		if !itm.IsDamaged() {
+			smith.Command(fmt.Sprintf(`say There's nothing wrong with %s.`, itm.DisplayName()))
+			return true, nil
+		}
+
+		toRepair = append(toRepair, itm)
+	}
+
+	basePrice := 0
+	price := 0
+	for _, itm := range toRepair {
+		basePrice += itm.GetRepairCost()
+		price += user.Character.GetBuyPrice(itm.GetRepairCost(), smithMobId)
+	}
+
+	if price > user.Character.Gold {
+		smith.Command(fmt.Sprintf(`say That will cost %d gold, which you don't seem to have.`, price))
+		return true, nil
+	}
+
+	user.Character.Gold -= price
+	smith.Character.Gold += price
+
+	for _, itm := range toRepair {
+		user.Character.RepairItem(itm)
+	}
+
+	user.Character.AdjustMerchantReputation(smithMobId, characters.MerchantReputationTrade)
+
+	events.AddToQueue(events.EquipmentChange{
+		UserId:       user.UserId,
+		GoldChange:   -price,
+		ItemsChanged: toRepair,
+	})
+
+	if price < basePrice && user.Character.GetSkillLevel(skills.Trading) > 0 {
+		user.SendText(fmt.Sprintf(`You haggle the price down from <ansi fg="gold">%d gold</ansi> to <ansi fg="gold">%d gold</ansi>.`, basePrice, price))
+	}
+
+	for _, itm := range toRepair {
+		user.SendText(fmt.Sprintf(`<ansi fg="mobname">%s</ansi> repairs your <ansi fg="itemname">%s</ansi>.`, smith.Character.Name, itm.DisplayName()))
+	}
+	user.SendText(fmt.Sprintf(`You pay <ansi fg="gold">%d gold</ansi> for the work.`, price))
+
+	room.SendText(fmt.Sprintf(`<ansi fg="mobname">%s</ansi> repairs some equipment for <ansi fg="username">%s</ansi>.`, smith.Character.Name, user.Character.Name), user.UserId)
+
+	return true, nil
+}
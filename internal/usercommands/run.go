@@ -0,0 +1,116 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+const (
+	maxSpeedwalkSteps = 50
+)
+
+var (
+	// Two letter directions are checked first so "ne" isn't read as north then east
+	speedwalkDirections = []string{`ne`, `nw`, `se`, `sw`, `n`, `s`, `e`, `w`, `u`, `d`}
+)
+
+func Run(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	if rest == `` {
+		user.SendText(`Run where? Try something like <ansi fg="command">run 3n2e</ansi>, or see <ansi fg="command">help run</ansi>.`)
+		return true, nil
+	}
+
+	if rest == `stop` {
+		return stopTravel(user), nil
+	}
+
+	if user.Character.Aggro != nil {
+		user.SendText("You can't do that! You are in combat!")
+		return true, nil
+	}
+
+	steps, ok := ParseSpeedwalk(rest)
+	if !ok {
+		user.SendText(fmt.Sprintf(`<ansi fg="command">%s</ansi> isn't something you can run. Try something like <ansi fg="command">run 3n2e</ansi>.`, rest))
+		return true, nil
+	}
+
+	user.SetTravelSteps(steps)
+
+	return true, nil
+}
+
+// Expands speedwalk shorthand into the directions to take, one step at a time.
+// For example "3n2e" is north, north, north, east, east.
+// Spaces separate directions that would otherwise be read together, so "n e" is north then east rather than northeast.
+func ParseSpeedwalk(input string) ([]string, bool) {
+
+	steps := []string{}
+
+	for _, part := range strings.Fields(strings.ToLower(input)) {
+
+		for len(part) > 0 {
+
+			count := 0
+			hasCount := false
+
+			for len(part) > 0 && part[0] >= '0' && part[0] <= '9' {
+				count = count*10 + int(part[0]-'0')
+				hasCount = true
+				part = part[1:]
+
+				if count > maxSpeedwalkSteps {
+					return nil, false
+				}
+			}
+
+			if !hasCount {
+				count = 1
+			}
+
+			direction := ``
+			for _, d := range speedwalkDirections {
+				if strings.HasPrefix(part, d) {
+					direction = d
+					break
+				}
+			}
+
+			if direction == `` || count == 0 {
+				return nil, false
+			}
+
+			part = part[len(direction):]
+
+			for ; count > 0; count-- {
+				steps = append(steps, keywords.TryDirectionAlias(direction))
+			}
+
+			if len(steps) > maxSpeedwalkSteps {
+				return nil, false
+			}
+		}
+	}
+
+	return steps, len(steps) > 0
+}
+
+// Stops any speedwalking or traveling in progress
+func stopTravel(user *users.UserRecord) bool {
+
+	if len(user.GetTravelSteps()) == 0 {
+		user.SendText(`You aren't going anywhere.`)
+		return true
+	}
+
+	user.SetTravelSteps(nil)
+	user.SendText(`You stop where you are.`)
+
+	return true
+}
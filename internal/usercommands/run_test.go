@@ -0,0 +1,57 @@
+package usercommands
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/keywords"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSpeedwalk(t *testing.T) {
+
+	// Direction aliases come from the keywords file
+	configs.AddOverlayOverrides(map[string]any{`FilePaths.DataFiles`: `../../_datafiles/world/default`})
+	keywords.LoadAliases()
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+		ok    bool
+	}{
+		{"Single direction", `n`, []string{`north`}, true},
+		{"Counts", `3n2e`, []string{`north`, `north`, `north`, `east`, `east`}, true},
+		{"Multi digit count", `12s`, repeatStep(`south`, 12), true},
+		{"Diagonals read first", `ne2sw`, []string{`northeast`, `southwest`, `southwest`}, true},
+		{"Spaces split directions", `n e`, []string{`north`, `east`}, true},
+		{"Up and down", `2u d`, []string{`up`, `up`, `down`}, true},
+		{"Upper case", `2N`, []string{`north`, `north`}, true},
+		{"Most steps allowed", `50w`, repeatStep(`west`, 50), true},
+
+		{"Empty", ``, nil, false},
+		{"Only spaces", `   `, nil, false},
+		{"Unknown direction", `3x`, nil, false},
+		{"Count without direction", `3`, nil, false},
+		{"Zero count", `0n`, nil, false},
+		{"Count too large", `51n`, nil, false},
+		{"Too many steps in total", `30n30s`, nil, false},
+		{"Huge count", `99999999999999999999n`, nil, false},
+		{"Full direction names", `north`, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseSpeedwalk(tt.input)
+			assert.Equal(t, tt.ok, ok)
+			if tt.ok {
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func repeatStep(direction string, count int) []string {
+	return slices.Repeat([]string{direction}, count)
+}
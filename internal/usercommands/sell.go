@@ -4,9 +4,11 @@ import (
 	"fmt"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/skills"
 	"github.com/GoMudEngine/GoMud/internal/users"
 )
 
@@ -39,6 +41,11 @@ func Sell(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 
 		user.Character.CancelBuffsWithFlag(buffs.Hidden)
 
+		if user.Character.GetMerchantReputation(int(mob.MobId)) <= characters.MerchantReputationRefuse {
+			mob.Command(`say I don't do business with thieves.`)
+			continue
+		}
+
 		if item.IsSpecial() {
 
 			mob.Command(`say I'm afraid I don't buy those.`)
@@ -46,13 +53,16 @@ func Sell(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 			continue
 		}
 
-		sellValue := mob.GetSellPrice(item)
+		baseValue := mob.GetSellPrice(item)
 
-		if sellValue <= 0 {
+		if baseValue <= 0 {
 			mob.Command(`say I'm not interested in that.`)
 			continue
 		}
 
+		// Merchants adjust their offer based on trading skill and reputation
+		sellValue := user.Character.GetSellPrice(baseValue, int(mob.MobId))
+
 		user.Character.Gold += sellValue
 		user.Character.RemoveItem(item)
 
@@ -69,6 +79,12 @@ func Sell(rest string, user *users.UserRecord, room *rooms.Room, flags events.Ev
 
 		mob.Character.Shop.StockItem(item.ItemId)
 
+		user.Character.AdjustMerchantReputation(int(mob.MobId), characters.MerchantReputationTrade)
+
+		if sellValue > baseValue && user.Character.GetSkillLevel(skills.Trading) > 0 {
+			user.SendText(fmt.Sprintf(`You haggle the price up from <ansi fg="gold">%d gold</ansi> to <ansi fg="gold">%d gold</ansi>.`, baseValue, sellValue))
+		}
+
 		user.EventLog.Add(`shop`, fmt.Sprintf(`Sold your <ansi fg="itemname">%s</ansi> to <ansi fg="mobname">%s</ansi> for <ansi fg="gold">%d gold</ansi>`, item.DisplayName(), mob.Character.Name, sellValue))
 
 		user.SendText(
@@ -124,19 +124,27 @@ func Map(rest string, user *users.UserRecord, room *rooms.Room, flags events.Eve
 		UserId:    user.UserId,
 	}
 
+	// The map is drawn from the source rooms, so symbols go on the roomIds the instance rooms were copied from.
+	// Only rooms in the same instance (if any) as the user are marked.
+	overrideSymbol := func(roomId int, symbol rune, legend string) {
+		if roomInfo := rooms.LoadRoom(roomId); roomInfo != nil && roomInfo.InstanceId == room.InstanceId {
+			c.OverrideSymbol(rooms.GetSourceRoomId(roomId), symbol, legend)
+		}
+	}
+
 	if skillLevel > 4 {
 		for _, rid := range rooms.GetRoomsWithMobs() {
 			if roomInfo := rooms.LoadRoom(rid); roomInfo != nil {
 				if len(roomInfo.GetMobs(rooms.FindFighting|rooms.FindHostile)) > 0 {
-					c.OverrideSymbol(rid, '☠', `Mob`)
+					overrideSymbol(rid, '☠', `Mob`)
 				} else {
-					c.OverrideSymbol(rid, '☺', `NPC`)
+					overrideSymbol(rid, '☺', `NPC`)
 				}
 			}
 		}
 
 		for _, rid := range rooms.GetRoomsWithPlayers() {
-			c.OverrideSymbol(rid, '☺', `Player`)
+			overrideSymbol(rid, '☺', `Player`)
 		}
 	}
 
@@ -147,18 +155,18 @@ func Map(rest string, user *users.UserRecord, room *rooms.Room, flags events.Eve
 				// Add any charmed mobs
 				for _, mid := range tmpUser.Character.GetCharmIds() {
 					if tmpMob := mobs.GetInstance(mid); tmpMob != nil {
-						c.OverrideSymbol(tmpMob.Character.RoomId, '☹', `Friend`)
+						overrideSymbol(tmpMob.Character.RoomId, '☹', `Friend`)
 					}
 				}
 
-				c.OverrideSymbol(tmpUser.Character.RoomId, '☺', `Party Member`)
+				overrideSymbol(tmpUser.Character.RoomId, '☺', `Party Member`)
 			}
 		}
 	}
 
-	c.OverrideSymbol(user.Character.RoomId, '@', `You`)
+	c.OverrideSymbol(rooms.GetSourceRoomId(user.Character.RoomId), '@', `You`)
 
-	mapOutput := zMapper.GetLimitedMap(roomId, c)
+	mapOutput := zMapper.GetLimitedMap(rooms.GetSourceRoomId(roomId), c)
 	if skillLevel > 4 {
 		//mapRender = m.GetFullMap(roomId, c)
 	}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
@@ -126,6 +127,10 @@ func Pickpocket(rest string, user *users.UserRecord, room *rooms.Room, flags eve
 					user.SendText(
 						fmt.Sprintf(`You succeed in picking the pockets of <ansi fg="mobname">%s</ansi> and steal %s`, m.Character.Name, strings.Join(stolenStuff, ` and `)))
 
+					// Merchants eventually notice what has gone missing
+					if m.HasShop() {
+						user.Character.AdjustMerchantReputation(int(m.MobId), characters.MerchantReputationTheft)
+					}
 				}
 
 			} else {
@@ -140,6 +145,10 @@ func Pickpocket(rest string, user *users.UserRecord, room *rooms.Room, flags eve
 
 				user.Character.CancelBuffsWithFlag(buffs.Hidden)
 
+				if m.HasShop() {
+					user.Character.AdjustMerchantReputation(int(m.MobId), characters.MerchantReputationCaughtStealing)
+				}
+
 				m.Command(fmt.Sprintf(`attack @%d`, user.UserId))
 
 			}
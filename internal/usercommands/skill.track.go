@@ -15,6 +15,11 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
+const (
+	// How many rooms away a quarry can be for the track skill to give directions to them
+	trackDirectionsMaxRooms = 10
+)
+
 type trackingInfo struct {
 	Name            string
 	Type            string // mob / user
@@ -28,7 +33,7 @@ Skill Track
 Level 1 - Display the last player or mob to walk through here (not the currently player or current mobs)
 Level 2 - Display all players and mobs to recently walk through here
 Level 3 - Shows exit information for all tracked players or mobs
-Level 4 - Specify a mob or username and every room you enter will tell you what exit they took.
+Level 4 - Specify a mob or username and every room you enter will tell you what exit they took, and directions to them if they haven't gone far.
 */
 func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
 
@@ -211,6 +216,7 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 		if skillLevel >= 4 {
 
 			allNames := []string{}
+			quarryRoomIds := map[string]int{}
 
 			for uId, _ := range room.Visitors(rooms.VisitorUser) {
 
@@ -220,6 +226,7 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 
 				if visitorUser := users.GetByUserId(uId); visitorUser != nil {
 					allNames = append(allNames, visitorUser.Character.Name)
+					quarryRoomIds[visitorUser.Character.Name] = visitorUser.Character.RoomId
 				}
 
 			}
@@ -232,6 +239,8 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 
 				user.AddBuff(26, `skill`) // 26 is the buff for active tracking
 
+				sendTrackDirections(user, room.RoomId, quarryRoomIds[match], fmt.Sprintf(`<ansi fg="username">%s</ansi>`, match))
+
 				return true, nil
 
 			} else if closeMatch != `` {
@@ -241,15 +250,19 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 
 				user.AddBuff(26, `skill`) // 26 is the buff for active tracking
 
+				sendTrackDirections(user, room.RoomId, quarryRoomIds[closeMatch], fmt.Sprintf(`<ansi fg="username">%s</ansi>`, closeMatch))
+
 				return true, nil
 
 			}
 
 			allNames = []string{}
+			quarryRoomIds = map[string]int{}
 
 			for mId, _ := range room.Visitors(rooms.VisitorMob) {
 				if visitorMob := mobs.GetInstance(mId); visitorMob != nil {
 					allNames = append(allNames, visitorMob.Character.Name)
+					quarryRoomIds[visitorMob.Character.Name] = visitorMob.Character.RoomId
 				}
 			}
 
@@ -261,6 +274,8 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 
 				user.AddBuff(26, `skill`) // 26 is the buff for active tracking
 
+				sendTrackDirections(user, room.RoomId, quarryRoomIds[match], fmt.Sprintf(`<ansi fg="mobname">%s</ansi>`, match))
+
 				return true, nil
 
 			} else if closeMatch != `` {
@@ -270,6 +285,8 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 
 				user.AddBuff(26, `skill`) // 26 is the buff for active tracking
 
+				sendTrackDirections(user, room.RoomId, quarryRoomIds[closeMatch], fmt.Sprintf(`<ansi fg="mobname">%s</ansi>`, closeMatch))
+
 				return true, nil
 
 			}
@@ -403,6 +420,26 @@ func Track(rest string, user *users.UserRecord, room *rooms.Room, flags events.E
 	return true, nil
 }
 
+// Once a trail is picked up, an expert tracker can tell the way to their quarry if they haven't gone far.
+func sendTrackDirections(user *users.UserRecord, fromRoomId int, quarryRoomId int, quarryName string) {
+
+	if quarryRoomId == 0 {
+		return
+	}
+
+	path, err := rooms.FindPath(fromRoomId, quarryRoomId, 0)
+	if err != nil || len(path) == 0 || len(path) > trackDirectionsMaxRooms {
+		return
+	}
+
+	exitNames := []string{}
+	for _, step := range path {
+		exitNames = append(exitNames, fmt.Sprintf(`<ansi fg="exit">%s</ansi>`, step.ExitName))
+	}
+
+	user.SendText(fmt.Sprintf(`The trail of %s leads %s.`, quarryName, strings.Join(exitNames, `, `)))
+}
+
 func trailStrengthToString(trailStrength float64) string {
 	strengthStr := ""
 	strength := int(math.Round(trailStrength * 100))
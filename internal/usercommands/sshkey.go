@@ -0,0 +1,94 @@
+package usercommands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"golang.org/x/crypto/ssh"
+)
+
+/*
+* Manages the public keys that can be used to log in over SSH
+* sshkey 					- list keys
+* sshkey add <public key>	- add a key (authorized_keys format)
+* sshkey remove <#|fingerprint>	- remove a key
+ */
+func SSHKey(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	cmd, args, _ := strings.Cut(strings.TrimSpace(rest), ` `)
+	args = strings.TrimSpace(args)
+
+	switch strings.ToLower(cmd) {
+
+	case `add`:
+
+		if args == `` {
+			user.SendText(`Usage: <ansi fg="command">sshkey add ssh-ed25519 AAAA...</ansi>`)
+			return true, nil
+		}
+
+		fingerprint, err := user.AddSSHKey(args)
+		if err != nil {
+			user.SendText(fmt.Sprintf(`<ansi fg="alert-5">Could not add that key: %s</ansi>`, err.Error()))
+			return true, nil
+		}
+
+		users.SaveUser(*user)
+
+		user.SendText(fmt.Sprintf(`<ansi fg="alert-1">Key added:</ansi> <ansi fg="yellow">%s</ansi>`, fingerprint))
+		user.SendText(fmt.Sprintf(`You can now log in over SSH as <ansi fg="username">%s</ansi> without a password.`, user.Username))
+
+		return true, nil
+
+	case `remove`, `delete`:
+
+		if args == `` {
+			user.SendText(`Usage: <ansi fg="command">sshkey remove [#|fingerprint]</ansi>`)
+			return true, nil
+		}
+
+		fingerprint, err := user.RemoveSSHKey(args)
+		if err != nil {
+			user.SendText(fmt.Sprintf(`<ansi fg="alert-5">Could not remove that key: %s</ansi>`, err.Error()))
+			return true, nil
+		}
+
+		users.SaveUser(*user)
+
+		user.SendText(fmt.Sprintf(`<ansi fg="alert-1">Key removed:</ansi> <ansi fg="yellow">%s</ansi>`, fingerprint))
+
+		return true, nil
+	}
+
+	user.SendText(``)
+
+	if len(configs.GetNetworkConfig().SshPort) == 0 {
+		user.SendText(`<ansi fg="alert-3">SSH is not enabled on this server.</ansi>`)
+		user.SendText(``)
+	}
+
+	if len(user.SSHKeys) == 0 {
+		user.SendText(`You have no SSH keys.`)
+	} else {
+		user.SendText(`Your SSH keys:`)
+		for i, keyLine := range user.SSHKeys {
+
+			pubKey, comment, err := users.ParseSSHKey(keyLine)
+			if err != nil {
+				continue
+			}
+
+			user.SendText(fmt.Sprintf(`  <ansi fg="white-bold">%d.</ansi> <ansi fg="yellow">%s</ansi> %s <ansi fg="black-bold">%s</ansi>`, i+1, ssh.FingerprintSHA256(pubKey), pubKey.Type(), comment))
+		}
+	}
+
+	user.SendText(``)
+	user.SendText(`Type <ansi fg="command">sshkey add [public key]</ansi> or <ansi fg="command">sshkey remove [#]</ansi> to change them.`)
+	user.SendText(``)
+
+	return true, nil
+}
@@ -0,0 +1,91 @@
+package usercommands
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/events"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+/*
+Walks the shortest known route to a landmark, zone or room, one step per turn.
+Usage:
+
+	travel
+	travel bank
+	travel frostfang
+	travel 59
+	travel stop
+*/
+func Travel(rest string, user *users.UserRecord, room *rooms.Room, flags events.EventFlag) (bool, error) {
+
+	if rest == `` {
+
+		landmarks := rooms.GetZoneLandmarks(room.Zone)
+		if len(landmarks) == 0 {
+			user.SendText(fmt.Sprintf(`There are no landmarks in <ansi fg="zone">%s</ansi>.`, room.Zone))
+		} else {
+			for i, landmark := range landmarks {
+				landmarks[i] = `<ansi fg="command">` + strings.ToLower(landmark) + `</ansi>`
+			}
+			user.SendText(fmt.Sprintf(`Landmarks in <ansi fg="zone">%s</ansi>: %s`, room.Zone, strings.Join(landmarks, `, `)))
+		}
+
+		user.SendText(`Type <ansi fg="command">travel [landmark]</ansi> to head for one, or see <ansi fg="command">help travel</ansi>.`)
+
+		return true, nil
+	}
+
+	if rest == `stop` {
+		return stopTravel(user), nil
+	}
+
+	if user.Character.Aggro != nil {
+		user.SendText("You can't do that! You are in combat!")
+		return true, nil
+	}
+
+	// Players never know the way through locked doors or secret passages
+	var path []rooms.PathStep
+	var err error = rooms.ErrNoPath
+
+	if roomId, convErr := strconv.Atoi(rest); convErr == nil {
+		path, err = rooms.FindPath(room.RoomId, roomId, 0)
+	} else {
+
+		path, err = rooms.FindLandmarkPath(room.RoomId, rest, 0)
+
+		if err != nil {
+			if zoneName := rooms.FindZoneName(rest); zoneName != `` {
+				if rootRoomId, rootErr := rooms.GetZoneRoot(zoneName); rootErr == nil {
+					rest = zoneName
+					path, err = rooms.FindPath(room.RoomId, rootRoomId, 0)
+				}
+			}
+		}
+	}
+
+	if err != nil {
+		user.SendText(fmt.Sprintf(`You don't know the way to <ansi fg="command">%s</ansi>.`, rest))
+		return true, nil
+	}
+
+	if len(path) == 0 {
+		user.SendText(`You're already there!`)
+		return true, nil
+	}
+
+	exitNames := make([]string, 0, len(path))
+	for _, step := range path {
+		exitNames = append(exitNames, step.ExitName)
+	}
+
+	user.SetTravelSteps(exitNames)
+
+	user.SendText(fmt.Sprintf(`You set off towards <ansi fg="command">%s</ansi>, %d rooms away.`, rest, len(path)))
+
+	return true, nil
+}
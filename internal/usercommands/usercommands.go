@@ -53,7 +53,9 @@ var (
 		`biome`:       {Biome, true, false},
 		`broadcast`:   {Broadcast, true, false},
 		`bury`:        {Bury, false, false},
+		`channel`:     {Channel, true, false},
 		`character`:   {Character, true, false},
+		`clan`:        {Clan, true, false},
 		`tackle`:      {Tackle, false, false},
 		`bank`:        {Bank, false, false},
 		`break`:       {Break, false, false},
@@ -64,12 +66,15 @@ var (
 		`cast`:        {Cast, false, false},
 		`cooldowns`:   {Cooldowns, true, false},
 		`command`:     {Command, false, true}, // Admin only
+		`compress`:    {Compress, true, false},
 		`conditions`:  {Conditions, true, false},
+		`craft`:       {Craft, false, false},
 		`consider`:    {Consider, true, false},
 		`deafen`:      {Deafen, true, true}, // Admin only
 		`default`:     {Default, false, false},
 		`disarm`:      {Disarm, false, false},
 		`drop`:        {Drop, true, false},
+		`duel`:        {Duel, false, false},
 		`drink`:       {Drink, false, false},
 		`eat`:         {Eat, false, false},
 		`emote`:       {Emote, true, false},
@@ -87,6 +92,8 @@ var (
 		`keyring`:     {KeyRing, true, false},
 		`killstats`:   {Killstats, true, false},
 		`history`:     {History, true, false},
+		`house`:       {House, false, false},
+		`ignore`:      {Ignore, true, false},
 		`inbox`:       {Inbox, true, false},
 		`inspect`:     {Inspect, false, false},
 		`inventory`:   {Inventory, true, false},
@@ -125,14 +132,18 @@ var (
 		`questtoken`:  {QuestToken, false, true}, // Admin only
 		`rank`:        {Rank, false, false},
 		`read`:        {Read, false, false},
+		`recipes`:     {Recipes, true, false},
 		`recover`:     {Recover, false, false},
 		`reload`:      {Reload, true, true}, // Admin only
 		`remove`:      {Remove, false, false},
+		`repair`:      {Repair, false, false},
 		`rename`:      {Rename, false, true},     // Admin only
 		`redescribe`:  {Redescribe, false, true}, // Admin only
 		`room`:        {Room, false, true},       // Admin only
+		`run`:         {Run, false, false},
 		`save`:        {Save, true, false},
 		`say`:         {Say, true, false},
+		`script`:      {Script, true, true}, // Admin only
 		`scribe`:      {Scribe, false, false},
 		`search`:      {Search, false, false},
 		`sell`:        {Sell, false, false},
@@ -148,6 +159,7 @@ var (
 		`spawn`:       {Spawn, false, true}, // Admin only
 		`spell`:       {Spell, true, true},  // Admin only
 		`spells`:      {Spells, true, false},
+		`sshkey`:      {SSHKey, true, false},
 		`stash`:       {Stash, false, false},
 		`status`:      {Status, true, false},
 		`storage`:     {Storage, false, false},
@@ -159,7 +171,9 @@ var (
 		`track`:       {Track, false, false},
 		`trash`:       {Trash, false, false},
 		`train`:       {Train, false, false},
+		`travel`:      {Travel, false, false},
 		`unenchant`:   {Unenchant, false, false},
+		`unignore`:    {Unignore, true, false},
 		`uncurse`:     {Uncurse, false, false},
 		`unlock`:      {Unlock, false, false},
 		`undeafen`:    {UnDeafen, true, true}, // Admin only
@@ -386,6 +400,14 @@ func TryCommand(cmd string, rest string, userId int, flags events.EventFlag) (bo
 		return Cast(castCmd, user, room, flags)
 	}
 
+	// Talking on a chat channel, such as "ooc hello"
+	// Exits come first, so a channel can't take over an exit with the same name, such as "gate"
+	if exitName, _ := room.FindExitByName(cmd); exitName == `` {
+		if tryChannelMessage(cmd, rest, user) {
+			return true, nil
+		}
+	}
+
 	// "go" attempt
 	start := time.Now()
 	defer func() {
@@ -36,6 +36,11 @@ func Whisper(rest string, user *users.UserRecord, room *rooms.Room, flags events
 	sourceIsMod := user.Role != users.RoleUser
 	targetIsMod := toUser.Role != users.RoleUser
 
+	if toUser.IsIgnoring(user.UserId) {
+		user.SendText(fmt.Sprintf(`<ansi fg="username">%s</ansi> is ignoring you.`, toUser.Character.Name))
+		return true, nil
+	}
+
 	if user.Muted && !targetIsMod {
 		user.SendText(`You are <ansi fg="alert-5">MUTED</ansi>. You can only send <ansi fg="command">whisper</ansi>'s to Admins and Moderators.`)
 		return true, nil
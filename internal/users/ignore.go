@@ -0,0 +1,96 @@
+package users
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+const (
+	MaxIgnored = 50
+)
+
+var (
+	ErrIgnoreSelf    = errors.New("you can't ignore yourself")
+	ErrIgnoreStaff   = errors.New("admins and moderators can't be ignored")
+	ErrIgnoreLimit   = errors.New("your ignore list is full")
+	ErrAlreadyIgnore = errors.New("already ignoring them")
+)
+
+type IgnoredUser struct {
+	UserId        int
+	CharacterName string
+}
+
+// Stops another user's communications, party invites and gifts reaching this user
+func (u *UserRecord) Ignore(userId int, characterName string, isStaff bool) error {
+
+	if userId == u.UserId {
+		return ErrIgnoreSelf
+	}
+
+	if isStaff {
+		return ErrIgnoreStaff
+	}
+
+	if _, ok := u.Ignored[userId]; ok {
+		return ErrAlreadyIgnore
+	}
+
+	if len(u.Ignored) >= MaxIgnored {
+		return ErrIgnoreLimit
+	}
+
+	if u.Ignored == nil {
+		u.Ignored = map[int]string{}
+	}
+	u.Ignored[userId] = characterName
+
+	return nil
+}
+
+// Returns false if the user wasn't being ignored
+func (u *UserRecord) Unignore(userId int) bool {
+	if _, ok := u.Ignored[userId]; !ok {
+		return false
+	}
+	delete(u.Ignored, userId)
+	return true
+}
+
+// Whether this user is ignoring another user.
+// Admins and moderators are never ignored, even if they became staff after being ignored.
+func (u *UserRecord) IsIgnoring(userId int) bool {
+
+	if _, ok := u.Ignored[userId]; !ok {
+		return false
+	}
+
+	if source := GetByUserId(userId); source != nil && source.Role != RoleUser {
+		return false
+	}
+
+	return true
+}
+
+// Finds an ignored user by character name, ignoring case
+func (u *UserRecord) FindIgnored(characterName string) (IgnoredUser, bool) {
+	for _, ignored := range u.GetIgnored() {
+		if strings.EqualFold(ignored.CharacterName, characterName) {
+			return ignored, true
+		}
+	}
+	return IgnoredUser{}, false
+}
+
+// Returns everyone being ignored, sorted by name
+func (u *UserRecord) GetIgnored() []IgnoredUser {
+	ret := make([]IgnoredUser, 0, len(u.Ignored))
+	for userId, name := range u.Ignored {
+		ret = append(ret, IgnoredUser{UserId: userId, CharacterName: name})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		return ret[i].CharacterName < ret[j].CharacterName
+	})
+	return ret
+}
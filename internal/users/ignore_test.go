@@ -0,0 +1,59 @@
+package users
+
+import (
+	"testing"
+)
+
+func TestIgnore_AddAndRemove(t *testing.T) {
+
+	u := &UserRecord{UserId: 1}
+
+	if err := u.Ignore(2, `Bob`, false); err != nil {
+		t.Fatalf("Ignore() error: %v", err)
+	}
+	if !u.IsIgnoring(2) {
+		t.Errorf("IsIgnoring() = false for an ignored user")
+	}
+	if u.IsIgnoring(3) {
+		t.Errorf("IsIgnoring() = true for a user that was never ignored")
+	}
+
+	if err := u.Ignore(2, `Bob`, false); err != ErrAlreadyIgnore {
+		t.Errorf("Ignore() duplicate error = %v, want %v", err, ErrAlreadyIgnore)
+	}
+	if err := u.Ignore(1, `Me`, false); err != ErrIgnoreSelf {
+		t.Errorf("Ignore() self error = %v, want %v", err, ErrIgnoreSelf)
+	}
+	if err := u.Ignore(4, `Admin`, true); err != ErrIgnoreStaff {
+		t.Errorf("Ignore() staff error = %v, want %v", err, ErrIgnoreStaff)
+	}
+
+	if found, ok := u.FindIgnored(`bob`); !ok || found.UserId != 2 {
+		t.Errorf("FindIgnored() = %v, %v", found, ok)
+	}
+
+	if !u.Unignore(2) {
+		t.Errorf("Unignore() = false for an ignored user")
+	}
+	if u.Unignore(2) {
+		t.Errorf("Unignore() = true for a user that is no longer ignored")
+	}
+	if u.IsIgnoring(2) {
+		t.Errorf("IsIgnoring() = true after Unignore()")
+	}
+}
+
+func TestIgnore_Limit(t *testing.T) {
+
+	u := &UserRecord{UserId: 1}
+
+	for i := 0; i < MaxIgnored; i++ {
+		if err := u.Ignore(100+i, `Someone`, false); err != nil {
+			t.Fatalf("Ignore() error: %v", err)
+		}
+	}
+
+	if err := u.Ignore(99, `OneTooMany`, false); err != ErrIgnoreLimit {
+		t.Errorf("Ignore() over the limit error = %v, want %v", err, ErrIgnoreLimit)
+	}
+}
@@ -24,12 +24,57 @@ func DoUserMigrations() {
 
 }
 
-func DoFilenameMigrationV1() error {
+// Converts any plaintext passwords to salted hashes, and wraps any unsalted
+// SHA-256 digests in a salted hash. Wrapped digests are replaced with a normal
+// salted hash the next time the user logs in.
+// Returns the number of user records that were updated.
+func DoPasswordMigrationV1() (int, error) {
 
 	var errorResult error = nil
+	migratedCt := 0
 
 	SearchOfflineUsers(func(u *UserRecord) bool {
 
+		if u.Password == `` || isBcryptHash(u.Password) || strings.HasPrefix(u.Password, wrappedSha256Prefix) {
+			return true
+		}
+
+		var newHash string
+		var err error
+
+		if isSha256Hash(u.Password) {
+			newHash, err = wrapSha256Hash(u.Password)
+		} else {
+			newHash, err = HashPassword(u.Password)
+		}
+
+		if err != nil {
+			errorResult = err
+			return false
+		}
+
+		u.Password = newHash
+
+		if err := SaveUser(*u); err != nil {
+			errorResult = err
+			return false
+		}
+
+		migratedCt++
+
+		return true
+	})
+
+	return migratedCt, errorResult
+}
+
+func DoFilenameMigrationV1() error {
+
+	var errorResult error = nil
+
+	// Only applies to flat files
+	NewYamlUserStore().Search(func(u *UserRecord) bool {
+
 		oldUserPath := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`, `/`, strings.ToLower(u.Username)+`.yaml`)
 		newUserPath := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`, `/`, strconv.Itoa(u.UserId)+`.yaml`)
 
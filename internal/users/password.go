@@ -0,0 +1,96 @@
+package users
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"golang.org/x/crypto/bcrypt"
+)
+
+//
+// Password hashing
+//
+// New and changed passwords are stored as salted bcrypt hashes.
+// Older records may contain one of the following, all of which still work
+// and are upgraded to a plain bcrypt hash the next time the user logs in:
+//
+//   - A plaintext password (hand edited or very old user files)
+//   - An unsalted SHA-256 hex digest created by util.Hash()
+//   - A bcrypt hash of a SHA-256 digest, created by DoPasswordMigrationV1()
+//
+
+const (
+	passwordHashCost = bcrypt.DefaultCost
+	// Prefix for legacy SHA-256 digests that have been wrapped in bcrypt by the migration
+	wrappedSha256Prefix = `sha256+`
+	// bcrypt cannot hash anything longer than this
+	PasswordMaxBytes = 72
+)
+
+// Returns a salted hash of the password suitable for storing
+func HashPassword(pw string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pw), passwordHashCost)
+	if err != nil {
+		return ``, err
+	}
+	return string(hash), nil
+}
+
+// Returns a salted hash of an existing unsalted SHA-256 digest.
+// Used to protect legacy records without knowing the original password.
+func wrapSha256Hash(digest string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(digest), passwordHashCost)
+	if err != nil {
+		return ``, err
+	}
+	return wrappedSha256Prefix + string(hash), nil
+}
+
+func isBcryptHash(stored string) bool {
+	return strings.HasPrefix(stored, `$2a$`) || strings.HasPrefix(stored, `$2b$`) || strings.HasPrefix(stored, `$2y$`)
+}
+
+// Whether a stored value is an unsalted SHA-256 digest as created by util.Hash()
+func isSha256Hash(stored string) bool {
+	if len(stored) != 64 {
+		return false
+	}
+	for _, r := range stored {
+		if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// Whether a stored password is in a legacy or outdated format
+func passwordNeedsRehash(stored string) bool {
+	if !isBcryptHash(stored) {
+		return true
+	}
+	cost, err := bcrypt.Cost([]byte(stored))
+	return err != nil || cost < passwordHashCost
+}
+
+func passwordMatches(stored string, input string) bool {
+
+	if stored == `` {
+		return false
+	}
+
+	if isBcryptHash(stored) {
+		return bcrypt.CompareHashAndPassword([]byte(stored), []byte(input)) == nil
+	}
+
+	if wrapped, ok := strings.CutPrefix(stored, wrappedSha256Prefix); ok {
+		return bcrypt.CompareHashAndPassword([]byte(wrapped), []byte(util.Hash(input))) == nil
+	}
+
+	if isSha256Hash(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(util.Hash(input))) == 1
+	}
+
+	// Plaintext, such as a password that was reset by hand in the user file
+	return subtle.ConstantTimeCompare([]byte(stored), []byte(input)) == 1
+}
@@ -0,0 +1,83 @@
+package users
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+func TestPasswordMatches_Formats(t *testing.T) {
+
+	bcryptHash, err := HashPassword(`hunter2`)
+	if err != nil {
+		t.Fatalf("HashPassword() error: %v", err)
+	}
+
+	wrappedHash, err := wrapSha256Hash(util.Hash(`hunter2`))
+	if err != nil {
+		t.Fatalf("wrapSha256Hash() error: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		stored      string
+		needsRehash bool
+	}{
+		{"bcrypt", bcryptHash, false},
+		{"wrapped sha256", wrappedHash, true},
+		{"sha256", util.Hash(`hunter2`), true},
+		{"plaintext", `hunter2`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := &UserRecord{Password: tt.stored}
+
+			if !u.PasswordMatches(`hunter2`) {
+				t.Errorf("expected correct password to match")
+			}
+			if u.PasswordMatches(`hunter3`) {
+				t.Errorf("expected wrong password not to match")
+			}
+			if u.PasswordNeedsRehash() != tt.needsRehash {
+				t.Errorf("PasswordNeedsRehash() = %v, want %v", u.PasswordNeedsRehash(), tt.needsRehash)
+			}
+
+			if err := u.RehashPassword(`hunter2`); err != nil {
+				t.Fatalf("RehashPassword() error: %v", err)
+			}
+			if u.PasswordNeedsRehash() || !u.PasswordMatches(`hunter2`) {
+				t.Errorf("expected rehashed password to be current and still match")
+			}
+		})
+	}
+}
+
+func TestPasswordMatches_NoPassTheHash(t *testing.T) {
+
+	// Knowing the stored SHA-256 digest must not be enough to log in
+	digest := util.Hash(`hunter2`)
+	u := &UserRecord{Password: digest}
+	if u.PasswordMatches(digest) {
+		t.Errorf("expected stored digest not to be accepted as a password")
+	}
+
+	u = &UserRecord{Password: ``}
+	if u.PasswordMatches(``) {
+		t.Errorf("expected empty stored password never to match")
+	}
+}
+
+func TestHashPassword_Salted(t *testing.T) {
+
+	hash1, _ := HashPassword(`hunter2`)
+	hash2, _ := HashPassword(`hunter2`)
+
+	if hash1 == hash2 {
+		t.Errorf("expected two hashes of the same password to differ")
+	}
+	if !strings.HasPrefix(hash1, `$2`) {
+		t.Errorf("expected a bcrypt hash, got %q", hash1)
+	}
+}
@@ -0,0 +1,134 @@
+package users
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	MaxSSHKeys = 10
+)
+
+var (
+	ErrSSHKeyInvalid   = errors.New("not a valid public key")
+	ErrSSHKeyExists    = errors.New("that key has already been added")
+	ErrSSHKeyLimit     = errors.New("too many keys")
+	ErrSSHKeyNotFound  = errors.New("no matching key found")
+	ErrSSHKeyAmbiguous = errors.New("more than one key matches")
+)
+
+// Parses a single line in authorized_keys format, such as "ssh-ed25519 AAAA... comment"
+func ParseSSHKey(authorizedKey string) (ssh.PublicKey, string, error) {
+	pubKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(strings.TrimSpace(authorizedKey)))
+	if err != nil {
+		return nil, ``, ErrSSHKeyInvalid
+	}
+	return pubKey, comment, nil
+}
+
+// Adds a public key (authorized_keys format) that can be used to log in over SSH.
+// Returns the fingerprint of the key.
+func (u *UserRecord) AddSSHKey(authorizedKey string) (string, error) {
+
+	pubKey, comment, err := ParseSSHKey(authorizedKey)
+	if err != nil {
+		return ``, err
+	}
+
+	if u.HasSSHKey(pubKey) {
+		return ``, ErrSSHKeyExists
+	}
+
+	if len(u.SSHKeys) >= MaxSSHKeys {
+		return ``, ErrSSHKeyLimit
+	}
+
+	// Store it normalized, without any options that may have been included
+	keyLine := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pubKey)))
+	if comment != `` {
+		keyLine += ` ` + comment
+	}
+
+	u.SSHKeys = append(u.SSHKeys, keyLine)
+
+	return ssh.FingerprintSHA256(pubKey), nil
+}
+
+// Removes a key by its position (starting at 1) in the list, or by its fingerprint.
+// A partial fingerprint is accepted as long as it only matches one key.
+// Returns the fingerprint of the removed key.
+func (u *UserRecord) RemoveSSHKey(match string) (string, error) {
+
+	match = strings.TrimSpace(match)
+	if match == `` {
+		return ``, ErrSSHKeyNotFound
+	}
+
+	matchIdx := -1
+
+	if pos, err := strconv.Atoi(match); err == nil {
+		if pos < 1 || pos > len(u.SSHKeys) {
+			return ``, ErrSSHKeyNotFound
+		}
+		matchIdx = pos - 1
+	} else {
+		for i, keyLine := range u.SSHKeys {
+
+			pubKey, _, err := ParseSSHKey(keyLine)
+			if err != nil {
+				continue
+			}
+
+			fingerprint := ssh.FingerprintSHA256(pubKey)
+
+			if match == fingerprint || match == strings.TrimPrefix(fingerprint, `SHA256:`) {
+				matchIdx = i
+				break
+			}
+
+			if strings.Contains(fingerprint, match) {
+				if matchIdx != -1 {
+					return ``, ErrSSHKeyAmbiguous
+				}
+				matchIdx = i
+			}
+		}
+	}
+
+	if matchIdx == -1 {
+		return ``, ErrSSHKeyNotFound
+	}
+
+	matchFingerprint := ``
+	if pubKey, _, err := ParseSSHKey(u.SSHKeys[matchIdx]); err == nil {
+		matchFingerprint = ssh.FingerprintSHA256(pubKey)
+	}
+
+	u.SSHKeys = append(u.SSHKeys[:matchIdx], u.SSHKeys[matchIdx+1:]...)
+
+	return matchFingerprint, nil
+}
+
+// Whether the public key has been added to this user
+func (u *UserRecord) HasSSHKey(pubKey ssh.PublicKey) bool {
+
+	if pubKey == nil {
+		return false
+	}
+
+	keyBytes := pubKey.Marshal()
+
+	for _, keyLine := range u.SSHKeys {
+		if storedKey, _, err := ParseSSHKey(keyLine); err == nil {
+			if bytes.Equal(storedKey.Marshal(), keyBytes) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
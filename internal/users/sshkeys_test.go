@@ -0,0 +1,82 @@
+package users
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newTestSSHKey(t *testing.T) (ssh.PublicKey, string) {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error: %v", err)
+	}
+	pubKey, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatalf("NewPublicKey() error: %v", err)
+	}
+	return pubKey, strings.TrimSpace(string(ssh.MarshalAuthorizedKey(pubKey)))
+}
+
+func TestSSHKeys_AddAndMatch(t *testing.T) {
+
+	u := &UserRecord{}
+
+	key1, line1 := newTestSSHKey(t)
+	key2, _ := newTestSSHKey(t)
+
+	fingerprint, err := u.AddSSHKey(line1 + ` me@home`)
+	if err != nil {
+		t.Fatalf("AddSSHKey() error: %v", err)
+	}
+	if fingerprint != ssh.FingerprintSHA256(key1) {
+		t.Errorf("AddSSHKey() fingerprint = %q, want %q", fingerprint, ssh.FingerprintSHA256(key1))
+	}
+
+	if _, err := u.AddSSHKey(line1); err != ErrSSHKeyExists {
+		t.Errorf("AddSSHKey() duplicate error = %v, want %v", err, ErrSSHKeyExists)
+	}
+
+	if _, err := u.AddSSHKey(`ssh-ed25519 notakey`); err != ErrSSHKeyInvalid {
+		t.Errorf("AddSSHKey() invalid error = %v, want %v", err, ErrSSHKeyInvalid)
+	}
+
+	if !u.HasSSHKey(key1) {
+		t.Errorf("HasSSHKey() = false for an added key")
+	}
+	if u.HasSSHKey(key2) {
+		t.Errorf("HasSSHKey() = true for a key that was never added")
+	}
+}
+
+func TestSSHKeys_Remove(t *testing.T) {
+
+	u := &UserRecord{}
+
+	key1, line1 := newTestSSHKey(t)
+	key2, line2 := newTestSSHKey(t)
+
+	u.AddSSHKey(line1)
+	u.AddSSHKey(line2)
+
+	if _, err := u.RemoveSSHKey(`3`); err != ErrSSHKeyNotFound {
+		t.Errorf("RemoveSSHKey() out of range error = %v, want %v", err, ErrSSHKeyNotFound)
+	}
+
+	removed, err := u.RemoveSSHKey(ssh.FingerprintSHA256(key2))
+	if err != nil || removed != ssh.FingerprintSHA256(key2) {
+		t.Errorf("RemoveSSHKey() by fingerprint = %q, %v", removed, err)
+	}
+
+	removed, err = u.RemoveSSHKey(`1`)
+	if err != nil || removed != ssh.FingerprintSHA256(key1) {
+		t.Errorf("RemoveSSHKey() by position = %q, %v", removed, err)
+	}
+
+	if len(u.SSHKeys) != 0 {
+		t.Errorf("SSHKeys has %d keys after removing all of them", len(u.SSHKeys))
+	}
+}
@@ -34,6 +34,7 @@ type UserRecord struct {
 	Username       string                `yaml:"username"`
 	Password       string                `yaml:"password"`
 	Joined         time.Time             `yaml:"joined"`
+	LastLogin      time.Time             `yaml:"lastlogin,omitempty"`
 	Macros         map[string]string     `yaml:"macros,omitempty"`  // Up to 10 macros, just string commands.
 	Aliases        map[string]string     `yaml:"aliases,omitempty"` // string=>string remapping of commands
 	Character      *characters.Character `yaml:"character,omitempty"`
@@ -45,6 +46,8 @@ type UserRecord struct {
 	ScreenReader   bool                  `yaml:"screenreader,omitempty"` // Are they using a screen reader? (We should remove excess symbols)
 	EmailAddress   string                `yaml:"emailaddress,omitempty"` // Email address (if provided)
 	TipsComplete   map[string]bool       `yaml:"tipscomplete,omitempty"` // Tips the user has followed/completed so they can be quiet
+	SSHKeys        []string              `yaml:"sshkeys,omitempty"`      // Public keys (authorized_keys format) allowed to log in over SSH
+	Ignored        map[int]string        `yaml:"ignored,omitempty"`      // userId=>character name of players whose communications they don't want
 	EventLog       UserLog               `yaml:"-"`                      // Do not retain in user file (for now)
 	LastMusic      string                `yaml:"-"`                      // Keeps track of the last music that was played
 	connectionId   uint64
@@ -54,8 +57,9 @@ type UserRecord struct {
 	lastInputRound uint64
 	tempDataStore  map[string]any
 	activePrompt   *prompt.Prompt
-	isZombie       bool // are they a zombie currently?
-	inputBlocked   bool // Whether input is currently intentionally turned off (for a certain category of commands)
+	isZombie       bool     // are they a zombie currently?
+	inputBlocked   bool     // Whether input is currently intentionally turned off (for a certain category of commands)
+	travelSteps    []string // Exits still to be taken while speedwalking or traveling
 }
 
 func NewUserRecord(userId int, connectionId uint64) *UserRecord {
@@ -89,21 +93,25 @@ func (u *UserRecord) ClientSettings() connections.ClientSettings {
 }
 
 func (u *UserRecord) PasswordMatches(input string) bool {
+	return passwordMatches(u.Password, input)
+}
 
-	if input == u.Password {
-		return true
-	}
-
-	if u.Password == util.Hash(input) {
-		return true
-	}
+// Whether the stored password is plaintext, unsalted or hashed with an outdated cost
+// Should be checked after a successful PasswordMatches() so it can be rehashed.
+func (u *UserRecord) PasswordNeedsRehash() bool {
+	return passwordNeedsRehash(u.Password)
+}
 
-	// In case we reset the password to a plaintext string
-	if input == util.Hash(u.Password) {
-		return true
+// Rehashes an already verified password using the current hashing scheme.
+// Unlike SetPassword() it skips validation, since legacy passwords
+// may not meet the current length requirements.
+func (u *UserRecord) RehashPassword(pw string) error {
+	hash, err := HashPassword(pw)
+	if err != nil {
+		return err
 	}
-
-	return false
+	u.Password = hash
+	return nil
 }
 
 func (u *UserRecord) AddCommandAlias(input string, output string) (addedAlias string, deletedAlias string) {
@@ -358,6 +366,15 @@ func (u *UserRecord) AddBuff(buffId int, source string) {
 
 }
 
+// Sets the exits to take, one per turn, while speedwalking or traveling. nil stops them.
+func (u *UserRecord) SetTravelSteps(exitNames []string) {
+	u.travelSteps = exitNames
+}
+
+func (u *UserRecord) GetTravelSteps() []string {
+	return u.travelSteps
+}
+
 func (u *UserRecord) SendText(txt string) {
 
 	events.AddToQueue(events.Message{
@@ -367,6 +384,18 @@ func (u *UserRecord) SendText(txt string) {
 
 }
 
+// Sends text that came from another user, such as party chat.
+// It won't reach them if they are ignoring that user.
+func (u *UserRecord) SendTextFrom(sourceUserId int, txt string) {
+
+	events.AddToQueue(events.Message{
+		UserId:       u.UserId,
+		SourceUserId: sourceUserId,
+		Text:         txt + "\n",
+	})
+
+}
+
 func (u *UserRecord) SendWebClientCommand(txt string) {
 
 	events.AddToQueue(events.WebClientCommand{
@@ -559,7 +588,12 @@ func (u *UserRecord) SetPassword(pw string) error {
 		return fmt.Errorf("password must be between %d and %d characters long", validation.PasswordSizeMin, validation.PasswordSizeMax)
 	}
 
-	u.Password = util.Hash(pw)
+	hash, err := HashPassword(pw)
+	if err != nil {
+		return err
+	}
+
+	u.Password = hash
 	return nil
 }
 
@@ -3,22 +3,15 @@ package users
 import (
 	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
 	"regexp"
-	"strconv"
 	"strings"
 	"time"
 
-	"github.com/GoMudEngine/GoMud/internal/characters"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/connections"
 	"github.com/GoMudEngine/GoMud/internal/mobs"
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/util"
-
-	//
-	"gopkg.in/yaml.v2"
 )
 
 var (
@@ -160,6 +153,9 @@ func LoginUser(user *UserRecord, connectionId connections.ConnectionId) (*UserRe
 
 	user.Character.SetAdjective(`zombie`, false)
 
+	// Lets the character find things that belong to the user, such as their clan tag
+	user.Character.SetUserId(user.UserId)
+
 	// If they're already logged in
 	if userId, ok := userManager.Usernames[user.Username]; ok {
 
@@ -218,6 +214,8 @@ func LoginUser(user *UserRecord, connectionId connections.ConnectionId) (*UserRe
 
 	mudlog.Info("LOGIN", "userId", user.UserId)
 
+	user.LastLogin = time.Now()
+
 	user.EventLog.Add(`conn`, `Connected`)
 
 	for _, mobInstId := range user.Character.GetCharmIds() {
@@ -296,9 +294,6 @@ func CreateUser(u *UserRecord) error {
 	u.UserId = GetUniqueUserId()
 	u.Role = RoleUser
 
-	idx := NewUserIndex()
-	idx.AddUser(u.UserId, u.Username)
-
 	if err := SaveUser(*u); err != nil {
 		return err
 	}
@@ -313,25 +308,19 @@ func CreateUser(u *UserRecord) error {
 
 func LoadUser(username string, skipValidation ...bool) (*UserRecord, error) {
 
-	idx := NewUserIndex()
-	userId, found := idx.FindByUsername(username)
+	store := GetUserStore()
+
+	userId, found := store.FindUserId(username)
 
 	if !found {
 		return nil, errors.New("user doesn't exist")
 	}
 
-	userFilePath := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`, `/`, strconv.Itoa(int(userId))+`.yaml`)
-
-	userFileTxt, err := os.ReadFile(userFilePath)
+	loadedUser, err := store.Load(userId)
 	if err != nil {
 		return nil, err
 	}
 
-	loadedUser := &UserRecord{}
-	if err := yaml.Unmarshal([]byte(userFileTxt), loadedUser); err != nil {
-		mudlog.Error("LoadUser", "error", err.Error())
-	}
-
 	if len(skipValidation) == 0 || !skipValidation[0] {
 		if err := loadedUser.Character.Validate(true); err == nil {
 			SaveUser(*loadedUser)
@@ -352,47 +341,14 @@ func LoadUser(username string, skipValidation ...bool) (*UserRecord, error) {
 // Stops searching if false is returned.
 func SearchOfflineUsers(searchFunc func(u *UserRecord) bool) {
 
-	basePath := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`)
-
-	filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
+	GetUserStore().Search(func(u *UserRecord) bool {
 
-		if err != nil {
-			return err
-		}
-
-		if info.IsDir() {
-			return nil
-		}
-
-		if len(path) > 10 && path[len(path)-10:] == `.alts.yaml` {
-			return nil
+		// If this is an online user, skip it
+		if _, ok := userManager.Usernames[u.Username]; ok {
+			return true
 		}
 
-		var uRecord UserRecord
-
-		fpathLower := path[len(path)-5:] // Only need to compare the last 5 characters
-		if fpathLower == `.yaml` {
-
-			bytes, err := os.ReadFile(path)
-			if err != nil {
-				return err
-			}
-
-			err = yaml.Unmarshal(bytes, &uRecord)
-			if err != nil {
-				return err
-			}
-
-			// If this is an online user, skip it
-			if _, ok := userManager.Usernames[uRecord.Username]; ok {
-				return nil
-			}
-
-			if res := searchFunc(&uRecord); !res {
-				return errors.New(`done searching`)
-			}
-		}
-		return nil
+		return searchFunc(u)
 	})
 
 }
@@ -440,47 +396,16 @@ func ValidatePassword(pw string) error {
 }
 
 // searches for a character name and returns the user that owns it
-// Slow and possibly memory intensive - use strategically
+// Slow and possibly memory intensive with flat file storage - use strategically
 func CharacterNameSearch(nameToFind string) (foundUserId int, foundUserName string) {
 
-	foundUserId = 0
-	foundUserName = ``
-
-	SearchOfflineUsers(func(u *UserRecord) bool {
-
-		if strings.EqualFold(u.Character.Name, nameToFind) {
-			foundUserId = u.UserId
-			foundUserName = u.Username
-			return false
-		}
-
-		// Not found? Search alts...
-
-		for _, char := range characters.LoadAlts(u.UserId) {
-			if strings.EqualFold(char.Name, nameToFind) {
-				foundUserId = u.UserId
-				foundUserName = u.Username
-				return false
-			}
-		}
-
-		return true
-	})
+	foundUserId, foundUserName, _ = GetUserStore().FindCharacterName(nameToFind)
 
 	return foundUserId, foundUserName
 }
 
 func SaveUser(u UserRecord, isAutoSave ...bool) error {
 
-	fileWritten := false
-	tmpSaved := false
-	tmpCopied := false
-	completed := false
-
-	defer func() {
-		mudlog.Info("SaveUser()", "username", u.Username, "wrote-file", fileWritten, "tmp-file", tmpSaved, "tmp-copied", tmpCopied, "completed", completed)
-	}()
-
 	// Don't save if they haven't entered the real game world yet.
 	//if u.Character.RoomId < 0 {
 	//return errors.New("Has not started game.")
@@ -492,74 +417,18 @@ func SaveUser(u UserRecord, isAutoSave ...bool) error {
 		}
 	}
 
-	data, err := yaml.Marshal(&u)
-	if err != nil {
-		return err
-	}
-
-	carefulSave := configs.GetFilePathsConfig().CarefulSaveFiles
-
-	path := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`, `/`, strconv.Itoa(u.UserId)+`.yaml`)
-
-	saveFilePath := path
-	if carefulSave { // careful save first saves a {filename}.new file
-		saveFilePath += `.new`
-	}
-
-	err = os.WriteFile(saveFilePath, data, 0777)
-	if err != nil {
-		return err
-	}
-	fileWritten = true
-	if carefulSave {
-		tmpSaved = true
-	}
-
-	if carefulSave {
-		//
-		// Once the file is written, rename it to remove the .new suffix and overwrite the old file
-		//
-		if err := os.Rename(saveFilePath, path); err != nil {
-			return err
-		}
-		tmpCopied = true
-	}
-
-	completed = true
-
-	return nil
+	return GetUserStore().Save(&u)
 }
 
 func GetUniqueUserId() int {
 
-	// if highestUserId is zero, loop through users and get real highest.
-
-	highestUserId := 0
-
-	idx := NewUserIndex()
-	if idx.Exists() {
-
-		highestUserId = idx.GetHighestUserId()
-
-	} else {
+	highestUserId := GetUserStore().HighestUserId()
 
-		// Check all user id's of offline users
-		SearchOfflineUsers(func(u *UserRecord) bool {
-
-			if u.UserId > highestUserId {
-				highestUserId = u.UserId
-			}
-
-			return true
-		})
-
-		// Check all user id's of online users
-		for _, u := range GetAllActiveUsers() {
-			if u.UserId > highestUserId {
-				highestUserId = u.UserId
-			}
+	// Check all user id's of online users
+	for _, u := range GetAllActiveUsers() {
+		if u.UserId > highestUserId {
+			highestUserId = u.UserId
 		}
-
 	}
 
 	// Increment the highestUserId before returning a new one
@@ -576,8 +445,7 @@ func Exists(name string) bool {
 		}
 	}
 
-	idx := NewUserIndex()
-	_, found := idx.FindByUsername(name)
+	_, found := GetUserStore().FindUserId(name)
 
 	return found
 }
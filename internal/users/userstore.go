@@ -0,0 +1,272 @@
+package users
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+)
+
+const (
+	StorageYaml   = `yaml`
+	StorageSQLite = `sqlite`
+)
+
+var (
+	ErrUnknownStorage   = errors.New(`unknown user storage`)
+	ErrStoreNotEmpty    = errors.New(`the database already contains users`)
+	ErrInvalidUserQuery = errors.New(`invalid user query`)
+
+	userStore UserStore = nil
+)
+
+// Where user records, along with their alts and inboxes, are kept
+type UserStore interface {
+	// Name of the backend ("yaml", "sqlite")
+	Name() string
+	// Finds a userId by username (case insensitive)
+	FindUserId(username string) (int, bool)
+	// Finds the owner of a character name, including alts (case insensitive)
+	FindCharacterName(name string) (userId int, username string, found bool)
+	// The highest userId that has been saved
+	HighestUserId() int
+	// Loads a user record by userId
+	Load(userId int) (*UserRecord, error)
+	// Saves a user record that may or may not already exist
+	Save(u *UserRecord) error
+	// Runs every saved user record through a function, stopping when false is returned
+	Search(searchFunc func(u *UserRecord) bool) error
+	// Returns summaries of the saved users that match a query
+	Query(q UserQuery) ([]UserSummary, error)
+	// Alts are stored separately from user records
+	LoadAlts(userId int) ([]characters.Character, error)
+	SaveAlts(userId int, alts []characters.Character) error
+	Close() error
+}
+
+// Filters for Query(). Zero values are ignored.
+type UserQuery struct {
+	Name          string    // Partial match on username or character name
+	MinLevel      int       // Character level at least this
+	MaxLevel      int       // Character level at most this
+	LoginAfter    time.Time // Last logged in after this
+	LoginBefore   time.Time // Last logged in before this (or never)
+	Role          string    // Exact role, such as "admin"
+	SortBy        string    // "userid" (default), "username", "level" or "lastlogin"
+	SortDescended bool
+	Limit         int
+}
+
+// The searchable details of a saved user
+type UserSummary struct {
+	UserId        int
+	Username      string
+	Role          string
+	CharacterName string
+	Level         int
+	Joined        time.Time
+	LastLogin     time.Time
+}
+
+// Opens the configured user storage.
+// Should be called once at startup, before any users are loaded.
+func InitUserStore() error {
+
+	fp := configs.GetFilePathsConfig()
+
+	store, err := OpenUserStore(fp.UserStorage.String(), fp.UserDatabaseFile.String())
+	if err != nil {
+		return err
+	}
+
+	SetUserStore(store)
+
+	return nil
+}
+
+// Opens a user storage backend by name.
+// dbFile is only used by backends that need one.
+func OpenUserStore(backend string, dbFile string) (UserStore, error) {
+
+	switch backend {
+	case StorageYaml:
+		return NewYamlUserStore(), nil
+	case StorageSQLite:
+		return NewSQLiteUserStore(dbFile)
+	}
+
+	return nil, ErrUnknownStorage
+}
+
+// Replaces the current user storage, closing the old one
+func SetUserStore(store UserStore) {
+
+	if userStore != nil && userStore != store {
+		userStore.Close()
+	}
+
+	userStore = store
+
+	// Alts follow the users wherever they are stored
+	if altStore, ok := store.(characters.AltStorage); ok && store.Name() != StorageYaml {
+		characters.SetAltStorage(altStore)
+	} else {
+		characters.SetAltStorage(nil)
+	}
+}
+
+// Returns the current user storage. Defaults to yaml files if none has been set.
+func GetUserStore() UserStore {
+	if userStore == nil {
+		SetUserStore(NewYamlUserStore())
+	}
+	return userStore
+}
+
+// Returns summaries of saved users matching the query, without loading full records where possible.
+// Online users may have unsaved changes that aren't reflected.
+func QueryUsers(q UserQuery) ([]UserSummary, error) {
+	return GetUserStore().Query(q)
+}
+
+// Copies every user (with their alts and inboxes) from one storage to another.
+// The destination must not already contain any users.
+// Returns the number of users copied.
+func CopyUsers(from UserStore, to UserStore) (int, error) {
+
+	if to.HighestUserId() > 0 {
+		return 0, ErrStoreNotEmpty
+	}
+
+	copiedCt := 0
+	var copyErr error
+
+	err := from.Search(func(u *UserRecord) bool {
+
+		if copyErr = to.Save(u); copyErr != nil {
+			return false
+		}
+
+		alts, err := from.LoadAlts(u.UserId)
+		if err != nil {
+			copyErr = err
+			return false
+		}
+
+		if len(alts) > 0 {
+			if copyErr = to.SaveAlts(u.UserId, alts); copyErr != nil {
+				return false
+			}
+		}
+
+		copiedCt++
+
+		return true
+	})
+
+	if copyErr != nil {
+		return copiedCt, copyErr
+	}
+
+	return copiedCt, err
+}
+
+// Migrates the flat yaml user files into the configured SQLite database.
+// The flat files are left untouched.
+func MigrateFlatFilesToSQLite() (int, error) {
+
+	sqliteStore, err := NewSQLiteUserStore(configs.GetFilePathsConfig().UserDatabaseFile.String())
+	if err != nil {
+		return 0, err
+	}
+	defer sqliteStore.Close()
+
+	return CopyUsers(NewYamlUserStore(), sqliteStore)
+}
+
+func newUserSummary(u *UserRecord) UserSummary {
+	s := UserSummary{
+		UserId:    u.UserId,
+		Username:  u.Username,
+		Role:      u.Role,
+		Joined:    u.Joined,
+		LastLogin: u.LastLogin,
+	}
+	if u.Character != nil {
+		s.CharacterName = u.Character.Name
+		s.Level = u.Character.Level
+	}
+	return s
+}
+
+// Whether a summary matches the query.
+// Used by stores that can't filter on their own.
+func (q UserQuery) Matches(s UserSummary) bool {
+
+	if q.Name != `` {
+		name := strings.ToLower(q.Name)
+		if !strings.Contains(strings.ToLower(s.Username), name) && !strings.Contains(strings.ToLower(s.CharacterName), name) {
+			return false
+		}
+	}
+
+	if q.MinLevel > 0 && s.Level < q.MinLevel {
+		return false
+	}
+
+	if q.MaxLevel > 0 && s.Level > q.MaxLevel {
+		return false
+	}
+
+	if !q.LoginAfter.IsZero() && !s.LastLogin.After(q.LoginAfter) {
+		return false
+	}
+
+	if !q.LoginBefore.IsZero() && !s.LastLogin.Before(q.LoginBefore) {
+		return false
+	}
+
+	if q.Role != `` && q.Role != s.Role {
+		return false
+	}
+
+	return true
+}
+
+// Sorts and limits summaries according to the query.
+// Used by stores that can't sort on their own.
+func (q UserQuery) Apply(summaries []UserSummary) []UserSummary {
+
+	sort.SliceStable(summaries, func(i, j int) bool {
+		a, b := summaries[i], summaries[j]
+		if q.SortDescended {
+			a, b = b, a
+		}
+		switch q.SortBy {
+		case `username`:
+			return strings.ToLower(a.Username) < strings.ToLower(b.Username)
+		case `level`:
+			return a.Level < b.Level
+		case `lastlogin`:
+			return a.LastLogin.Before(b.LastLogin)
+		}
+		return a.UserId < b.UserId
+	})
+
+	if q.Limit > 0 && len(summaries) > q.Limit {
+		summaries = summaries[:q.Limit]
+	}
+
+	return summaries
+}
+
+func (q UserQuery) validate() error {
+	switch q.SortBy {
+	case ``, `userid`, `username`, `level`, `lastlogin`:
+		return nil
+	}
+	return ErrInvalidUserQuery
+}
@@ -0,0 +1,475 @@
+package users
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"gopkg.in/yaml.v2"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	sqliteSchemaVersion = 1
+)
+
+// Full records are kept as yaml (the same as the flat files), alongside
+// columns for anything that needs to be looked up or queried.
+var sqliteSchema = []string{
+	`CREATE TABLE IF NOT EXISTS users (
+		user_id        INTEGER PRIMARY KEY,
+		username       TEXT    NOT NULL UNIQUE COLLATE NOCASE,
+		role           TEXT    NOT NULL DEFAULT '',
+		character_name TEXT    NOT NULL DEFAULT '' COLLATE NOCASE,
+		level          INTEGER NOT NULL DEFAULT 0,
+		joined         INTEGER NOT NULL DEFAULT 0,
+		last_login     INTEGER NOT NULL DEFAULT 0,
+		record         TEXT    NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS users_character_name ON users (character_name)`,
+	`CREATE INDEX IF NOT EXISTS users_level ON users (level)`,
+	`CREATE INDEX IF NOT EXISTS users_last_login ON users (last_login)`,
+	`CREATE TABLE IF NOT EXISTS alts (
+		user_id        INTEGER NOT NULL,
+		position       INTEGER NOT NULL,
+		character_name TEXT    NOT NULL DEFAULT '' COLLATE NOCASE,
+		level          INTEGER NOT NULL DEFAULT 0,
+		record         TEXT    NOT NULL,
+		PRIMARY KEY (user_id, position)
+	)`,
+	`CREATE INDEX IF NOT EXISTS alts_character_name ON alts (character_name)`,
+	`CREATE TABLE IF NOT EXISTS inbox (
+		user_id      INTEGER NOT NULL,
+		position     INTEGER NOT NULL,
+		from_user_id INTEGER NOT NULL DEFAULT 0,
+		read         INTEGER NOT NULL DEFAULT 0,
+		date_sent    INTEGER NOT NULL DEFAULT 0,
+		record       TEXT    NOT NULL,
+		PRIMARY KEY (user_id, position)
+	)`,
+}
+
+// Stores users, alts and inboxes in a single SQLite database
+type SQLiteUserStore struct {
+	db       *sql.DB
+	filename string
+}
+
+func NewSQLiteUserStore(filename string) (*SQLiteUserStore, error) {
+
+	if dir := filepath.Dir(filename); dir != `` {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	db, err := sql.Open(`sqlite`, filename+`?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)`)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite only allows one writer at a time anyways
+	db.SetMaxOpenConns(1)
+
+	s := &SQLiteUserStore{
+		db:       db,
+		filename: filename,
+	}
+
+	if err := s.createSchema(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+
+	return s, nil
+}
+
+func (s *SQLiteUserStore) createSchema() error {
+
+	var version int
+	if err := s.db.QueryRow(`PRAGMA user_version`).Scan(&version); err != nil {
+		return err
+	}
+
+	if version > sqliteSchemaVersion {
+		return fmt.Errorf("database schema version %d is newer than supported version %d", version, sqliteSchemaVersion)
+	}
+
+	for _, stmt := range sqliteSchema {
+		if _, err := s.db.Exec(stmt); err != nil {
+			return err
+		}
+	}
+
+	_, err := s.db.Exec(fmt.Sprintf(`PRAGMA user_version = %d`, sqliteSchemaVersion))
+	return err
+}
+
+func (s *SQLiteUserStore) Name() string {
+	return StorageSQLite
+}
+
+func (s *SQLiteUserStore) FindUserId(username string) (int, bool) {
+
+	var userId int
+	err := s.db.QueryRow(`SELECT user_id FROM users WHERE username = ?`, username).Scan(&userId)
+	if err != nil {
+		if err != sql.ErrNoRows {
+			mudlog.Error("SQLiteUserStore.FindUserId()", "error", err)
+		}
+		return 0, false
+	}
+
+	return userId, true
+}
+
+func (s *SQLiteUserStore) FindCharacterName(name string) (int, string, bool) {
+
+	var userId int
+	var username string
+
+	err := s.db.QueryRow(`
+		SELECT u.user_id, u.username FROM users u WHERE u.character_name = ?
+		UNION ALL
+		SELECT u.user_id, u.username FROM alts a JOIN users u ON u.user_id = a.user_id WHERE a.character_name = ?
+		LIMIT 1`, name, name).Scan(&userId, &username)
+
+	if err != nil {
+		if err != sql.ErrNoRows {
+			mudlog.Error("SQLiteUserStore.FindCharacterName()", "error", err)
+		}
+		return 0, ``, false
+	}
+
+	return userId, username, true
+}
+
+func (s *SQLiteUserStore) HighestUserId() int {
+
+	var userId sql.NullInt64
+	if err := s.db.QueryRow(`SELECT MAX(user_id) FROM users`).Scan(&userId); err != nil {
+		mudlog.Error("SQLiteUserStore.HighestUserId()", "error", err)
+	}
+
+	return int(userId.Int64)
+}
+
+func (s *SQLiteUserStore) Load(userId int) (*UserRecord, error) {
+
+	var record string
+	if err := s.db.QueryRow(`SELECT record FROM users WHERE user_id = ?`, userId).Scan(&record); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	loadedUser := &UserRecord{}
+	if err := yaml.Unmarshal([]byte(record), loadedUser); err != nil {
+		mudlog.Error("LoadUser", "error", err.Error())
+	}
+
+	inbox, err := s.loadInbox(userId)
+	if err != nil {
+		return nil, err
+	}
+	loadedUser.Inbox = inbox
+
+	return loadedUser, nil
+}
+
+func (s *SQLiteUserStore) loadInbox(userId int) (Inbox, error) {
+
+	rows, err := s.db.Query(`SELECT record FROM inbox WHERE user_id = ? ORDER BY position`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var inbox Inbox
+	for rows.Next() {
+
+		var record string
+		if err := rows.Scan(&record); err != nil {
+			return nil, err
+		}
+
+		msg := Message{}
+		if err := yaml.Unmarshal([]byte(record), &msg); err != nil {
+			mudlog.Error("LoadUser", "error", err.Error())
+			continue
+		}
+
+		inbox = append(inbox, msg)
+	}
+
+	return inbox, rows.Err()
+}
+
+func (s *SQLiteUserStore) Save(u *UserRecord) error {
+
+	// The inbox is saved to its own table
+	recordCopy := *u
+	recordCopy.Inbox = nil
+
+	data, err := yaml.Marshal(&recordCopy)
+	if err != nil {
+		return err
+	}
+
+	summary := newUserSummary(u)
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO users (user_id, username, role, character_name, level, joined, last_login, record)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (user_id) DO UPDATE SET
+			username = excluded.username,
+			role = excluded.role,
+			character_name = excluded.character_name,
+			level = excluded.level,
+			joined = excluded.joined,
+			last_login = excluded.last_login,
+			record = excluded.record`,
+		summary.UserId, summary.Username, summary.Role, summary.CharacterName, summary.Level,
+		unixOrZero(summary.Joined), unixOrZero(summary.LastLogin), string(data),
+	)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(`DELETE FROM inbox WHERE user_id = ?`, u.UserId); err != nil {
+		return err
+	}
+
+	for i, msg := range u.Inbox {
+
+		msgData, err := yaml.Marshal(&msg)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO inbox (user_id, position, from_user_id, read, date_sent, record) VALUES (?, ?, ?, ?, ?, ?)`,
+			u.UserId, i, msg.FromUserId, msg.Read, unixOrZero(msg.DateSent), string(msgData),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteUserStore) Search(searchFunc func(u *UserRecord) bool) error {
+
+	// Collect the ids first, so that searchFunc is free to use the database (such as saving)
+	rows, err := s.db.Query(`SELECT user_id FROM users ORDER BY user_id`)
+	if err != nil {
+		return err
+	}
+
+	userIds := []int{}
+	for rows.Next() {
+		var userId int
+		if err := rows.Scan(&userId); err != nil {
+			rows.Close()
+			return err
+		}
+		userIds = append(userIds, userId)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, userId := range userIds {
+
+		u, err := s.Load(userId)
+		if err != nil {
+			return err
+		}
+
+		if !searchFunc(u) {
+			break
+		}
+	}
+
+	return nil
+}
+
+// Only reads the indexed columns, user records aren't loaded.
+func (s *SQLiteUserStore) Query(q UserQuery) ([]UserSummary, error) {
+
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	where := []string{}
+	args := []any{}
+
+	if q.Name != `` {
+		like := `%` + strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(q.Name) + `%`
+		where = append(where, `(username LIKE ? ESCAPE '\' OR character_name LIKE ? ESCAPE '\')`)
+		args = append(args, like, like)
+	}
+
+	if q.MinLevel > 0 {
+		where = append(where, `level >= ?`)
+		args = append(args, q.MinLevel)
+	}
+
+	if q.MaxLevel > 0 {
+		where = append(where, `level <= ?`)
+		args = append(args, q.MaxLevel)
+	}
+
+	if !q.LoginAfter.IsZero() {
+		where = append(where, `last_login > ?`)
+		args = append(args, q.LoginAfter.Unix())
+	}
+
+	if !q.LoginBefore.IsZero() {
+		where = append(where, `last_login < ?`)
+		args = append(args, q.LoginBefore.Unix())
+	}
+
+	if q.Role != `` {
+		where = append(where, `role = ?`)
+		args = append(args, q.Role)
+	}
+
+	query := `SELECT user_id, username, role, character_name, level, joined, last_login FROM users`
+	if len(where) > 0 {
+		query += ` WHERE ` + strings.Join(where, ` AND `)
+	}
+
+	orderBy := `user_id`
+	switch q.SortBy {
+	case `username`:
+		orderBy = `username`
+	case `level`:
+		orderBy = `level`
+	case `lastlogin`:
+		orderBy = `last_login`
+	}
+	if q.SortDescended {
+		orderBy += ` DESC`
+	}
+	query += ` ORDER BY ` + orderBy
+
+	if q.Limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, q.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []UserSummary{}
+	for rows.Next() {
+
+		var summary UserSummary
+		var joined, lastLogin int64
+
+		if err := rows.Scan(&summary.UserId, &summary.Username, &summary.Role, &summary.CharacterName, &summary.Level, &joined, &lastLogin); err != nil {
+			return nil, err
+		}
+
+		summary.Joined = timeOrZero(joined)
+		summary.LastLogin = timeOrZero(lastLogin)
+
+		results = append(results, summary)
+	}
+
+	return results, rows.Err()
+}
+
+func (s *SQLiteUserStore) LoadAlts(userId int) ([]characters.Character, error) {
+
+	rows, err := s.db.Query(`SELECT record FROM alts WHERE user_id = ? ORDER BY position`, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alts []characters.Character
+	for rows.Next() {
+
+		var record string
+		if err := rows.Scan(&record); err != nil {
+			return nil, err
+		}
+
+		char := characters.Character{}
+		if err := yaml.Unmarshal([]byte(record), &char); err != nil {
+			mudlog.Error("LoadAlts", "error", err.Error())
+			continue
+		}
+
+		alts = append(alts, char)
+	}
+
+	return alts, rows.Err()
+}
+
+func (s *SQLiteUserStore) SaveAlts(userId int, alts []characters.Character) error {
+
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM alts WHERE user_id = ?`, userId); err != nil {
+		return err
+	}
+
+	for i, char := range alts {
+
+		data, err := yaml.Marshal(&char)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO alts (user_id, position, character_name, level, record) VALUES (?, ?, ?, ?, ?)`,
+			userId, i, char.Name, char.Level, string(data),
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLiteUserStore) Close() error {
+	return s.db.Close()
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+func timeOrZero(unix int64) time.Time {
+	if unix == 0 {
+		return time.Time{}
+	}
+	return time.Unix(unix, 0)
+}
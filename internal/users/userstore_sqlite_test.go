@@ -0,0 +1,200 @@
+package users
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+)
+
+func newTestSQLiteStore(t *testing.T) *SQLiteUserStore {
+	store, err := NewSQLiteUserStore(filepath.Join(t.TempDir(), `users.db`))
+	if err != nil {
+		t.Fatalf("NewSQLiteUserStore() error: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func newTestUser(userId int, username string, charName string, level int, lastLogin time.Time) *UserRecord {
+	return &UserRecord{
+		UserId:    userId,
+		Username:  username,
+		Role:      RoleUser,
+		Joined:    time.Unix(1700000000, 0),
+		LastLogin: lastLogin,
+		Character: &characters.Character{
+			Name:  charName,
+			Level: level,
+		},
+	}
+}
+
+func TestSQLiteUserStore_SaveAndLoad(t *testing.T) {
+
+	store := newTestSQLiteStore(t)
+
+	u := newTestUser(5, `Alice`, `Alicia`, 3, time.Unix(1710000000, 0))
+	u.Inbox = Inbox{
+		{FromName: `Bob`, Message: `newest`},
+		{FromName: `Bob`, Message: `oldest`, Read: true},
+	}
+
+	if err := store.Save(u); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	// Saving again should update, not duplicate
+	u.Character.Level = 4
+	if err := store.Save(u); err != nil {
+		t.Fatalf("Save() update error: %v", err)
+	}
+
+	userId, found := store.FindUserId(`alice`)
+	if !found || userId != 5 {
+		t.Fatalf("FindUserId(alice) = %d, %v", userId, found)
+	}
+
+	if _, found := store.FindUserId(`nobody`); found {
+		t.Errorf("FindUserId(nobody) found a user")
+	}
+
+	loaded, err := store.Load(5)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+
+	if loaded.Username != `Alice` || loaded.Character.Name != `Alicia` || loaded.Character.Level != 4 {
+		t.Errorf("Load() = %s/%s/%d", loaded.Username, loaded.Character.Name, loaded.Character.Level)
+	}
+
+	if len(loaded.Inbox) != 2 || loaded.Inbox[0].Message != `newest` || !loaded.Inbox[1].Read {
+		t.Errorf("Load() inbox = %+v", loaded.Inbox)
+	}
+
+	if !loaded.LastLogin.Equal(u.LastLogin) {
+		t.Errorf("Load() LastLogin = %v, want %v", loaded.LastLogin, u.LastLogin)
+	}
+
+	if _, err := store.Load(6); err != ErrNotFound {
+		t.Errorf("Load() missing user error = %v, want %v", err, ErrNotFound)
+	}
+
+	if got := store.HighestUserId(); got != 5 {
+		t.Errorf("HighestUserId() = %d, want 5", got)
+	}
+}
+
+func TestSQLiteUserStore_Alts(t *testing.T) {
+
+	store := newTestSQLiteStore(t)
+
+	store.Save(newTestUser(1, `alice`, `Alicia`, 1, time.Time{}))
+
+	alts := []characters.Character{{Name: `Zed`, Level: 9}, {Name: `Yara`, Level: 2}}
+	if err := store.SaveAlts(1, alts); err != nil {
+		t.Fatalf("SaveAlts() error: %v", err)
+	}
+
+	loaded, err := store.LoadAlts(1)
+	if err != nil || len(loaded) != 2 || loaded[0].Name != `Zed` {
+		t.Fatalf("LoadAlts() = %+v, %v", loaded, err)
+	}
+
+	if userId, username, found := store.FindCharacterName(`yara`); !found || userId != 1 || username != `alice` {
+		t.Errorf("FindCharacterName(yara) = %d, %q, %v", userId, username, found)
+	}
+
+	if userId, _, found := store.FindCharacterName(`alicia`); !found || userId != 1 {
+		t.Errorf("FindCharacterName(alicia) = %d, %v", userId, found)
+	}
+
+	// Removing an alt
+	store.SaveAlts(1, alts[:1])
+	if _, _, found := store.FindCharacterName(`yara`); found {
+		t.Errorf("FindCharacterName(yara) found a removed alt")
+	}
+}
+
+func TestSQLiteUserStore_Query(t *testing.T) {
+
+	store := newTestSQLiteStore(t)
+
+	now := time.Unix(1720000000, 0)
+
+	store.Save(newTestUser(1, `alice`, `Alicia`, 10, now.Add(-1*time.Hour)))
+	store.Save(newTestUser(2, `bob`, `Bobbert`, 5, now.Add(-48*time.Hour)))
+	store.Save(newTestUser(3, `carol`, `Carolina`, 20, time.Time{}))
+
+	tests := []struct {
+		name  string
+		query UserQuery
+		want  []int
+	}{
+		{`all`, UserQuery{}, []int{1, 2, 3}},
+		{`name`, UserQuery{Name: `bob`}, []int{2}},
+		{`character name`, UserQuery{Name: `lina`}, []int{3}},
+		{`level range`, UserQuery{MinLevel: 6, MaxLevel: 15}, []int{1}},
+		{`recent login`, UserQuery{LoginAfter: now.Add(-24 * time.Hour)}, []int{1}},
+		{`stale login`, UserQuery{LoginBefore: now.Add(-24 * time.Hour)}, []int{2, 3}},
+		{`by level`, UserQuery{SortBy: `level`, SortDescended: true, Limit: 2}, []int{3, 1}},
+	}
+
+	for _, tt := range tests {
+
+		results, err := store.Query(tt.query)
+		if err != nil {
+			t.Fatalf("%s: Query() error: %v", tt.name, err)
+		}
+
+		// The flat file implementation should agree
+		manual := []UserSummary{}
+		for _, id := range []int{1, 2, 3} {
+			u, _ := store.Load(id)
+			if s := newUserSummary(u); tt.query.Matches(s) {
+				manual = append(manual, s)
+			}
+		}
+		manual = tt.query.Apply(manual)
+
+		for _, r := range [][]UserSummary{results, manual} {
+			if len(r) != len(tt.want) {
+				t.Errorf("%s: got %d results, want %d", tt.name, len(r), len(tt.want))
+				continue
+			}
+			for i, s := range r {
+				if s.UserId != tt.want[i] {
+					t.Errorf("%s: result %d = user %d, want %d", tt.name, i, s.UserId, tt.want[i])
+				}
+			}
+		}
+	}
+
+	if _, err := store.Query(UserQuery{SortBy: `password`}); err != ErrInvalidUserQuery {
+		t.Errorf("Query() bad sort error = %v, want %v", err, ErrInvalidUserQuery)
+	}
+}
+
+func TestCopyUsers(t *testing.T) {
+
+	from := newTestSQLiteStore(t)
+	from.Save(newTestUser(1, `alice`, `Alicia`, 1, time.Time{}))
+	from.Save(newTestUser(2, `bob`, `Bobbert`, 1, time.Time{}))
+	from.SaveAlts(2, []characters.Character{{Name: `Zed`}})
+
+	to := newTestSQLiteStore(t)
+
+	copiedCt, err := CopyUsers(from, to)
+	if err != nil || copiedCt != 2 {
+		t.Fatalf("CopyUsers() = %d, %v", copiedCt, err)
+	}
+
+	if userId, _, found := to.FindCharacterName(`zed`); !found || userId != 2 {
+		t.Errorf("alts were not copied")
+	}
+
+	if _, err := CopyUsers(from, to); err != ErrStoreNotEmpty {
+		t.Errorf("CopyUsers() into a non-empty store error = %v, want %v", err, ErrStoreNotEmpty)
+	}
+}
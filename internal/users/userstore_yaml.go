@@ -0,0 +1,240 @@
+package users
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	errDoneSearching = errors.New(`done searching`)
+)
+
+// Stores each user as a yaml file in the users folder, with a UserIndex for username lookups.
+// Anything that isn't a lookup by username or userId has to read every file.
+type YamlUserStore struct{}
+
+func NewYamlUserStore() *YamlUserStore {
+	return &YamlUserStore{}
+}
+
+func (s *YamlUserStore) Name() string {
+	return StorageYaml
+}
+
+func (s *YamlUserStore) FindUserId(username string) (int, bool) {
+	idx := NewUserIndex()
+	userId, found := idx.FindByUsername(username)
+	return int(userId), found
+}
+
+func (s *YamlUserStore) FindCharacterName(name string) (int, string, bool) {
+
+	foundUserId := 0
+	foundUserName := ``
+
+	s.Search(func(u *UserRecord) bool {
+
+		if u.Character != nil && strings.EqualFold(u.Character.Name, name) {
+			foundUserId = u.UserId
+			foundUserName = u.Username
+			return false
+		}
+
+		// Not found? Search alts...
+
+		for _, char := range characters.LoadAltsFile(u.UserId) {
+			if strings.EqualFold(char.Name, name) {
+				foundUserId = u.UserId
+				foundUserName = u.Username
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return foundUserId, foundUserName, foundUserId > 0
+}
+
+func (s *YamlUserStore) HighestUserId() int {
+
+	idx := NewUserIndex()
+	if idx.Exists() {
+		return idx.GetHighestUserId()
+	}
+
+	highestUserId := 0
+
+	s.Search(func(u *UserRecord) bool {
+		if u.UserId > highestUserId {
+			highestUserId = u.UserId
+		}
+		return true
+	})
+
+	return highestUserId
+}
+
+func (s *YamlUserStore) Load(userId int) (*UserRecord, error) {
+
+	userFileTxt, err := os.ReadFile(s.userFilePath(userId))
+	if err != nil {
+		return nil, err
+	}
+
+	loadedUser := &UserRecord{}
+	if err := yaml.Unmarshal([]byte(userFileTxt), loadedUser); err != nil {
+		mudlog.Error("LoadUser", "error", err.Error())
+	}
+
+	return loadedUser, nil
+}
+
+func (s *YamlUserStore) Save(u *UserRecord) error {
+
+	fileWritten := false
+	tmpSaved := false
+	tmpCopied := false
+	completed := false
+
+	defer func() {
+		mudlog.Info("SaveUser()", "username", u.Username, "wrote-file", fileWritten, "tmp-file", tmpSaved, "tmp-copied", tmpCopied, "completed", completed)
+	}()
+
+	data, err := yaml.Marshal(u)
+	if err != nil {
+		return err
+	}
+
+	carefulSave := configs.GetFilePathsConfig().CarefulSaveFiles
+
+	path := s.userFilePath(u.UserId)
+
+	saveFilePath := path
+	if carefulSave { // careful save first saves a {filename}.new file
+		saveFilePath += `.new`
+	}
+
+	err = os.WriteFile(saveFilePath, data, 0777)
+	if err != nil {
+		return err
+	}
+	fileWritten = true
+	if carefulSave {
+		tmpSaved = true
+	}
+
+	if carefulSave {
+		//
+		// Once the file is written, rename it to remove the .new suffix and overwrite the old file
+		//
+		if err := os.Rename(saveFilePath, path); err != nil {
+			return err
+		}
+		tmpCopied = true
+	}
+
+	// New users need to be added to the index
+	idx := NewUserIndex()
+	if _, found := idx.FindByUsername(u.Username); !found {
+		idx.AddUser(u.UserId, u.Username)
+	}
+
+	completed = true
+
+	return nil
+}
+
+func (s *YamlUserStore) Search(searchFunc func(u *UserRecord) bool) error {
+
+	basePath := util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`)
+
+	err := filepath.Walk(basePath, func(path string, info os.FileInfo, err error) error {
+
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			return nil
+		}
+
+		if len(path) > 10 && path[len(path)-10:] == `.alts.yaml` {
+			return nil
+		}
+
+		var uRecord UserRecord
+
+		fpathLower := path[len(path)-5:] // Only need to compare the last 5 characters
+		if fpathLower == `.yaml` {
+
+			bytes, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			err = yaml.Unmarshal(bytes, &uRecord)
+			if err != nil {
+				return err
+			}
+
+			if res := searchFunc(&uRecord); !res {
+				return errDoneSearching
+			}
+		}
+		return nil
+	})
+
+	if err == errDoneSearching {
+		return nil
+	}
+
+	return err
+}
+
+// Has to read every user file.
+func (s *YamlUserStore) Query(q UserQuery) ([]UserSummary, error) {
+
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	results := []UserSummary{}
+
+	err := s.Search(func(u *UserRecord) bool {
+		if summary := newUserSummary(u); q.Matches(summary) {
+			results = append(results, summary)
+		}
+		return true
+	})
+
+	return q.Apply(results), err
+}
+
+func (s *YamlUserStore) LoadAlts(userId int) ([]characters.Character, error) {
+	return characters.LoadAltsFile(userId), nil
+}
+
+func (s *YamlUserStore) SaveAlts(userId int, alts []characters.Character) error {
+	if !characters.SaveAltsFile(userId, alts) {
+		return errors.New(`could not save alts`)
+	}
+	return nil
+}
+
+func (s *YamlUserStore) Close() error {
+	return nil
+}
+
+func (s *YamlUserStore) userFilePath(userId int) string {
+	return util.FilePath(string(configs.GetFilePathsConfig().DataFiles), `/`, `users`, `/`, strconv.Itoa(userId)+`.yaml`)
+}
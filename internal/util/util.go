@@ -31,6 +31,7 @@ var (
 	roundCount   uint64 = RoundCountMinimum
 	timeTrackers        = map[string]*Accumulator{}
 	serverAddr   string = `Unknown`
+	startTime           = time.Now()
 
 	strippablePrepositions = []string{
 		`onto`,
@@ -101,6 +102,11 @@ func GetServerAddress() string {
 	return serverAddr
 }
 
+// When the server process started
+func GetServerStartTime() time.Time {
+	return startTime
+}
+
 func SetRoundCount(newRoundCount uint64) {
 	roundCount = newRoundCount
 }
@@ -0,0 +1,240 @@
+package weather
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/fileloader"
+	"github.com/GoMudEngine/GoMud/internal/gametime"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/util"
+)
+
+const (
+	// Key of the chance used for any biome without its own entry
+	DefaultBiome = `default`
+)
+
+var (
+	allWeather = map[string]*WeatherSpec{}
+
+	zoneWeather     = map[string]ZoneWeather{}
+	zoneWeatherLock = sync.RWMutex{}
+)
+
+type WeatherSpec struct {
+	WeatherId     string         `yaml:"weatherid"`               // Short text that will uniquely identify this weather ("rain")
+	Name          string         `yaml:"name"`                    // Friendly name ("Rain")
+	Description   string         `yaml:"description,omitempty"`   // Shown when looking at an outdoor room
+	StartMessage  string         `yaml:"startmessage,omitempty"`  // Sent to outdoor rooms when this weather begins
+	StopMessage   string         `yaml:"stopmessage,omitempty"`   // Sent to outdoor rooms when this weather ends
+	PlayerBuffIds []int          `yaml:"playerbuffids,omitempty"` // buffId's that apply to players in outdoor rooms
+	MobBuffIds    []int          `yaml:"mobbuffids,omitempty"`    // buffId's that apply to mobs in outdoor rooms
+	LightMod      int            `yaml:"lightmod,omitempty"`      // -2 to 2 (change) to room visibility
+	Chance        map[string]int `yaml:"chance,omitempty"`        // biome => relative chance of this weather. "default" covers any biome not listed
+	SeasonMod     map[string]int `yaml:"seasonmod,omitempty"`     // season => percent the chance is scaled by (100 = unchanged)
+}
+
+// The current weather of a zone
+type ZoneWeather struct {
+	WeatherId   string
+	StartRound  uint64 // When this weather began
+	ChangeRound uint64 // When this weather may change
+}
+
+func GetSpec(weatherId string) *WeatherSpec {
+	return allWeather[weatherId]
+}
+
+func GetAllWeatherIds() []string {
+	allIds := []string{}
+	for weatherId := range allWeather {
+		allIds = append(allIds, weatherId)
+	}
+	sort.Strings(allIds)
+	return allIds
+}
+
+// Returns the spec of the current weather of a zone, or nil if it has none
+func GetZoneWeather(zone string) *WeatherSpec {
+	zoneWeatherLock.RLock()
+	defer zoneWeatherLock.RUnlock()
+
+	if zw, ok := zoneWeather[zone]; ok {
+		return allWeather[zw.WeatherId]
+	}
+	return nil
+}
+
+// Forces the weather of a zone, which will last a new random duration.
+// Returns false if the weatherId doesn't exist.
+func SetZoneWeather(zone string, weatherId string, roundNow uint64) bool {
+	if _, ok := allWeather[weatherId]; !ok {
+		return false
+	}
+
+	zoneWeatherLock.Lock()
+	defer zoneWeatherLock.Unlock()
+
+	zoneWeather[zone] = ZoneWeather{
+		WeatherId:   weatherId,
+		StartRound:  roundNow,
+		ChangeRound: nextChangeRound(roundNow),
+	}
+
+	return true
+}
+
+// Rolls new weather for a zone if its current weather has run its course.
+// Zones with no weather yet get their first weather silently.
+// Returns the old and new weatherId, and whether there was a change.
+func UpdateZone(zone string, biome string, roundNow uint64) (string, string, bool) {
+
+	zoneWeatherLock.Lock()
+	defer zoneWeatherLock.Unlock()
+
+	zw, ok := zoneWeather[zone]
+	if ok && roundNow < zw.ChangeRound {
+		return zw.WeatherId, zw.WeatherId, false
+	}
+
+	season := gametime.GetDate(roundNow).Season
+
+	newWeatherId := pickWeather(biome, season)
+
+	newZw := ZoneWeather{
+		WeatherId:   newWeatherId,
+		StartRound:  roundNow,
+		ChangeRound: nextChangeRound(roundNow),
+	}
+
+	// Weather that continues keeps its original start round
+	if ok && zw.WeatherId == newWeatherId {
+		newZw.StartRound = zw.StartRound
+	}
+
+	zoneWeather[zone] = newZw
+
+	return zw.WeatherId, newWeatherId, ok && zw.WeatherId != newWeatherId
+}
+
+// Forgets all zone weather, such as when the weather system is disabled
+func ClearZones() {
+	zoneWeatherLock.Lock()
+	defer zoneWeatherLock.Unlock()
+
+	zoneWeather = map[string]ZoneWeather{}
+}
+
+// The relative chance of this weather occuring in a biome during a season
+func (w *WeatherSpec) GetChance(biome string, season string) int {
+
+	chance, ok := w.Chance[strings.ToLower(biome)]
+	if !ok {
+		chance = w.Chance[DefaultBiome]
+	}
+
+	if pct, ok := w.SeasonMod[season]; ok {
+		chance = chance * pct / 100
+	}
+
+	if chance < 0 {
+		return 0
+	}
+	return chance
+}
+
+// Weighted random pick of weather suitable for the biome and season
+// Returns an empty string if nothing can occur.
+func pickWeather(biome string, season string) string {
+
+	total := 0
+	weatherIds := GetAllWeatherIds()
+	chances := make([]int, len(weatherIds))
+
+	for i, weatherId := range weatherIds {
+		chances[i] = allWeather[weatherId].GetChance(biome, season)
+		total += chances[i]
+	}
+
+	if total < 1 {
+		return ``
+	}
+
+	roll := util.Rand(total)
+	for i, weatherId := range weatherIds {
+		if roll < chances[i] {
+			return weatherId
+		}
+		roll -= chances[i]
+	}
+
+	return ``
+}
+
+func nextChangeRound(roundNow uint64) uint64 {
+
+	wConfig := configs.GetGamePlayConfig().Weather
+	gd := gametime.GetDate(roundNow)
+
+	minRound := gd.AddPeriod(string(wConfig.MinDuration))
+	maxRound := gd.AddPeriod(string(wConfig.MaxDuration))
+
+	if maxRound <= minRound {
+		return minRound
+	}
+
+	return minRound + uint64(util.Rand(int(maxRound-minRound)+1))
+}
+
+func (w *WeatherSpec) Filename() string {
+	filename := util.ConvertForFilename(w.WeatherId)
+	return fmt.Sprintf("%s.yaml", filename)
+}
+
+func (w *WeatherSpec) Filepath() string {
+	return w.Filename()
+}
+
+func (w *WeatherSpec) Id() string {
+	return w.WeatherId
+}
+
+func (w *WeatherSpec) Validate() error {
+
+	if w.WeatherId == `` {
+		return fmt.Errorf("weatherid is required")
+	}
+
+	w.WeatherId = strings.ToLower(w.WeatherId)
+
+	if w.Name == `` {
+		w.Name = w.WeatherId
+	}
+
+	if w.LightMod < -2 {
+		w.LightMod = -2
+	} else if w.LightMod > 2 {
+		w.LightMod = 2
+	}
+
+	return nil
+}
+
+func LoadDataFiles() {
+
+	start := time.Now()
+
+	tmpWeather, err := fileloader.LoadAllFlatFiles[string, *WeatherSpec](configs.GetFilePathsConfig().DataFiles.String() + `/weather`)
+	if err != nil {
+		panic(err)
+	}
+
+	allWeather = tmpWeather
+
+	mudlog.Info("weather.LoadDataFiles()", "loadedCount", len(allWeather), "Time Taken", time.Since(start))
+}
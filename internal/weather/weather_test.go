@@ -0,0 +1,60 @@
+package weather
+
+import (
+	"testing"
+)
+
+func TestWeatherSpec_GetChance(t *testing.T) {
+
+	w := &WeatherSpec{
+		WeatherId: `snow`,
+		Chance: map[string]int{
+			DefaultBiome: 10,
+			`snow`:       60,
+		},
+		SeasonMod: map[string]int{
+			`winter`: 200,
+			`summer`: 0,
+		},
+	}
+
+	tests := []struct {
+		biome  string
+		season string
+		want   int
+	}{
+		{`snow`, `spring`, 60},
+		{`Snow`, `winter`, 120},
+		{`forest`, `autumn`, 10},
+		{`forest`, `summer`, 0},
+	}
+
+	for _, tt := range tests {
+		if got := w.GetChance(tt.biome, tt.season); got != tt.want {
+			t.Errorf("GetChance(%q, %q) = %d, want %d", tt.biome, tt.season, got, tt.want)
+		}
+	}
+}
+
+func TestPickWeather(t *testing.T) {
+
+	allWeather = map[string]*WeatherSpec{
+		`clear`: {WeatherId: `clear`, Chance: map[string]int{DefaultBiome: 10}},
+		`snow`:  {WeatherId: `snow`, Chance: map[string]int{`snow`: 10}, SeasonMod: map[string]int{`summer`: 0}},
+	}
+	defer func() { allWeather = map[string]*WeatherSpec{} }()
+
+	for i := 0; i < 50; i++ {
+		if got := pickWeather(`forest`, `winter`); got != `clear` {
+			t.Fatalf("pickWeather(forest, winter) = %q, want %q", got, `clear`)
+		}
+		if got := pickWeather(`snow`, `summer`); got != `clear` {
+			t.Fatalf("pickWeather(snow, summer) = %q, want %q", got, `clear`)
+		}
+	}
+
+	allWeather = map[string]*WeatherSpec{}
+	if got := pickWeather(`forest`, `winter`); got != `` {
+		t.Errorf("pickWeather() with no weather = %q, want empty", got)
+	}
+}
@@ -0,0 +1,437 @@
+package web
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/items"
+	"github.com/GoMudEngine/GoMud/internal/mobs"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/mutators"
+	"github.com/GoMudEngine/GoMud/internal/quests"
+	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/spells"
+	"github.com/GoMudEngine/GoMud/internal/users"
+)
+
+const (
+	apiBasePath = `/admin/api/v1/`
+
+	// Largest request body accepted when creating or updating
+	apiMaxBodyBytes = 1 << 20
+)
+
+// A type of world data that can be listed, fetched, created, updated and deleted through the JSON api.
+// Every request runs with the MUD locked, so changes are live as soon as they are saved.
+type apiResource[K cmp.Ordered, T any] struct {
+	Name    string                  // Path segment, such as "items"
+	ParseId func(string) (K, error) // Converts the {id} of a path
+	GetId   func(*T) K
+	List    func(query url.Values) []T
+	Get     func(K) (T, bool)
+	Create  func(T) (K, error) // Validates, saves and loads a new record, returning its id
+	Update  func(T) error      // Validates, saves and reloads an existing record
+	Delete  func(K) error
+}
+
+func registerAdminApi() {
+
+	registerApiResource(apiResource[int, rooms.Room]{
+		Name:    `rooms`,
+		ParseId: strconv.Atoi,
+		GetId:   func(r *rooms.Room) int { return r.RoomId },
+		List: func(query url.Values) []rooms.Room {
+			// Optionally ?zone=Frostfang
+			roomIds := rooms.GetAllRoomIds()
+			if zone := query.Get(`zone`); zone != `` {
+				roomIds = rooms.GetZoneRoomIds(zone)
+			}
+			ret := []rooms.Room{}
+			for _, roomId := range roomIds {
+				if r, ok := apiGetRoom(roomId); ok {
+					ret = append(ret, r)
+				}
+			}
+			return ret
+		},
+		Get:    apiGetRoom,
+		Create: rooms.CreateNewRoomFile,
+		Update: rooms.UpdateRoomFile,
+		Delete: rooms.DeleteRoomFile,
+	})
+
+	registerApiResource(apiResource[int, items.ItemSpec]{
+		Name:    `items`,
+		ParseId: strconv.Atoi,
+		GetId:   func(i *items.ItemSpec) int { return i.ItemId },
+		List:    func(url.Values) []items.ItemSpec { return items.GetAllItemSpecs() },
+		Get: func(itemId int) (items.ItemSpec, bool) {
+			if spec := items.GetItemSpec(itemId); spec != nil {
+				return *spec, true
+			}
+			return items.ItemSpec{}, false
+		},
+		Create: items.CreateNewItemFile,
+		Update: items.SaveItemSpec,
+		Delete: items.DeleteItemSpec,
+	})
+
+	registerApiResource(apiResource[mobs.MobId, mobs.Mob]{
+		Name: `mobs`,
+		ParseId: func(s string) (mobs.MobId, error) {
+			mobId, err := strconv.Atoi(s)
+			return mobs.MobId(mobId), err
+		},
+		GetId: func(m *mobs.Mob) mobs.MobId { return m.MobId },
+		List: func(url.Values) []mobs.Mob {
+			ret := mobs.GetAllMobInfo()
+			for i := range ret {
+				ret[i].Character.Description = ret[i].Character.GetDescription()
+			}
+			return ret
+		},
+		Get: func(mobId mobs.MobId) (mobs.Mob, bool) {
+			if spec := mobs.GetMobSpec(mobId); spec != nil {
+				spec.Character.Description = spec.Character.GetDescription()
+				return *spec, true
+			}
+			return mobs.Mob{}, false
+		},
+		Create: func(m mobs.Mob) (mobs.MobId, error) { return mobs.CreateNewMobFile(m, ``) },
+		Update: mobs.SaveMobSpec,
+		Delete: mobs.DeleteMobSpec,
+	})
+
+	registerApiResource(apiResource[string, spells.SpellData]{
+		Name:    `spells`,
+		ParseId: func(s string) (string, error) { return s, nil },
+		GetId:   func(s *spells.SpellData) string { return s.SpellId },
+		List: func(url.Values) []spells.SpellData {
+			ret := []spells.SpellData{}
+			for _, spell := range spells.GetAllSpells() {
+				ret = append(ret, *spell)
+			}
+			return ret
+		},
+		Get: func(spellId string) (spells.SpellData, bool) {
+			if spell := spells.GetSpell(spellId); spell != nil {
+				return *spell, true
+			}
+			return spells.SpellData{}, false
+		},
+		Create: spells.CreateNewSpellFile,
+		Update: spells.SaveSpell,
+		Delete: spells.DeleteSpell,
+	})
+
+	registerApiResource(apiResource[int, buffs.BuffSpec]{
+		Name:    `buffs`,
+		ParseId: strconv.Atoi,
+		GetId:   func(b *buffs.BuffSpec) int { return b.BuffId },
+		List: func(url.Values) []buffs.BuffSpec {
+			ret := []buffs.BuffSpec{}
+			for _, buffId := range buffs.GetAllBuffIds() {
+				if spec := buffs.GetBuffSpec(buffId); spec != nil {
+					ret = append(ret, *spec)
+				}
+			}
+			return ret
+		},
+		Get: func(buffId int) (buffs.BuffSpec, bool) {
+			// GetBuffSpec() treats negative buffIds as positive
+			if spec := buffs.GetBuffSpec(buffId); spec != nil && buffId >= 0 {
+				return *spec, true
+			}
+			return buffs.BuffSpec{}, false
+		},
+		Create: buffs.CreateNewBuffFile,
+		Update: buffs.SaveBuffSpec,
+		Delete: buffs.DeleteBuffSpec,
+	})
+
+	registerApiResource(apiResource[int, quests.Quest]{
+		Name:    `quests`,
+		ParseId: strconv.Atoi,
+		GetId:   func(q *quests.Quest) int { return q.QuestId },
+		List:    func(url.Values) []quests.Quest { return quests.GetAllQuests() },
+		Get: func(questId int) (quests.Quest, bool) {
+			if quest := quests.GetQuestById(questId); quest != nil {
+				return *quest, true
+			}
+			return quests.Quest{}, false
+		},
+		Create: quests.CreateNewQuestFile,
+		Update: quests.SaveQuest,
+		Delete: quests.DeleteQuest,
+	})
+
+	registerApiResource(apiResource[string, mutators.MutatorSpec]{
+		Name:    `mutators`,
+		ParseId: func(s string) (string, error) { return s, nil },
+		GetId:   func(m *mutators.MutatorSpec) string { return m.MutatorId },
+		List:    func(url.Values) []mutators.MutatorSpec { return mutators.GetAllMutatorSpecs() },
+		Get: func(mutatorId string) (mutators.MutatorSpec, bool) {
+			if spec := mutators.GetMutatorSpec(mutatorId); spec != nil {
+				return *spec, true
+			}
+			return mutators.MutatorSpec{}, false
+		},
+		Create: mutators.CreateNewMutatorFile,
+		Update: mutators.SaveMutatorSpec,
+		Delete: mutators.DeleteMutatorSpec,
+	})
+
+	// Users are read only, and searched through the user storage rather than loaded one by one
+	http.HandleFunc("GET "+apiBasePath+"users", doBasicAuth(
+		RunWithMUDLocked(handleUserQuery),
+	))
+
+}
+
+// GET /admin/api/v1/users
+// Optionally ?name=bob&minlevel=5&maxlevel=10&role=admin&loginafter=2025-01-01&loginbefore=2025-02-01&sort=level&desc=true&limit=20
+func handleUserQuery(w http.ResponseWriter, r *http.Request) {
+
+	query := r.URL.Query()
+
+	q := users.UserQuery{
+		Name:          query.Get(`name`),
+		Role:          query.Get(`role`),
+		SortBy:        query.Get(`sort`),
+		SortDescended: query.Get(`desc`) == `true`,
+	}
+
+	var err error
+
+	for param, val := range map[string]*int{`minlevel`: &q.MinLevel, `maxlevel`: &q.MaxLevel, `limit`: &q.Limit} {
+		if query.Has(param) {
+			if *val, err = strconv.Atoi(query.Get(param)); err != nil {
+				writeJSONError(w, http.StatusBadRequest, fmt.Errorf(`invalid %s: %s`, param, query.Get(param)))
+				return
+			}
+		}
+	}
+
+	for param, val := range map[string]*time.Time{`loginafter`: &q.LoginAfter, `loginbefore`: &q.LoginBefore} {
+		if query.Has(param) {
+			if *val, err = time.Parse(time.DateOnly, query.Get(param)); err != nil {
+				writeJSONError(w, http.StatusBadRequest, fmt.Errorf(`invalid %s (expected YYYY-MM-DD): %s`, param, query.Get(param)))
+				return
+			}
+		}
+	}
+
+	results, err := users.QueryUsers(q)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, results)
+}
+
+// Loaded rooms keep their description hashed, so return a copy with the real description
+func apiGetRoom(roomId int) (rooms.Room, bool) {
+
+	// LoadRoom() treats 0 as the start room
+	if roomId == rooms.StartRoomIdAlias {
+		return rooms.Room{}, false
+	}
+
+	room := rooms.LoadRoom(roomId)
+	if room == nil {
+		return rooms.Room{}, false
+	}
+
+	roomCopy := *room
+	roomCopy.Description = room.GetDescription()
+
+	return roomCopy, true
+}
+
+func registerApiResource[K cmp.Ordered, T any](res apiResource[K, T]) {
+
+	path := apiBasePath + res.Name
+
+	http.HandleFunc("GET "+path, doBasicAuth(
+		RunWithMUDLocked(res.handleList),
+	))
+	http.HandleFunc("POST "+path, doBasicAuth(
+		RunWithMUDLocked(res.handleCreate),
+	))
+	http.HandleFunc("GET "+path+"/{id}", doBasicAuth(
+		RunWithMUDLocked(res.handleGet),
+	))
+	http.HandleFunc("PUT "+path+"/{id}", doBasicAuth(
+		RunWithMUDLocked(res.handleUpdate),
+	))
+	http.HandleFunc("DELETE "+path+"/{id}", doBasicAuth(
+		RunWithMUDLocked(res.handleDelete),
+	))
+}
+
+// GET /admin/api/v1/{resource}
+func (res apiResource[K, T]) handleList(w http.ResponseWriter, r *http.Request) {
+
+	list := res.List(r.URL.Query())
+
+	slices.SortFunc(list, func(a, b T) int {
+		return cmp.Compare(res.GetId(&a), res.GetId(&b))
+	})
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// GET /admin/api/v1/{resource}/{id}
+func (res apiResource[K, T]) handleGet(w http.ResponseWriter, r *http.Request) {
+
+	id, ok := res.pathId(w, r)
+	if !ok {
+		return
+	}
+
+	data, found := res.Get(id)
+	if !found {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf(`no %s found with id %v`, res.Name, id))
+		return
+	}
+
+	writeJSON(w, http.StatusOK, data)
+}
+
+// POST /admin/api/v1/{resource}
+// Items, mobs and rooms are assigned a new id. Everything else must provide an unused one.
+func (res apiResource[K, T]) handleCreate(w http.ResponseWriter, r *http.Request) {
+
+	var data T
+	if err := readJSON(w, r, &data); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	id, err := res.Create(data)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res.logChange(r, `create`, id)
+
+	data, _ = res.Get(id)
+
+	writeJSON(w, http.StatusCreated, data)
+}
+
+// PUT /admin/api/v1/{resource}/{id}
+// The body must be the entire record, not just the fields being changed.
+func (res apiResource[K, T]) handleUpdate(w http.ResponseWriter, r *http.Request) {
+
+	id, ok := res.pathId(w, r)
+	if !ok {
+		return
+	}
+
+	if _, found := res.Get(id); !found {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf(`no %s found with id %v`, res.Name, id))
+		return
+	}
+
+	var data T
+	if err := readJSON(w, r, &data); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if res.GetId(&data) != id {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf(`id %v in the body does not match id %v in the path`, res.GetId(&data), id))
+		return
+	}
+
+	if err := res.Update(data); err != nil {
+		writeJSONError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res.logChange(r, `update`, id)
+
+	data, _ = res.Get(id)
+
+	writeJSON(w, http.StatusOK, data)
+}
+
+// DELETE /admin/api/v1/{resource}/{id}
+func (res apiResource[K, T]) handleDelete(w http.ResponseWriter, r *http.Request) {
+
+	id, ok := res.pathId(w, r)
+	if !ok {
+		return
+	}
+
+	if _, found := res.Get(id); !found {
+		writeJSONError(w, http.StatusNotFound, fmt.Errorf(`no %s found with id %v`, res.Name, id))
+		return
+	}
+
+	if err := res.Delete(id); err != nil {
+		writeJSONError(w, http.StatusConflict, err)
+		return
+	}
+
+	res.logChange(r, `delete`, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (res apiResource[K, T]) pathId(w http.ResponseWriter, r *http.Request) (K, bool) {
+
+	id, err := res.ParseId(r.PathValue(`id`))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, fmt.Errorf(`invalid %s id: %s`, res.Name, r.PathValue(`id`)))
+		return id, false
+	}
+
+	return id, true
+}
+
+func (res apiResource[K, T]) logChange(r *http.Request, action string, id K) {
+	username, _, _ := r.BasicAuth()
+	mudlog.Warn("ADMIN API", "username", username, "action", action, "type", res.Name, "id", id)
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, data any) error {
+
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(data); err != nil {
+		return fmt.Errorf(`invalid json: %w`, err)
+	}
+
+	if decoder.More() {
+		return errors.New(`invalid json: unexpected data after the object`)
+	}
+
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, data any) {
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		mudlog.Error("ADMIN API", "error", err)
+	}
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{`error`: err.Error()})
+}
@@ -15,6 +15,8 @@ func adminIndex(w http.ResponseWriter, r *http.Request) {
 		mudlog.Error("HTML ERROR", "error", err)
 	}
 
-	tmpl.Execute(w, nil)
+	tmpl.Execute(w, map[string]any{
+		"STATS": GetStats(),
+	})
 
 }
@@ -2,14 +2,17 @@ package web
 
 import (
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/users"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
 var (
-	authCache = map[string]time.Time{}
+	authCache     = map[string]time.Time{}
+	authCacheLock = sync.Mutex{}
 )
 
 func handlerToHandlerFunc(h http.Handler) http.HandlerFunc {
@@ -18,19 +21,18 @@ func handlerToHandlerFunc(h http.Handler) http.HandlerFunc {
 	}
 }
 
+// Checks the request against an admin's user record.
+// Should wrap RunWithMUDLocked() rather than the other way around, so that
+// slow password hashing doesn't hold up the game. The lock is only taken
+// to read and save the user record.
 func doBasicAuth(next http.HandlerFunc) http.HandlerFunc {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 
 		authHeader := r.Header.Get("Authorization")
 
-		if t, ok := authCache[authHeader]; ok {
-
-			if t.After(time.Now()) {
-				next.ServeHTTP(w, r)
-				return
-			}
-
-			delete(authCache, authHeader)
+		if authCached(authHeader) {
+			next.ServeHTTP(w, r)
+			return
 		}
 
 		// Extract the username and password from the request
@@ -41,17 +43,26 @@ func doBasicAuth(next http.HandlerFunc) http.HandlerFunc {
 		if ok {
 
 			// Authorize against actual user record
+			util.LockMud()
 			uRecord, err := users.LoadUser(username, true)
+			util.UnlockMud()
+
 			if err == nil {
 
 				if uRecord.PasswordMatches(password) {
 
+					if uRecord.PasswordNeedsRehash() {
+						rehashPassword(uRecord, password)
+					}
+
 					if uRecord.Role != users.RoleUser {
 
 						mudlog.Warn("ADMIN LOGIN", "username", username, "success", true)
 
 						// Cache auth for 30 minutes to avoid re-auth every load
+						authCacheLock.Lock()
 						authCache[authHeader] = time.Now().Add(time.Minute * 30)
+						authCacheLock.Unlock()
 
 						next.ServeHTTP(w, r)
 						return
@@ -76,3 +87,40 @@ func doBasicAuth(next http.HandlerFunc) http.HandlerFunc {
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 	})
 }
+
+// Whether the Authorization header was recently accepted
+func authCached(authHeader string) bool {
+
+	authCacheLock.Lock()
+	defer authCacheLock.Unlock()
+
+	t, ok := authCache[authHeader]
+	if !ok {
+		return false
+	}
+
+	if t.After(time.Now()) {
+		return true
+	}
+
+	delete(authCache, authHeader)
+
+	return false
+}
+
+// Upgrades a stored password that has already been verified.
+// The hashing is done before taking the lock.
+func rehashPassword(uRecord *users.UserRecord, password string) {
+
+	if err := uRecord.RehashPassword(password); err != nil {
+		return
+	}
+
+	util.LockMud()
+	defer util.UnlockMud()
+
+	// Only save if they aren't online, otherwise we'd overwrite their live record
+	if users.GetByUserId(uRecord.UserId) == nil {
+		users.SaveUser(*uRecord)
+	}
+}
@@ -3,6 +3,7 @@ package web
 import (
 	"sync"
 
+	"github.com/GoMudEngine/GoMud/internal/connections"
 	"github.com/GoMudEngine/GoMud/internal/users"
 )
 
@@ -10,6 +11,7 @@ type Stats struct {
 	OnlineUsers   []users.OnlineInfo
 	TelnetPorts   []int
 	WebSocketPort int
+	Compression   connections.CompressionStats // MCCP byte savings
 }
 
 var (
@@ -41,4 +43,5 @@ func (s *Stats) Reset() {
 	s.WebSocketPort = 0
 	s.OnlineUsers = []users.OnlineInfo{}
 	s.TelnetPorts = []int{}
+	s.Compression = connections.CompressionStats{}
 }
@@ -7,6 +7,7 @@ import (
 	"text/template"
 
 	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/util"
 )
 
 var (
@@ -102,5 +103,8 @@ var (
 		"getconfig": func() configs.Config {
 			return configs.GetConfig()
 		},
+		"formatbytes": func(b uint64) string {
+			return util.FormatBytes(b)
+		},
 	}
 )
@@ -258,8 +258,8 @@ func Listen(wg *sync.WaitGroup, webSocketHandler func(*websocket.Conn)) {
 		webSocketHandler(conn)
 	})
 
-	http.Handle("GET /admin/static/", RunWithMUDLocked(
-		doBasicAuth(
+	http.Handle("GET /admin/static/", doBasicAuth(
+		RunWithMUDLocked(
 			handlerToHandlerFunc(
 				http.StripPrefix("/admin/static/", http.FileServer(http.Dir(configs.GetFilePathsConfig().AdminHtml.String()+"/static"))),
 			),
@@ -267,50 +267,53 @@ func Listen(wg *sync.WaitGroup, webSocketHandler func(*websocket.Conn)) {
 	))
 
 	// Admin tools
-	http.HandleFunc("GET /admin/", RunWithMUDLocked(
-		doBasicAuth(adminIndex),
+	http.HandleFunc("GET /admin/", doBasicAuth(
+		RunWithMUDLocked(adminIndex),
 	))
 
 	// Item Admin
-	http.HandleFunc("GET /admin/items/", RunWithMUDLocked(
-		doBasicAuth(itemsIndex),
+	http.HandleFunc("GET /admin/items/", doBasicAuth(
+		RunWithMUDLocked(itemsIndex),
 	))
-	http.HandleFunc("GET /admin/items/itemdata/", RunWithMUDLocked(
-		doBasicAuth(itemData),
+	http.HandleFunc("GET /admin/items/itemdata/", doBasicAuth(
+		RunWithMUDLocked(itemData),
 	))
 
 	// Race Admin
-	http.HandleFunc("GET /admin/races/", RunWithMUDLocked(
-		doBasicAuth(racesIndex)),
+	http.HandleFunc("GET /admin/races/", doBasicAuth(
+		RunWithMUDLocked(racesIndex)),
 	)
-	http.HandleFunc("GET /admin/races/racedata/", RunWithMUDLocked(
-		doBasicAuth(raceData)),
+	http.HandleFunc("GET /admin/races/racedata/", doBasicAuth(
+		RunWithMUDLocked(raceData)),
 	)
 
 	// Mob Admin
-	http.HandleFunc("GET /admin/mobs/", RunWithMUDLocked(
-		doBasicAuth(mobsIndex),
+	http.HandleFunc("GET /admin/mobs/", doBasicAuth(
+		RunWithMUDLocked(mobsIndex),
 	))
-	http.HandleFunc("GET /admin/mobs/mobdata/", RunWithMUDLocked(
-		doBasicAuth(mobData),
+	http.HandleFunc("GET /admin/mobs/mobdata/", doBasicAuth(
+		RunWithMUDLocked(mobData),
 	))
 
 	// Mutator Admin
-	http.HandleFunc("GET /admin/mutators/", RunWithMUDLocked(
-		doBasicAuth(mutatorsIndex),
+	http.HandleFunc("GET /admin/mutators/", doBasicAuth(
+		RunWithMUDLocked(mutatorsIndex),
 	))
-	http.HandleFunc("GET /admin/mutators/mutatordata/", RunWithMUDLocked(
-		doBasicAuth(mutatorData),
+	http.HandleFunc("GET /admin/mutators/mutatordata/", doBasicAuth(
+		RunWithMUDLocked(mutatorData),
 	))
 
 	// Room Admin
-	http.HandleFunc("GET /admin/rooms/", RunWithMUDLocked(
-		doBasicAuth(roomsIndex),
+	http.HandleFunc("GET /admin/rooms/", doBasicAuth(
+		RunWithMUDLocked(roomsIndex),
 	))
-	http.HandleFunc("GET /admin/rooms/roomdata/", RunWithMUDLocked(
-		doBasicAuth(roomData),
+	http.HandleFunc("GET /admin/rooms/roomdata/", doBasicAuth(
+		RunWithMUDLocked(roomData),
 	))
 
+	// JSON api for editing world data
+	registerAdminApi()
+
 	//
 	// Https server start up
 	//
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"os"
@@ -18,10 +19,13 @@ import (
 
 	"github.com/GoMudEngine/GoMud/internal/audio"
 	"github.com/GoMudEngine/GoMud/internal/buffs"
+	"github.com/GoMudEngine/GoMud/internal/channels"
 	"github.com/GoMudEngine/GoMud/internal/characters"
+	"github.com/GoMudEngine/GoMud/internal/clans"
 	"github.com/GoMudEngine/GoMud/internal/colorpatterns"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/crafting"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/flags"
 	"github.com/GoMudEngine/GoMud/internal/gametime"
@@ -50,6 +54,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/users"
 	"github.com/GoMudEngine/GoMud/internal/util"
+	"github.com/GoMudEngine/GoMud/internal/weather"
 	"github.com/GoMudEngine/GoMud/internal/web"
 	_ "github.com/GoMudEngine/GoMud/modules"
 	textLang "golang.org/x/text/language"
@@ -182,15 +187,40 @@ func main() {
 
 	mudlog.Info(`========================`)
 
-	// Create the user index
-	idx := users.NewUserIndex()
-	if !idx.Exists() {
-		// Since it doesn't exist yet, that's a good indication we should do a quick format migration check
-		users.DoUserMigrations()
+	if err := users.InitUserStore(); err != nil {
+		mudlog.Error("UserStore", "error", err)
+		os.Exit(1)
+	}
+	mudlog.Info("UserStore", "storage", users.GetUserStore().Name())
+
+	// Flat files need their index rebuilt, since they can be edited by hand
+	if users.GetUserStore().Name() == users.StorageYaml {
+		// Create the user index
+		idx := users.NewUserIndex()
+		if !idx.Exists() {
+			// Since it doesn't exist yet, that's a good indication we should do a quick format migration check
+			users.DoUserMigrations()
+		}
+		idx.Create()
+		idx.Rebuild()
+		mudlog.Info("UserIndex", "info", "User index recreated.")
+	}
+
+	// User files may be hand edited, so always make sure no plaintext or unsalted passwords are stored
+	if migratedCt, err := users.DoPasswordMigrationV1(); err != nil {
+		mudlog.Error("PasswordMigration", "error", err, "migrated", migratedCt)
+	} else if migratedCt > 0 {
+		mudlog.Info("PasswordMigration", "info", "Legacy passwords rehashed.", "migrated", migratedCt)
 	}
-	idx.Create()
-	idx.Rebuild()
-	mudlog.Info("UserIndex", "info", "User index recreated.")
+
+	// Clans are player data, so they are only loaded once rather than with the other data files
+	clans.LoadDataFiles()
+
+	// Chat channels, same as clans
+	channels.LoadDataFiles()
+
+	// Values saved by scripts, also only loaded once
+	scripting.LoadScriptStore()
 
 	// Load the round count from the file
 	if util.LoadRoundCount(c.FilePaths.DataFiles.String()+`/`+util.RoundCountFilename) == util.RoundCountMinimum {
@@ -236,6 +266,30 @@ func main() {
 		}
 	}
 
+	if len(c.Network.TelnetTLSPort) > 0 {
+		if tlsConfig := getTelnetTLSConfig(); tlsConfig != nil {
+			for _, port := range c.Network.TelnetTLSPort {
+				if p, err := strconv.Atoi(port); err == nil {
+					if s := TelnetTLSListenOnPort(``, p, &wg, int(c.Network.MaxTelnetConnections), tlsConfig); s != nil {
+						allServerListeners = append(allServerListeners, s)
+					}
+				}
+			}
+		}
+	}
+
+	if len(c.Network.SshPort) > 0 {
+		if sshConfig := getSSHServerConfig(); sshConfig != nil {
+			for _, port := range c.Network.SshPort {
+				if p, err := strconv.Atoi(port); err == nil {
+					if s := SSHListenOnPort(``, p, &wg, int(c.Network.MaxTelnetConnections), sshConfig); s != nil {
+						allServerListeners = append(allServerListeners, s)
+					}
+				}
+			}
+		}
+	}
+
 	if c.Network.LocalPort > 0 {
 		TelnetListenOnPort(`127.0.0.1`, int(c.Network.LocalPort), &wg, 0)
 	}
@@ -303,6 +357,8 @@ func main() {
 	// Give it a second to disaptch any final messages in the event queue
 	// Example: discord server shutdown
 	time.Sleep(1 * time.Second)
+
+	users.GetUserStore().Close()
 }
 
 func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync.WaitGroup) {
@@ -316,10 +372,16 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 	// Needs to be created BEFORE the first handler call
 	var sharedState map[string]any = make(map[string]any)
 
+	// SSH connections have no telnet layer, so nothing is negotiated with IAC commands.
+	// Screen size and terminal type are reported by the SSH session instead.
+	isSSH := connDetails.IsSSH()
+
 	// Add starting handlers
 
 	// Special escape handlers
-	connDetails.AddInputHandler("TelnetIACHandler", inputhandlers.TelnetIACHandler)
+	if !isSSH {
+		connDetails.AddInputHandler("TelnetIACHandler", inputhandlers.TelnetIACHandler)
+	}
 	connDetails.AddInputHandler("AnsiHandler", inputhandlers.AnsiHandler)
 	// Consider a macro handler at this point?
 	// Text Processing
@@ -328,46 +390,75 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 	loginHandler := inputhandlers.GetLoginPromptHandler()           // Get the configured handler func
 	connDetails.AddInputHandler("LoginPromptHandler", loginHandler) // Add it with a unique name
 
-	// Turn off "line at a time", send chars as typed
-	connections.SendTo(
-		term.TelnetWILL(term.TELNET_OPT_SUP_GO_AHD),
-		connDetails.ConnectionId(),
-	)
-	// Tell the client we expect chars as they are typed
-	connections.SendTo(
-		term.TelnetWONT(term.TELNET_OPT_LINE_MODE),
-		connDetails.ConnectionId(),
-	)
+	if !isSSH {
 
-	// Tell the client we intend to echo back what they type
-	// So they shouldn't locally echo it
+		// Turn off "line at a time", send chars as typed
+		connections.SendTo(
+			term.TelnetWILL(term.TELNET_OPT_SUP_GO_AHD),
+			connDetails.ConnectionId(),
+		)
+		// Tell the client we expect chars as they are typed
+		connections.SendTo(
+			term.TelnetWONT(term.TELNET_OPT_LINE_MODE),
+			connDetails.ConnectionId(),
+		)
 
-	connections.SendTo(
-		term.TelnetWILL(term.TELNET_OPT_ECHO),
-		connDetails.ConnectionId(),
-	)
-	// Request that the client report window size changes as they happen
-	connections.SendTo(
-		term.TelnetDO(term.TELNET_OPT_NAWS),
-		connDetails.ConnectionId(),
-	)
+		// Tell the client we intend to echo back what they type
+		// So they shouldn't locally echo it
 
-	// Send request to change charset
-	connections.SendTo(
-		term.TelnetRequestChangeCharset.BytesWithPayload(nil),
-		connDetails.ConnectionId(),
-	)
+		connections.SendTo(
+			term.TelnetWILL(term.TELNET_OPT_ECHO),
+			connDetails.ConnectionId(),
+		)
+		// Request that the client report window size changes as they happen
+		connections.SendTo(
+			term.TelnetDO(term.TELNET_OPT_NAWS),
+			connDetails.ConnectionId(),
+		)
 
-	// Send request to enable MSP
-	connections.SendTo(
-		term.MspEnable.BytesWithPayload(nil),
-		connDetails.ConnectionId(),
-	)
+		// Request the client name, terminal type and capabilities (MTTS)
+		connections.SendTo(
+			term.TelnetTermTypeRequest.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
 
-	connections.SendTo(
-		term.TelnetSuppressGoAhead.BytesWithPayload(nil),
-		connDetails.ConnectionId(),
-	)
+		// Send request to change charset
+		connections.SendTo(
+			term.TelnetRequestChangeCharset.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
+
+		// Send request to enable MSP
+		connections.SendTo(
+			term.MspEnable.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
+
+		connections.SendTo(
+			term.TelnetSuppressGoAhead.BytesWithPayload(nil),
+			connDetails.ConnectionId(),
+		)
+
+		// Offer server status to MUD listing crawlers
+		if configs.GetMsspConfig().Enabled {
+			connections.SendTo(
+				term.MsspEnable.BytesWithPayload(nil),
+				connDetails.ConnectionId(),
+			)
+		}
+
+		// Offer compression of output (MCCP2) and input (MCCP3)
+		if configs.GetNetworkConfig().Compression {
+			connections.SendTo(
+				term.Mccp2Enable.BytesWithPayload(nil),
+				connDetails.ConnectionId(),
+			)
+			connections.SendTo(
+				term.Mccp3Enable.BytesWithPayload(nil),
+				connDetails.ConnectionId(),
+			)
+		}
+	}
 
 	clientSetupCommands := "" + //term.AnsiAltModeStart.String() + // alternative mode (No scrollback)
 		//term.AnsiCursorHide.String() + // Hide Cursor (Because we will manually echo back)
@@ -396,7 +487,7 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 		History:      connections.InputHistory{},
 	}
 
-	if audioConfig := audio.GetFile(`intro`); audioConfig.FilePath != `` {
+	if audioConfig := audio.GetFile(`intro`); audioConfig.FilePath != `` && !isSSH {
 		v := 100
 		if audioConfig.Volume > 0 && audioConfig.Volume <= 100 {
 			v = audioConfig.Volume
@@ -423,13 +514,27 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 	// 1. Creates the PromptHandlerState in sharedState.
 	// 2. Calls advanceAndSendPromptCustom -> sendPromptFunc for the *first* step (username).
 	// 3. Returns false (which we ignore here, as we aren't in the main loop yet).
-	loginHandler(initialTriggerInput, sharedState)
-
 	var userObject *users.UserRecord
 	var sug suggestions.Suggestions
 	lastInput := time.Now()
 	c := configs.GetConfig()
 
+	// SSH users that logged in with a public key skip the login prompts
+	if sshConn := connDetails.SSHConn(); sshConn != nil && sshConn.AuthenticatedUserId() > 0 {
+
+		if !inputhandlers.LoginAuthenticatedUser(sshConn.User(), sharedState, connDetails.ConnectionId()) {
+			return
+		}
+
+		userObject = sharedState["UserObject"].(*users.UserRecord)
+		delete(sharedState, "UserObject")
+
+		enterWorld(connDetails, userObject)
+
+	} else {
+		loginHandler(initialTriggerInput, sharedState)
+	}
+
 	for {
 
 		clientInput.EnterPressed = false // Default state is always false
@@ -556,12 +661,6 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 
 			// Prompt sequence finished successfully
 
-			// Stop intro music if playing
-			connections.SendTo(
-				term.MspCommand.BytesWithPayload([]byte("!!MUSIC(Off)")),
-				clientInput.ConnectionId,
-			)
-
 			// Retrieve the UserObject stored by the completion function
 			if uo, exists := sharedState["UserObject"]; exists {
 				userObject = uo.(*users.UserRecord)
@@ -574,24 +673,7 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 				break                                        // Exit the read loop for this connection
 			}
 
-			// Remove the prompt handler (it signaled completion by returning true)
-			connDetails.RemoveInputHandler("LoginPromptHandler")
-			// Replace it with a regular echo handler.
-			connDetails.AddInputHandler("EchoInputHandler", inputhandlers.EchoInputHandler)
-			// Add admin command handler
-			connDetails.AddInputHandler("HistoryInputHandler", inputhandlers.HistoryInputHandler) // Put history tracking after login handling, since login handling aborts input until complete
-
-			if userObject.Role == users.RoleAdmin {
-				connDetails.AddInputHandler("SystemCommandInputHandler", inputhandlers.SystemCommandInputHandler)
-			}
-
-			// Add a signal handler (shortcut ctrl combos) after the AnsiHandler
-			// This captures signals and replaces user input so should happen after AnsiHandler to ensure it happens before other processes.
-			connDetails.AddInputHandler("SignalHandler", inputhandlers.SignalHandler, "AnsiHandler")
-
-			connDetails.SetState(connections.LoggedIn)
-
-			worldManager.SendEnterWorld(userObject.UserId, userObject.Character.RoomId)
+			enterWorld(connDetails, userObject)
 
 			clientInput.Reset()
 			continue
@@ -675,6 +757,37 @@ func handleTelnetConnection(connDetails *connections.ConnectionDetails, wg *sync
 
 }
 
+// Swaps the login handlers out for the in-game handlers, and places the user in the world
+func enterWorld(connDetails *connections.ConnectionDetails, userObject *users.UserRecord) {
+
+	// Stop intro music if playing
+	if !connDetails.IsSSH() {
+		connections.SendTo(
+			term.MspCommand.BytesWithPayload([]byte("!!MUSIC(Off)")),
+			connDetails.ConnectionId(),
+		)
+	}
+
+	// Remove the prompt handler (it signaled completion by returning true)
+	connDetails.RemoveInputHandler("LoginPromptHandler")
+	// Replace it with a regular echo handler.
+	connDetails.AddInputHandler("EchoInputHandler", inputhandlers.EchoInputHandler)
+	// Add admin command handler
+	connDetails.AddInputHandler("HistoryInputHandler", inputhandlers.HistoryInputHandler) // Put history tracking after login handling, since login handling aborts input until complete
+
+	if userObject.Role == users.RoleAdmin {
+		connDetails.AddInputHandler("SystemCommandInputHandler", inputhandlers.SystemCommandInputHandler)
+	}
+
+	// Add a signal handler (shortcut ctrl combos) after the AnsiHandler
+	// This captures signals and replaces user input so should happen after AnsiHandler to ensure it happens before other processes.
+	connDetails.AddInputHandler("SignalHandler", inputhandlers.SignalHandler, "AnsiHandler")
+
+	connDetails.SetState(connections.LoggedIn)
+
+	worldManager.SendEnterWorld(userObject.UserId, userObject.Character.RoomId)
+}
+
 func HandleWebSocketConnection(conn *websocket.Conn) {
 
 	var userObject *users.UserRecord
@@ -861,6 +974,52 @@ func TelnetListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxCon
 		return nil
 	}
 
+	acceptTelnetConnections(server, wg, maxConnections)
+
+	return server
+}
+
+// Same as TelnetListenOnPort, but connections are TLS encrypted
+func TelnetTLSListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxConnections int, tlsConfig *tls.Config) net.Listener {
+
+	server, err := tls.Listen("tcp", fmt.Sprintf("%s:%d", hostname, portNum), tlsConfig)
+	if err != nil {
+		mudlog.Error("Error creating TLS server", "error", err)
+		return nil
+	}
+
+	mudlog.Info("Telnet TLS", "stage", "Listening", "port", portNum)
+
+	acceptTelnetConnections(server, wg, maxConnections)
+
+	return server
+}
+
+// Loads the HTTPS certificate files for use by TLS telnet listeners
+// Returns nil if they aren't configured or can't be loaded.
+func getTelnetTLSConfig() *tls.Config {
+
+	filePaths := configs.GetFilePathsConfig()
+
+	if filePaths.HttpsCertFile == `` || filePaths.HttpsKeyFile == `` {
+		mudlog.Error("Telnet TLS", "stage", "skipping", "error", "Undefined public/private key files", "Public Cert", filePaths.HttpsCertFile, "Private Key", filePaths.HttpsKeyFile)
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(string(filePaths.HttpsCertFile), string(filePaths.HttpsKeyFile))
+	if err != nil {
+		mudlog.Error("Telnet TLS", "error", fmt.Errorf("Error loading certificate and key: %w", err))
+		return nil
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+func acceptTelnetConnections(server net.Listener, wg *sync.WaitGroup, maxConnections int) {
+
 	// Start a goroutine to accept incoming connections, so that we can use a signal to stop the server
 	go func() {
 
@@ -880,12 +1039,40 @@ func TelnetListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxCon
 
 			if maxConnections > 0 {
 				if connections.ActiveConnectionCount() >= maxConnections {
-					conn.Write([]byte(fmt.Sprintf("\n\n\n!!! Server is full (%d connections). Try again later. !!!\n\n\n", connections.ActiveConnectionCount())))
-					conn.Close()
+					// Don't let a slow client (or TLS handshake) hold up the accept loop
+					go func(c net.Conn, ct int) {
+						c.SetDeadline(time.Now().Add(5 * time.Second))
+						c.Write([]byte(fmt.Sprintf("\n\n\n!!! Server is full (%d connections). Try again later. !!!\n\n\n", ct)))
+						c.Close()
+					}(conn, connections.ActiveConnectionCount())
 					continue
 				}
 			}
 
+			// TLS connections must finish their handshake before being added.
+			// Otherwise the handshake happens on the first write, while holding the connections lock.
+			if tlsConn, ok := conn.(*tls.Conn); ok {
+
+				wg.Add(1)
+				go func() {
+					tlsConn.SetDeadline(time.Now().Add(10 * time.Second))
+					if err := tlsConn.Handshake(); err != nil {
+						mudlog.Warn("Telnet TLS", "remoteAddr", tlsConn.RemoteAddr().String(), "error", err)
+						tlsConn.Close()
+						wg.Done()
+						return
+					}
+					tlsConn.SetDeadline(time.Time{})
+
+					handleTelnetConnection(
+						connections.Add(tlsConn, nil),
+						wg,
+					)
+				}()
+
+				continue
+			}
+
 			wg.Add(1)
 			// hand off the connection to a handler goroutine so that we can continue handling new connections
 			go handleTelnetConnection(
@@ -895,8 +1082,6 @@ func TelnetListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxCon
 
 		}
 	}()
-
-	return server
 }
 
 func loadAllDataFiles(isReload bool) {
@@ -922,9 +1107,11 @@ func loadAllDataFiles(isReload bool) {
 	mobs.LoadDataFiles()
 	pets.LoadDataFiles()
 	quests.LoadDataFiles()
+	crafting.LoadDataFiles()
 	templates.LoadAliases()
 	keywords.LoadAliases(plugins.GetPluginRegistry())
 	mutators.LoadDataFiles()
+	weather.LoadDataFiles()
 	colorpatterns.LoadColorPatterns()
 	audio.LoadAudioConfig()
 	characters.CompileAdjectiveSwaps() // This should come after loading color patterns.
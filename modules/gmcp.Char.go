@@ -2,6 +2,7 @@ package modules
 
 import (
 	"math"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/quests"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
 	"github.com/GoMudEngine/GoMud/internal/skills"
+	"github.com/GoMudEngine/GoMud/internal/spells"
 	"github.com/GoMudEngine/GoMud/internal/users"
 )
 
@@ -42,6 +44,7 @@ func init() {
 	events.RegisterListener(events.CharacterVitalsChanged{}, g.vitalsChangedHandler)
 	events.RegisterListener(events.LevelUp{}, g.levelUpHandler)
 	events.RegisterListener(events.CharacterTrained{}, g.charTrainedHandler)
+	events.RegisterListener(events.CharacterCooldownsChanged{}, g.cooldownsChangedHandler)
 	events.RegisterListener(GMCPCharUpdate{}, g.buildAndSendGMCPPayload)
 	events.RegisterListener(events.GainExperience{}, g.xpGainHandler)
 	events.RegisterListener(events.CharacterStatsChanged{}, g.statsChangeHandler)
@@ -209,6 +212,8 @@ func (g *GMCPCharModule) equipmentChangeHandler(e events.Event) events.ListenerR
 
 	if len(evt.ItemsRemoved) > 0 || len(evt.ItemsWorn) > 0 {
 		statsToChange += `Char.Inventory, Char.Stats, Char.Vitals`
+	} else if len(evt.ItemsChanged) > 0 {
+		statsToChange += `Char.Inventory`
 	}
 
 	// If only gold or bank changed
@@ -242,7 +247,26 @@ func (g *GMCPCharModule) charTrainedHandler(e events.Event) events.ListenerRetur
 	}
 
 	// Changing equipment might affect stats, inventory, maxhp/maxmp etc
-	events.AddToQueue(GMCPCharUpdate{UserId: evt.UserId, Identifier: `Char.Stats, Char.Worth, Char.Vitals, Char.Inventory.Backpack.Summary`})
+	events.AddToQueue(GMCPCharUpdate{UserId: evt.UserId, Identifier: `Char.Stats, Char.Worth, Char.Vitals, Char.Inventory.Backpack.Summary, Char.Skills, Char.Spells`})
+
+	return events.Continue
+}
+
+func (g *GMCPCharModule) cooldownsChangedHandler(e events.Event) events.ListenerReturn {
+
+	evt, typeOk := e.(events.CharacterCooldownsChanged)
+	if !typeOk {
+		return events.Continue // Return false to stop halt the event chain for this event
+	}
+
+	if evt.UserId == 0 {
+		return events.Continue
+	}
+
+	events.AddToQueue(GMCPCharUpdate{
+		UserId:     evt.UserId,
+		Identifier: `Char.Cooldowns`,
+	})
 
 	return events.Continue
 }
@@ -621,6 +645,93 @@ func (g *GMCPCharModule) GetCharNode(user *users.UserRecord, gmcpModule string)
 		}
 	}
 
+	if all || g.wantsGMCPPayload(`Char.Skills`, gmcpModule) {
+
+		payload.Skills = []GMCPCharModule_Payload_Skill{}
+
+		for skillName, skillLevel := range user.Character.GetSkills() {
+			payload.Skills = append(payload.Skills, GMCPCharModule_Payload_Skill{
+				Name:  skillName,
+				Level: skillLevel,
+			})
+		}
+
+		sort.Slice(payload.Skills, func(i, j int) bool {
+			return payload.Skills[i].Name < payload.Skills[j].Name
+		})
+
+		if !all {
+			return payload.Skills, `Char.Skills`
+		}
+	}
+
+	if all || g.wantsGMCPPayload(`Char.Spells`, gmcpModule) {
+
+		payload.Spells = []GMCPCharModule_Payload_Spell{}
+
+		for spellId, casts := range user.Character.GetSpells() {
+
+			spellInfo := spells.GetSpell(spellId)
+			if spellInfo == nil {
+				continue
+			}
+
+			// Disabled spells are stored as a negative cast count
+			enabled := casts > 0
+			if casts < 0 {
+				casts *= -1
+			}
+
+			payload.Spells = append(payload.Spells, GMCPCharModule_Payload_Spell{
+				Id:          spellInfo.SpellId,
+				Name:        spellInfo.Name,
+				Description: spellInfo.Description,
+				Cost:        spellInfo.Cost,
+				Target:      string(spellInfo.Type),
+				School:      string(spellInfo.School),
+				Casts:       casts,
+				Enabled:     enabled,
+			})
+		}
+
+		sort.Slice(payload.Spells, func(i, j int) bool {
+			return payload.Spells[i].Name < payload.Spells[j].Name
+		})
+
+		if !all {
+			return payload.Spells, `Char.Spells`
+		}
+	}
+
+	if all || g.wantsGMCPPayload(`Char.Cooldowns`, gmcpModule) {
+
+		c := configs.GetTimingConfig()
+
+		payload.Cooldowns = []GMCPCharModule_Payload_Cooldown{}
+
+		for trackingTag, roundsLeft := range user.Character.GetAllCooldowns() {
+
+			// Expired cooldowns are waiting to be pruned
+			if roundsLeft <= 0 {
+				continue
+			}
+
+			payload.Cooldowns = append(payload.Cooldowns, GMCPCharModule_Payload_Cooldown{
+				Name:         trackingTag,
+				RoundsLeft:   roundsLeft,
+				DurationLeft: c.RoundsToSeconds(roundsLeft),
+			})
+		}
+
+		sort.Slice(payload.Cooldowns, func(i, j int) bool {
+			return payload.Cooldowns[i].Name < payload.Cooldowns[j].Name
+		})
+
+		if !all {
+			return payload.Cooldowns, `Char.Cooldowns`
+		}
+	}
+
 	// If we reached this point and Char wasn't requested, we have a problem.
 	if !all {
 		mudlog.Error(`gmcp.Char`, `error`, `Bad module requested`, `module`, gmcpModule)
@@ -657,6 +768,40 @@ type GMCPCharModule_Payload struct {
 	Worth     *GMCPCharModule_Payload_Worth            `json:"Worth,omitempty"`
 	Quests    []GMCPCharModule_Payload_Quest           `json:"Quests,omitempty"`
 	Pets      []GMCPCharModule_Payload_Pet             `json:"Pets,omitempty"`
+	Skills    []GMCPCharModule_Payload_Skill           `json:"Skills,omitempty"`
+	Spells    []GMCPCharModule_Payload_Spell           `json:"Spells,omitempty"`
+	Cooldowns []GMCPCharModule_Payload_Cooldown        `json:"Cooldowns,omitempty"`
+}
+
+// /////////////////
+// Char.Skills
+// /////////////////
+type GMCPCharModule_Payload_Skill struct {
+	Name  string `json:"name"`
+	Level int    `json:"level"`
+}
+
+// /////////////////
+// Char.Spells
+// /////////////////
+type GMCPCharModule_Payload_Spell struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Cost        int    `json:"cost"`   // Mana cost
+	Target      string `json:"target"` // harmsingle, helpmulti etc.
+	School      string `json:"school"`
+	Casts       int    `json:"casts"`
+	Enabled     bool   `json:"enabled"`
+}
+
+// /////////////////
+// Char.Cooldowns
+// /////////////////
+type GMCPCharModule_Payload_Cooldown struct {
+	Name         string `json:"name"`
+	RoundsLeft   int    `json:"rounds_left"`
+	DurationLeft int    `json:"duration_left"` // Seconds
 }
 
 // /////////////////
@@ -715,12 +860,14 @@ type GMCPCharModule_Payload_Inventory_Worn struct {
 }
 
 type GMCPCharModule_Payload_Inventory_Item struct {
-	Id      string   `json:"id"`
-	Name    string   `json:"name"`
-	Type    string   `json:"type"`
-	SubType string   `json:"subtype"`
-	Uses    int      `json:"uses"`
-	Details []string `json:"details"`
+	Id            string   `json:"id"`
+	Name          string   `json:"name"`
+	Type          string   `json:"type"`
+	SubType       string   `json:"subtype"`
+	Uses          int      `json:"uses"`
+	Durability    int      `json:"durability,omitempty"`
+	MaxDurability int      `json:"maxdurability,omitempty"`
+	Details       []string `json:"details"`
 }
 
 func newInventory_Item(itm items.Item) GMCPCharModule_Payload_Inventory_Item {
@@ -743,6 +890,16 @@ func newInventory_Item(itm items.Item) GMCPCharModule_Payload_Inventory_Item {
 		d.Details = append(d.Details, `quest`)
 	}
 
+	if itm.HasDurability() {
+		d.Durability = itm.GetDurability()
+		d.MaxDurability = itm.GetMaxDurability()
+		if d.Durability == 0 {
+			d.Details = append(d.Details, `broken`)
+		} else if itm.GetEffectiveness() < 100 {
+			d.Details = append(d.Details, `damaged`)
+		}
+	}
+
 	return d
 }
 
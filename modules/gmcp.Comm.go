@@ -52,11 +52,16 @@ func (g *GMCPCommModule) onComm(e events.Event) events.ListenerReturn {
 	}
 
 	// Sent to everyone.
-	// say, party, broadcast, whisper
+	// say, party, broadcast, whisper, chat channels
 
 	sendToUserIds := []int{}
 
-	if evt.CommType == `say` {
+	if len(evt.Recipients) > 0 {
+
+		// Chat channels work out who is listening themselves
+		sendToUserIds = append([]int{}, evt.Recipients...)
+
+	} else if evt.CommType == `say` {
 
 		roomId := 0
 
@@ -105,6 +110,12 @@ func (g *GMCPCommModule) onComm(e events.Event) events.ListenerReturn {
 		//continue
 		//}
 
+		if evt.SourceUserId > 0 {
+			if u := users.GetByUserId(userId); u != nil && u.IsIgnoring(evt.SourceUserId) {
+				continue
+			}
+		}
+
 		events.AddToQueue(GMCPOut{
 			UserId:  userId,
 			Module:  `Comm.Channel`,
@@ -36,6 +36,8 @@ func init() {
 	events.RegisterListener(events.RoomChange{}, g.roomChangeHandler)
 	events.RegisterListener(events.PlayerDespawn{}, g.despawnHandler)
 	events.RegisterListener(GMCPRoomUpdate{}, g.buildAndSendGMCPPayload)
+	events.RegisterListener(events.WeatherChange{}, g.weatherChangeHandler)
+	events.RegisterListener(GMCPRoomMapRequest{}, g.roomMapHandler)
 
 }
 
@@ -52,6 +54,13 @@ type GMCPRoomUpdate struct {
 
 func (g GMCPRoomUpdate) Type() string { return `GMCPRoomUpdate` }
 
+// A client asking for the map of the zone they are in (Room.Map.Get)
+type GMCPRoomMapRequest struct {
+	ConnectionId uint64
+}
+
+func (g GMCPRoomMapRequest) Type() string { return `GMCPRoomMapRequest` }
+
 func (g *GMCPRoomModule) despawnHandler(e events.Event) events.ListenerReturn {
 
 	evt, typeOk := e.(events.PlayerDespawn)
@@ -151,6 +160,82 @@ func (g *GMCPRoomModule) roomChangeHandler(e events.Event) events.ListenerReturn
 	return events.Continue
 }
 
+func (g *GMCPRoomModule) weatherChangeHandler(e events.Event) events.ListenerReturn {
+
+	evt, typeOk := e.(events.WeatherChange)
+	if !typeOk {
+		mudlog.Error("Event", "Expected Type", "WeatherChange", "Actual Type", e.Type())
+		return events.Cancel
+	}
+
+	// Only players outdoors in the zone see the weather
+	for _, roomId := range rooms.GetRoomsWithPlayers() {
+
+		room := rooms.LoadRoom(roomId)
+		if room == nil || room.Zone != evt.Zone || !room.IsOutdoors() {
+			continue
+		}
+
+		for _, uId := range room.GetPlayers() {
+			events.AddToQueue(GMCPRoomUpdate{
+				UserId:     uId,
+				Identifier: `Room.Info`,
+			})
+		}
+	}
+
+	return events.Continue
+}
+
+// Sends the whole zone the player is in, so client mappers can lay it out in one go
+func (g *GMCPRoomModule) roomMapHandler(e events.Event) events.ListenerReturn {
+
+	evt, typeOk := e.(GMCPRoomMapRequest)
+	if !typeOk {
+		mudlog.Error("Event", "Expected Type", "GMCPRoomMapRequest", "Actual Type", e.Type())
+		return events.Cancel
+	}
+
+	user := users.GetByConnectionId(evt.ConnectionId)
+	if user == nil {
+		return events.Continue
+	}
+
+	room := rooms.LoadRoom(user.Character.RoomId)
+	if room == nil {
+		return events.Continue
+	}
+
+	m := mapper.GetZoneMapper(room.Zone)
+	if m == nil {
+		return events.Continue
+	}
+
+	export := m.ExportZone(room.Zone)
+
+	// Secret exits stay secret until the player has been through them, same as Room.Info
+	for i := range export.Rooms {
+		exits := export.Rooms[i].Exits[:0]
+		for _, exitInfo := range export.Rooms[i].Exits {
+			if exitInfo.Hidden {
+				if exitRoom := rooms.LoadRoom(exitInfo.Target); exitRoom == nil || !exitRoom.HasVisited(user.UserId, rooms.VisitorUser) {
+					continue
+				}
+			}
+			exits = append(exits, exitInfo)
+		}
+		export.Rooms[i].Exits = exits
+	}
+
+	events.AddToQueue(GMCPOut{
+		UserId:  user.UserId,
+		Module:  `Room.Map`,
+		Payload: export,
+	})
+
+	return events.Continue
+}
+
 func (g *GMCPRoomModule) buildAndSendGMCPPayload(e events.Event) events.ListenerReturn {
 
 	evt, typeOk := e.(GMCPRoomUpdate)
@@ -349,20 +434,31 @@ func (g *GMCPRoomModule) GetRoomNode(user *users.UserRecord, gmcpModule string)
 	if all || g.wantsGMCPPayload(`Room.Info`, gmcpModule) {
 
 		// Basic details
-		payload.Id = room.RoomId
+		// Rooms of a zone instance go by the roomId they were copied from, the same as Room.Map
+		payload.Id = rooms.GetSourceRoomId(room.RoomId)
 		payload.Name = room.Title
 		payload.Area = room.Zone
 		payload.Environment = room.GetBiome().Name()
 		payload.Details = []string{}
 
+		if w := room.GetWeather(); w != nil {
+			payload.Weather = w.WeatherId
+		}
+
 		// Coordinates
 		payload.Coordinates = room.Zone
-		m := mapper.GetZoneMapper(room.Zone)
-		x, y, z, err := m.GetCoordinates(room.RoomId)
-		if err != nil {
-			payload.Coordinates += `, 999999999999999999, 999999999999999999, 999999999999999999`
-		} else {
-			payload.Coordinates += `, ` + strconv.Itoa(x) + `, ` + strconv.Itoa(y) + `, ` + strconv.Itoa(z)
+		payload.Coordinates += `, 999999999999999999, 999999999999999999, 999999999999999999`
+
+		if m := mapper.GetZoneMapper(room.Zone); m != nil {
+			if x, y, z, err := m.GetCoordinates(rooms.GetSourceRoomId(room.RoomId)); err == nil {
+				payload.Coordinates = room.Zone + `, ` + strconv.Itoa(x) + `, ` + strconv.Itoa(y) + `, ` + strconv.Itoa(z)
+				payload.Map = &GMCPRoomModule_Payload_Map{
+					AreaId: mapper.GetAreaId(room.Zone),
+					X:      x,
+					Y:      y,
+					Z:      z,
+				}
+			}
 		}
 
 		// set exits
@@ -384,7 +480,7 @@ func (g *GMCPRoomModule) GetRoomNode(user *users.UserRecord, gmcpModule string)
 				//continue
 			}
 
-			payload.Exits[exitName] = exitInfo.RoomId
+			payload.Exits[exitName] = rooms.GetSourceRoomId(exitInfo.RoomId)
 
 			// Form the "exitV2"
 			deltaX, deltaY, deltaZ := 0, 0, 0
@@ -395,7 +491,7 @@ func (g *GMCPRoomModule) GetRoomNode(user *users.UserRecord, gmcpModule string)
 			}
 
 			exitV2 := GMCPRoomModule_Payload_Contents_ExitInfo{
-				RoomId:  exitInfo.RoomId,
+				RoomId:  rooms.GetSourceRoomId(exitInfo.RoomId),
 				DeltaX:  deltaX,
 				DeltaY:  deltaY,
 				DeltaZ:  deltaZ,
@@ -408,7 +504,11 @@ func (g *GMCPRoomModule) GetRoomNode(user *users.UserRecord, gmcpModule string)
 
 			if exitInfo.HasLock() {
 
-				exitV2.Details = append(exitV2.Details, `locked`)
+				exitV2.Details = append(exitV2.Details, `door`)
+
+				if exitInfo.Lock.IsLocked() {
+					exitV2.Details = append(exitV2.Details, `locked`)
+				}
 
 				lockId := fmt.Sprintf(`%d-%s`, room.RoomId, exitName)
 				haskey, hascombo := user.Character.HasKey(lockId, int(exitInfo.Lock.Difficulty))
@@ -474,13 +574,24 @@ type GMCPRoomModule_Payload struct {
 	Name        string                                              `json:"name"`
 	Area        string                                              `json:"area"`
 	Environment string                                              `json:"environment"`
+	Weather     string                                              `json:"weather"`
 	Coordinates string                                              `json:"coords"`
+	Map         *GMCPRoomModule_Payload_Map                         `json:"map,omitempty"`
 	Exits       map[string]int                                      `json:"exits"`
 	ExitsV2     map[string]GMCPRoomModule_Payload_Contents_ExitInfo `json:"exitsv2"`
 	Details     []string                                            `json:"details"`
 	Contents    GMCPRoomModule_Payload_Contents                     `json:"Contents"`
 }
 
+// Where the room sits on the zone map, for client mappers.
+// Left out entirely if the room couldn't be mapped.
+type GMCPRoomModule_Payload_Map struct {
+	AreaId int `json:"areaid"` // The same area id used by Room.Map
+	X      int `json:"x"`
+	Y      int `json:"y"`
+	Z      int `json:"z"`
+}
+
 type GMCPRoomModule_Payload_Contents_ExitInfo struct {
 	RoomId  int      `json:"num"`
 	DeltaX  int      `json:"dx"`
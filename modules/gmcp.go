@@ -149,6 +149,11 @@ func (g *GMCPModule) onNetConnect(n plugins.NetConnection) {
 
 	g.cache.Add(n.ConnectionId(), GMCPSettings{})
 
+	// SSH has no telnet layer to negotiate GMCP over
+	if n.IsSSH() {
+		return
+	}
+
 	g.sendGMCPEnableRequest(n.ConnectionId())
 }
 
@@ -341,6 +346,9 @@ func (g *GMCPModule) HandleIAC(connectionId uint64, iacCmd []byte) bool {
 				g.cache.Add(connectionId, gmcpData)
 
 			}
+		case `Room.Map.Get`:
+			// Building the map needs the game state, so leave that to the main loop
+			events.AddToQueue(GMCPRoomMapRequest{ConnectionId: connectionId})
 		case `Char.Login`:
 			decoded := GMCPLogin{}
 			if err := json.Unmarshal(payload, &decoded); err == nil {
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/GoMudEngine/GoMud/internal/configs"
+	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/mudlog"
+	"github.com/GoMudEngine/GoMud/internal/users"
+	"golang.org/x/crypto/ssh"
+)
+
+// Payload of a "pty-req" request (RFC 4254 6.2)
+type sshPtyRequest struct {
+	Term     string
+	Columns  uint32
+	Rows     uint32
+	WidthPx  uint32
+	HeightPx uint32
+	Modes    string
+}
+
+// Payload of a "window-change" request (RFC 4254 6.7)
+type sshWindowChange struct {
+	Columns  uint32
+	Rows     uint32
+	WidthPx  uint32
+	HeightPx uint32
+}
+
+// Same as TelnetListenOnPort, but accepts SSH connections
+func SSHListenOnPort(hostname string, portNum int, wg *sync.WaitGroup, maxConnections int, sshConfig *ssh.ServerConfig) net.Listener {
+
+	server, err := net.Listen("tcp", fmt.Sprintf("%s:%d", hostname, portNum))
+	if err != nil {
+		mudlog.Error("Error creating SSH server", "error", err)
+		return nil
+	}
+
+	mudlog.Info("SSH", "stage", "Listening", "port", portNum)
+
+	// Start a goroutine to accept incoming connections, so that we can use a signal to stop the server
+	go func() {
+
+		for {
+			conn, err := server.Accept()
+
+			if !serverAlive.Load() {
+				mudlog.Warn("Connections disabled.")
+				return
+			}
+
+			if err != nil {
+				mudlog.Warn("Connection error", "error", err)
+				continue
+			}
+
+			wg.Add(1)
+			go handleSSHConnection(conn, wg, maxConnections, sshConfig)
+		}
+	}()
+
+	return server
+}
+
+// Builds the SSH server configuration, loading (or creating) the host key.
+// Returns nil if the host key can't be loaded.
+func getSSHServerConfig() *ssh.ServerConfig {
+
+	hostKeyFile := configs.GetFilePathsConfig().SshHostKeyFile.String()
+
+	hostKey, err := loadSSHHostKey(hostKeyFile)
+	if err != nil {
+		mudlog.Error("SSH", "stage", "skipping", "error", fmt.Errorf("Error loading host key: %w", err), "Host Key", hostKeyFile)
+		return nil
+	}
+
+	sshConfig := &ssh.ServerConfig{
+		ServerVersion: `SSH-2.0-GoMud`,
+
+		// A key added with the "sshkey" command logs them straight in
+		PublicKeyCallback: func(meta ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+
+			user, err := users.LoadUser(meta.User(), true)
+			if err != nil || !user.HasSSHKey(key) {
+				return nil, errors.New("unknown public key")
+			}
+
+			return &ssh.Permissions{
+				Extensions: map[string]string{
+					connections.SSHUserIdExtension: strconv.Itoa(user.UserId),
+				},
+			}, nil
+		},
+
+		// Anyone else is let through without any questions, and logs in
+		// (or creates a new user) using the normal login prompts.
+		KeyboardInteractiveCallback: func(meta ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+			return &ssh.Permissions{}, nil
+		},
+	}
+
+	sshConfig.AddHostKey(hostKey)
+
+	return sshConfig
+}
+
+// Loads the host key from a file, generating and saving a new ed25519 key if the file doesn't exist
+func loadSSHHostKey(hostKeyFile string) (ssh.Signer, error) {
+
+	keyBytes, err := os.ReadFile(hostKeyFile)
+	if err == nil {
+		return ssh.ParsePrivateKey(keyBytes)
+	}
+
+	if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	pemBlock, err := ssh.MarshalPrivateKey(privateKey, `GoMud host key`)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(hostKeyFile), 0755); err != nil {
+		return nil, err
+	}
+
+	if err := os.WriteFile(hostKeyFile, pem.EncodeToMemory(pemBlock), 0600); err != nil {
+		return nil, err
+	}
+
+	mudlog.Info("SSH", "stage", "Generated new host key", "Host Key", hostKeyFile)
+
+	return ssh.NewSignerFromKey(privateKey)
+}
+
+func handleSSHConnection(conn net.Conn, wg *sync.WaitGroup, maxConnections int, sshConfig *ssh.ServerConfig) {
+	defer wg.Done()
+
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+
+	serverConn, newChannels, requests, err := ssh.NewServerConn(conn, sshConfig)
+	if err != nil {
+		mudlog.Warn("SSH", "remoteAddr", conn.RemoteAddr().String(), "error", err)
+		conn.Close()
+		return
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	// Global requests (such as keepalives) aren't used
+	go ssh.DiscardRequests(requests)
+
+	sessionStarted := false
+
+	for newChannel := range newChannels {
+
+		if newChannel.ChannelType() != `session` {
+			newChannel.Reject(ssh.UnknownChannelType, `unknown channel type`)
+			continue
+		}
+
+		// Each connection is a single player, so only one session is allowed
+		if sessionStarted {
+			newChannel.Reject(ssh.Prohibited, `only one session is allowed per connection`)
+			continue
+		}
+
+		if maxConnections > 0 {
+			if ct := connections.ActiveConnectionCount(); ct >= maxConnections {
+				newChannel.Reject(ssh.ResourceShortage, fmt.Sprintf("Server is full (%d connections). Try again later.", ct))
+				serverConn.Close()
+				continue
+			}
+		}
+
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			mudlog.Warn("SSH", "remoteAddr", conn.RemoteAddr().String(), "error", err)
+			continue
+		}
+
+		sessionStarted = true
+
+		wg.Add(1)
+		go handleSSHSession(
+			connections.Add(connections.NewSSHConn(channel, serverConn), nil),
+			channelRequests,
+			wg,
+		)
+	}
+}
+
+// Handles the requests of a session, and once a shell is requested hands the session off
+// to the same handler telnet connections use.
+func handleSSHSession(connDetails *connections.ConnectionDetails, requests <-chan *ssh.Request, wg *sync.WaitGroup) {
+
+	connectionId := connDetails.ConnectionId()
+
+	shellRequested := make(chan struct{})
+	requestsDone := make(chan struct{})
+
+	go func() {
+		defer close(requestsDone)
+
+		shellStarted := false
+
+		for req := range requests {
+
+			ok := false
+
+			switch req.Type {
+
+			case `pty-req`:
+				ptyReq := sshPtyRequest{}
+				if err := ssh.Unmarshal(req.Payload, &ptyReq); err == nil {
+					cs := connections.GetClientSettings(connectionId)
+					cs.TerminalType = ptyReq.Term
+					setSSHScreenSize(&cs, ptyReq.Columns, ptyReq.Rows)
+					connections.OverwriteClientSettings(connectionId, cs)
+
+					mudlog.Debug("Received", "type", "SSH (pty-req)", "terminal", ptyReq.Term, "width", ptyReq.Columns, "height", ptyReq.Rows)
+					ok = true
+				}
+
+			// Works like NAWS, the client reports whenever the window is resized
+			case `window-change`:
+				winChange := sshWindowChange{}
+				if err := ssh.Unmarshal(req.Payload, &winChange); err == nil {
+					cs := connections.GetClientSettings(connectionId)
+					setSSHScreenSize(&cs, winChange.Columns, winChange.Rows)
+					connections.OverwriteClientSettings(connectionId, cs)
+
+					mudlog.Debug("Received", "type", "SSH (window-change)", "width", winChange.Columns, "height", winChange.Rows)
+					ok = true
+				}
+
+			case `shell`:
+				ok = !shellStarted
+
+			}
+
+			// Commands (exec) and subsystems (sftp etc.) are refused
+			if req.WantReply {
+				req.Reply(ok, nil)
+			}
+
+			if req.Type == `shell` && ok {
+				shellStarted = true
+				close(shellRequested)
+			}
+		}
+	}()
+
+	select {
+	case <-shellRequested:
+	case <-requestsDone:
+		connections.Remove(connectionId)
+		wg.Done()
+		return
+	case <-time.After(30 * time.Second):
+		connections.Remove(connectionId)
+		wg.Done()
+		return
+	}
+
+	handleTelnetConnection(connDetails, wg)
+}
+
+// Zero means the client didn't report that dimension
+func setSSHScreenSize(cs *connections.ClientSettings, columns uint32, rows uint32) {
+	if columns > 0 {
+		cs.Display.ScreenWidth = columns
+	}
+	if rows > 0 {
+		cs.Display.ScreenHeight = rows
+	}
+}
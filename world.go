@@ -10,8 +10,11 @@ import (
 	"time"
 
 	"github.com/GoMudEngine/GoMud/internal/badinputtracker"
+	"github.com/GoMudEngine/GoMud/internal/channels"
+	"github.com/GoMudEngine/GoMud/internal/clans"
 	"github.com/GoMudEngine/GoMud/internal/configs"
 	"github.com/GoMudEngine/GoMud/internal/connections"
+	"github.com/GoMudEngine/GoMud/internal/duels"
 	"github.com/GoMudEngine/GoMud/internal/events"
 	"github.com/GoMudEngine/GoMud/internal/items"
 	"github.com/GoMudEngine/GoMud/internal/keywords"
@@ -20,6 +23,7 @@ import (
 	"github.com/GoMudEngine/GoMud/internal/mudlog"
 	"github.com/GoMudEngine/GoMud/internal/prompt"
 	"github.com/GoMudEngine/GoMud/internal/rooms"
+	"github.com/GoMudEngine/GoMud/internal/scripting"
 	"github.com/GoMudEngine/GoMud/internal/templates"
 	"github.com/GoMudEngine/GoMud/internal/term"
 	"github.com/GoMudEngine/GoMud/internal/usercommands"
@@ -708,7 +712,17 @@ loop:
 			if err := rooms.SaveAllRooms(); err != nil {
 				mudlog.Error("rooms.SaveAllRooms()", "error", err.Error())
 			}
-			users.SaveAllUsers() // Save all user data too.
+			duels.CancelAll()                    // Bets aren't saved, so give them back before users are.
+			rooms.SaveAllUsersOutsideInstances() // Save all user data too.
+			if err := clans.SaveAllClans(); err != nil {
+				mudlog.Error("clans.SaveAllClans()", "error", err.Error())
+			}
+			if err := channels.SaveAllChannels(); err != nil {
+				mudlog.Error("channels.SaveAllChannels()", "error", err.Error())
+			}
+			if err := scripting.SaveScriptStore(); err != nil {
+				mudlog.Error("scripting.SaveScriptStore()", "error", err.Error())
+			}
 			util.UnlockMud()
 
 			break loop
@@ -911,6 +925,13 @@ func (w *World) processInput(userId int, inputText string, flags events.EventFla
 				}
 			}
 
+			// Lets users speedwalk without typing run, as long as there's a count in it (3n2e)
+			if strings.ContainsAny(inputText, `0123456789`) {
+				if _, ok := usercommands.ParseSpeedwalk(inputText); ok {
+					inputText = `run ` + inputText
+				}
+			}
+
 			if index := strings.Index(inputText, " "); index != -1 {
 				command, remains = strings.ToLower(inputText[0:index]), inputText[index+1:]
 			} else {
@@ -1021,6 +1042,8 @@ func (w *World) UpdateStats() {
 
 	s.WebSocketPort = int(c.HttpPort)
 
+	s.Compression = connections.GetCompressionStats()
+
 	web.UpdateStats(s)
 }
 